@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"go.uber.org/zap"
 
 	"github.com/code-xd/k8s-deployment-manager/internal/api"
@@ -8,7 +10,9 @@ import (
 	natscommon "github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres"
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/templates"
 	"github.com/code-xd/k8s-deployment-manager/internal/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/internal/service/workerService"
 	"github.com/code-xd/k8s-deployment-manager/pkg/config"
 	"github.com/code-xd/k8s-deployment-manager/pkg/constants"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
@@ -31,6 +35,15 @@ import (
 
 // @BasePath  /api/v1
 
+// @securityDefinitions.apikey  ApiKeyAuth
+// @in                          header
+// @name                        X-API-Key
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 HMAC-signed JWT as "Bearer <token>"
+
 // main is the composition root - this is the ONLY place where concrete implementations
 // should be instantiated. All other layers interact via interfaces from pkg/ports/
 func main() {
@@ -64,21 +77,64 @@ func main() {
 	}
 	defer natsConn.Close()
 
-	// Ensure JetStream stream exists (stream name and subjects from config)
+	// Ensure JetStream stream exists (stream name, subjects and settings from config)
 	prod := &apiCfg.Nats.Producer
-	subjects := []string{prod.DeploymentRequestChannel, prod.DeploymentUpdateChannel}
-	if err := natsConn.EnsureStream(prod.StreamName, subjects); err != nil {
+	if err := natsConn.EnsureStream(prod); err != nil {
 		dto.Log.Fatal("Failed to ensure JetStream stream", zap.Error(err))
 	}
 
 	natsProducer := natscommon.NewProducer(natsConn)
 	deploymentRequestPublisher := nats.NewDeploymentRequestProducer(natsProducer, prod)
+	deadLetterPublisher := nats.NewDeadLetterProducer(natsProducer)
+	outboxPublisher := nats.NewOutboxProducer(natsProducer)
+	deploymentStatusSubscriber := nats.NewDeploymentStatusSubscriber(natsConn, prod, dto.Log)
 
 	// Initialize repositories (concrete implementations - OK in composition root)
 	// These implement interfaces from pkg/ports/ and are injected as interfaces
 	deploymentRequestRepo := postgres.NewDeploymentRequestRepository(db)
 	deploymentRepo := postgres.NewDeploymentRepository(db)
+	deploymentRevisionRepo := postgres.NewDeploymentRevisionRepository(db)
 	userRepo := postgres.NewUserRepository(db)
+	deadLetterRepo := postgres.NewDeadLetterRepository(db)
+	templateRegistry, err := templates.NewRegistry(".")
+	if err != nil {
+		dto.Log.Fatal("Failed to load deployment templates", zap.Error(err))
+	}
+	outboxRepo := postgres.NewOutboxRepository(db)
+	apiKeyRepo := postgres.NewAPIKeyRepository(db)
+	teamRepo := postgres.NewTeamRepository(db)
+	webhookRepo := postgres.NewWebhookRepository(db)
+	deploymentScheduleRepo := postgres.NewDeploymentScheduleRepository(db)
+
+	// Initialize the authenticator chain selected in auth config; the JWT secret may come from the environment
+	if secret := os.Getenv(constants.JWT_SECRET_ENV_VAR); secret != "" {
+		apiCfg.Auth.JWT.Secret = secret
+	}
+	authenticator, err := apiService.NewAuthenticator(
+		apiCfg.Auth,
+		userRepo,
+		apiKeyRepo,
+		dto.Log,
+	)
+	if err != nil {
+		dto.Log.Fatal("Failed to initialize authenticator", zap.Error(err))
+	}
+
+	// Initialize the team policy that authorizes access to deployments by owner and team role
+	policy := apiService.NewTeamPolicy(teamRepo)
+
+	// Initialize the admission checks (namespace allowlists and quotas) from admission config
+	admission, err := apiService.NewAdmissionService(
+		apiCfg.Admission,
+		deploymentRepo,
+		deploymentRequestRepo,
+		teamRepo,
+		userRepo,
+		dto.Log,
+	)
+	if err != nil {
+		dto.Log.Fatal("Failed to initialize admission", zap.Error(err))
+	}
 
 	// Initialize services (concrete implementations - OK in composition root)
 	// Service receives repo interfaces (ports/repo/db, ports/repo/queue) and returns ports/service/apiService.DeploymentRequest
@@ -86,21 +142,89 @@ func main() {
 		deploymentRequestRepo,
 		deploymentRepo,
 		deploymentRequestPublisher,
+		deploymentStatusSubscriber,
+		templateRegistry,
+		policy,
+		admission,
 		dto.Log,
 	)
 
 	// Initialize deployment service
 	deployment := apiService.NewDeploymentService(
 		deploymentRepo,
+		deploymentRevisionRepo,
+		policy,
 		dto.Log,
 	)
 
+	// Initialize dead-letter service
+	deadLetter := apiService.NewDeadLetterService(
+		deadLetterRepo,
+		deploymentRequestRepo,
+		deadLetterPublisher,
+		dto.Log,
+	)
+
+	// Initialize template service
+	template := apiService.NewTemplateService(
+		templateRegistry,
+		dto.Log,
+	)
+
+	// Initialize API key service
+	apiKey := apiService.NewAPIKeyService(
+		apiKeyRepo,
+		dto.Log,
+	)
+
+	// Initialize team service
+	team := apiService.NewTeamService(
+		teamRepo,
+		userRepo,
+		policy,
+		dto.Log,
+	)
+
+	// Initialize webhook service
+	webhook := apiService.NewWebhookService(
+		webhookRepo,
+		policy,
+		dto.Log,
+	)
+
+	// Initialize deployment schedule service
+	deploymentSchedule := apiService.NewDeploymentScheduleService(
+		deploymentScheduleRepo,
+		deploymentRepo,
+		templateRegistry,
+		policy,
+		admission,
+		dto.Log,
+	)
+
+	// Start the outbox relay that publishes deployment request messages to NATS
+	outboxRelay := workerService.NewOutboxRelayService(
+		outboxRepo,
+		outboxPublisher,
+		apiCfg.Outbox,
+		dto.Log,
+	)
+	outboxRelay.Start()
+	defer outboxRelay.Stop()
+
 	// Setup router with injected service dependencies (as interface from pkg/ports/service/apiService)
 	router := api.SetupRouter(
 		dto.Log,
 		deploymentRequest,
 		deployment,
-		userRepo,
+		deadLetter,
+		template,
+		apiKey,
+		team,
+		admission,
+		webhook,
+		deploymentSchedule,
+		authenticator,
 		deploymentRequestRepo,
 	)
 	server := api.NewServer(dto.APICfg, router)
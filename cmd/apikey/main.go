@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/config"
+	"github.com/code-xd/k8s-deployment-manager/pkg/constants"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/code-xd/k8s-deployment-manager/pkg/logger"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+)
+
+// main creates an API key for a user (creating the user if needed) and prints it once.
+// Usage: go run cmd/apikey/main.go -user <external id> -name <key name> [-scopes "deployments:read ..."] [-expires 720h]
+func main() {
+	userExternalID := flag.String("user", "", "external ID of the user the key belongs to (required)")
+	name := flag.String("name", "", "name of the key (required)")
+	scopes := flag.String("scopes", utils.JoinScopes(dto.AllScopes), "space-delimited scopes granted to the key")
+	expires := flag.Duration("expires", 0, "lifetime of the key; 0 never expires")
+	flag.Parse()
+
+	dto.Log = logger.New()
+	defer dto.Log.Sync()
+
+	if *userExternalID == "" || *name == "" {
+		dto.Log.Fatal("-user and -name are required")
+	}
+	for _, scope := range utils.ParseScopes(*scopes) {
+		if !slices.Contains(dto.AllScopes, scope) {
+			dto.Log.Fatal("Unknown scope", zap.String("scope", scope), zap.Strings("allowed", dto.AllScopes))
+		}
+	}
+
+	cfg := config.NewConfigLoader[dto.APIConfig](
+		constants.DEFAULT_CONFIG_PATH,
+		constants.DEFAULT_CONFIG_FILE,
+	)
+	apiCfg, err := cfg.Load()
+	if err != nil {
+		dto.Log.Fatal("Failed to load config", zap.Error(err))
+	}
+
+	db, err := common.NewDB(&apiCfg.Database, dto.Log)
+	if err != nil {
+		dto.Log.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	user, err := postgres.NewUserRepository(db).GetOrCreate(ctx, *userExternalID)
+	if err != nil {
+		dto.Log.Fatal("Failed to get or create user", zap.Error(err))
+	}
+
+	key, keyID, secretHash, err := utils.GenerateAPIKey()
+	if err != nil {
+		dto.Log.Fatal("Failed to generate api key", zap.Error(err))
+	}
+	apiKey := &models.APIKey{
+		UserID:     user.ID,
+		Name:       *name,
+		KeyID:      keyID,
+		SecretHash: secretHash,
+		Scopes:     *scopes,
+	}
+	if *expires > 0 {
+		expiresOn := time.Now().Add(*expires)
+		apiKey.ExpiresOn = &expiresOn
+	}
+	if err := postgres.NewAPIKeyRepository(db).Create(ctx, apiKey); err != nil {
+		dto.Log.Fatal("Failed to create api key", zap.Error(err))
+	}
+
+	fmt.Println(key)
+}
@@ -21,6 +21,16 @@ func main() {
 		models.User{},
 		models.DeploymentRequest{},
 		models.Deployment{},
+		models.DeadLetter{},
+		models.OutboxMessage{},
+		models.DeploymentRevision{},
+		models.APIKey{},
+		models.Team{},
+		models.TeamMembership{},
+		models.WebhookSubscription{},
+		models.WebhookDelivery{},
+		models.WebhookDeliveryAttempt{},
+		models.DeploymentSchedule{},
 	)
 
 	// Execute the generator
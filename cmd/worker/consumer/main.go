@@ -4,9 +4,12 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/k8sclient"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats"
 	natscommon "github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres"
 	pgcommon "github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/templates"
+	"github.com/code-xd/k8s-deployment-manager/internal/service/apiService"
 	"github.com/code-xd/k8s-deployment-manager/internal/service/workerService"
 	"github.com/code-xd/k8s-deployment-manager/internal/worker"
 	"github.com/code-xd/k8s-deployment-manager/pkg/config"
@@ -49,24 +52,38 @@ func main() {
 
 	// Ensure JetStream stream exists
 	prod := &workerCfg.Nats.Producer
-	subjects := []string{prod.DeploymentRequestChannel, prod.DeploymentUpdateChannel}
-	if err := natsConn.EnsureStream(prod.StreamName, subjects); err != nil {
+	if err := natsConn.EnsureStream(prod); err != nil {
 		log.Fatal("Failed to ensure JetStream stream", zap.Error(err))
 	}
 
+	natsProducer := natscommon.NewProducer(natsConn)
+	deploymentRequestPublisher := nats.NewDeploymentRequestProducer(natsProducer, prod)
+	deploymentStatusPublisher := nats.NewDeploymentStatusProducer(natsProducer, prod)
+	webhookEventPublisher := nats.NewWebhookEventProducer(natsProducer, prod)
+	outboxPublisher := nats.NewOutboxProducer(natsProducer)
+
 	// Initialize repositories
 	deploymentRequestRepo := postgres.NewDeploymentRequestRepository(db)
 	deploymentRepo := postgres.NewDeploymentRepository(db)
-	k8sDeploymentManager, err := k8sclient.NewDeploymentManager(".", &workerCfg.K8s, log)
+	deploymentRevisionRepo := postgres.NewDeploymentRevisionRepository(db)
+	deadLetterRepo := postgres.NewDeadLetterRepository(db)
+	deploymentScheduleRepo := postgres.NewDeploymentScheduleRepository(db)
+	outboxRepo := postgres.NewOutboxRepository(db)
+	templateRegistry, err := templates.NewRegistry(".")
+	if err != nil {
+		log.Fatal("Failed to load deployment templates", zap.Error(err))
+	}
+	k8sDeploymentManager, err := k8sclient.NewDeploymentManager(".", templateRegistry, &workerCfg.K8s, log)
 	if err != nil {
 		log.Fatal("Failed to create k8s deployment manager", zap.Error(err))
 	}
 
 	// Create consumer and wire services
 	nc := consumer.NewNATSConsumer(natsConn.JS, natsConn.Conn, log, workerCfg.Consumer.ShutdownTimeout)
-	deploymentRequest := workerService.NewDeploymentRequestService(deploymentRequestRepo, k8sDeploymentManager, log)
-	deploymentUpdate := workerService.NewDeploymentUpdateService(deploymentRepo, k8sDeploymentManager, log)
-	worker.SetupRouter(nc, &workerCfg.Consumer, deploymentRequest, deploymentUpdate, log)
+	deploymentRequest := workerService.NewDeploymentRequestService(deploymentRequestRepo, k8sDeploymentManager, deploymentStatusPublisher, webhookEventPublisher, log)
+	deploymentUpdate := workerService.NewDeploymentUpdateService(deploymentRepo, deploymentRevisionRepo, k8sDeploymentManager, webhookEventPublisher, log)
+	deadLetter := workerService.NewDeadLetterService(deadLetterRepo, log)
+	worker.SetupRouter(nc, &workerCfg.Consumer, deploymentRequest, deploymentUpdate, deadLetter, log)
 
 	// Start consuming
 	if err := nc.Run(); err != nil {
@@ -78,6 +95,60 @@ func main() {
 	// Defer shutdown - runs when main returns (after WaitForShutdown)
 	defer nc.Shutdown()
 
+	// Start the outbox relay that publishes the messages written by the reaper and scheduler, so they
+	// reach NATS even when no API replica is running
+	outboxRelay := workerService.NewOutboxRelayService(
+		outboxRepo,
+		outboxPublisher,
+		workerCfg.Outbox,
+		log,
+	)
+	outboxRelay.Start()
+	defer outboxRelay.Stop()
+
+	// Start the reaper that repairs deployment requests stuck in CREATED
+	if workerCfg.Reaper.Enabled {
+		reaper := workerService.NewReaperService(
+			deploymentRequestRepo,
+			deploymentRequestPublisher,
+			deploymentStatusPublisher,
+			webhookEventPublisher,
+			k8sDeploymentManager,
+			workerCfg.Reaper,
+			log,
+		)
+		reaper.Start()
+		defer reaper.Stop()
+	}
+
+	// Start the scheduler that emits the UPDATE requests of due scaling schedules
+	if workerCfg.Scheduler.Enabled {
+		// Each run is admitted with the API's quotas, so a schedule cannot grow a deployment past them
+		admission, err := apiService.NewAdmissionService(
+			workerCfg.Admission,
+			deploymentRepo,
+			deploymentRequestRepo,
+			postgres.NewTeamRepository(db),
+			postgres.NewUserRepository(db),
+			log,
+		)
+		if err != nil {
+			log.Fatal("Failed to create admission service", zap.Error(err))
+		}
+		scheduler := workerService.NewSchedulerService(
+			deploymentScheduleRepo,
+			deploymentRepo,
+			deploymentRequestPublisher,
+			deploymentStatusPublisher,
+			webhookEventPublisher,
+			admission,
+			workerCfg.Scheduler,
+			log,
+		)
+		scheduler.Start()
+		defer scheduler.Stop()
+	}
+
 	// Block until shutdown signal
 	utils.WaitForShutdown()
 }
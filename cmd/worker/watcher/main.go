@@ -36,8 +36,7 @@ func main() {
 	defer natsConn.Close()
 
 	prod := &workerCfg.Nats.Producer
-	subjects := []string{prod.DeploymentRequestChannel, prod.DeploymentUpdateChannel}
-	if err := natsConn.EnsureStream(prod.StreamName, subjects); err != nil {
+	if err := natsConn.EnsureStream(prod); err != nil {
 		log.Fatal("Failed to ensure JetStream stream", zap.Error(err))
 	}
 
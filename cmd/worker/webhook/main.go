@@ -0,0 +1,86 @@
+package main
+
+import (
+	"go.uber.org/zap"
+
+	natscommon "github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres"
+	pgcommon "github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/webhook"
+	"github.com/code-xd/k8s-deployment-manager/internal/service/workerService"
+	"github.com/code-xd/k8s-deployment-manager/internal/worker"
+	"github.com/code-xd/k8s-deployment-manager/pkg/config"
+	"github.com/code-xd/k8s-deployment-manager/pkg/constants"
+	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/logger"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+)
+
+func main() {
+	// Initialize logger
+	log := logger.New()
+	defer log.Sync()
+	dto.Log = log
+
+	// Load config
+	cfg := config.NewConfigLoader[dto.WebhookWorkerConfig](
+		constants.DEFAULT_CONFIG_PATH,
+		constants.DEFAULT_CONFIG_FILE,
+	)
+	webhookCfg, err := cfg.Load()
+	if err != nil {
+		log.Fatal("Failed to load config", zap.Error(err))
+	}
+
+	// Initialize database connection
+	db, err := pgcommon.NewDB(&webhookCfg.Database, log)
+	if err != nil {
+		log.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer db.Close()
+
+	// Initialize NATS connection
+	natsConn, err := natscommon.NewNATS(&webhookCfg.Nats, log)
+	if err != nil {
+		log.Fatal("Failed to connect to NATS", zap.Error(err))
+	}
+	defer natsConn.Close()
+
+	// Ensure JetStream stream exists
+	if err := natsConn.EnsureStream(&webhookCfg.Nats.Producer); err != nil {
+		log.Fatal("Failed to ensure JetStream stream", zap.Error(err))
+	}
+
+	// Initialize repositories
+	webhookRepo := postgres.NewWebhookRepository(db)
+	teamRepo := postgres.NewTeamRepository(db)
+
+	// Create consumer and wire services
+	nc := consumer.NewNATSConsumer(natsConn.JS, natsConn.Conn, log, webhookCfg.Webhook.ShutdownTimeout)
+	webhookEvent := workerService.NewWebhookEventService(webhookRepo, teamRepo, log)
+	worker.SetupWebhookRouter(nc, &webhookCfg.Webhook, webhookEvent, log)
+
+	// Start consuming
+	if err := nc.Run(); err != nil {
+		log.Fatal("Failed to start consumer", zap.Error(err))
+	}
+
+	log.Info("Webhook consumer started", zap.String("channel", webhookCfg.Webhook.EventTask.Channel))
+
+	// Defer shutdown - runs when main returns (after WaitForShutdown)
+	defer nc.Shutdown()
+
+	// Start the dispatcher that sends due deliveries
+	dispatcher := workerService.NewWebhookDispatcherService(
+		webhookRepo,
+		webhook.NewHTTPSender(webhookCfg.Webhook.RequestTimeout, webhookCfg.Webhook.AllowPrivateNetworks),
+		webhookCfg.Webhook,
+		log,
+	)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	// Block until shutdown signal
+	utils.WaitForShutdown()
+}
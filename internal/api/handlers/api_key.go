@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler handles API key management requests
+type APIKeyHandler struct {
+	apiKeyService portsapi.APIKey
+	authenticator portsapi.Authenticator
+	log           *zap.Logger
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance with injected dependencies
+func NewAPIKeyHandler(
+	apiKeyService portsapi.APIKey,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		authenticator: authenticator,
+		log:           log,
+	}
+}
+
+// GetRoutes returns all API key route definitions
+func (h *APIKeyHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "POST",
+			Path:   dto.PathAPIKeys,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeAPIKeysManage, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.CreateAPIKeyRequest](h.CreateAPIKey),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathAPIKeys,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeAPIKeysManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListAPIKeys),
+		},
+		{
+			Method: "DELETE",
+			Path:   dto.PathAPIKeyByID,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeAPIKeysManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.RevokeAPIKey),
+		},
+	}
+}
+
+// CreateAPIKey handles POST /api/v1/api-keys
+// @Summary      Create an API key
+// @Description  Creates an API key for the authenticated user with the given scopes. The key is only returned in this response. Scopes the caller's own credentials lack cannot be granted.
+// @Tags         APIKeyService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      dto.CreateAPIKeyRequest  true  "API key name, scopes and lifetime"
+// @Success      201      {object}  dto.SuccessResponse{data=dto.CreateAPIKeyResponse}
+// @Failure      401      {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403      {object}  dto.ErrorResponse  "Missing api_keys:manage scope or requested scope not granted to caller"
+// @Failure      422      {object}  dto.ErrorResponse  "Validation failed"
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context, req *dto.CreateAPIKeyRequest) {
+	principal, err := middleware.GetPrincipalFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	apiKey, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), principal, req)
+	if err != nil {
+		if errors.Is(err, dto.ErrScopeNotGranted) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientScope,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToCreateAPIKey,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: dto.MsgAPIKeyCreated,
+		Data:    apiKey,
+	})
+}
+
+// ListAPIKeys handles GET /api/v1/api-keys
+// @Summary      List API keys
+// @Description  Returns the authenticated user's API keys, including revoked and expired ones. Secrets are never returned.
+// @Tags         APIKeyService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.APIKeyResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing api_keys:manage scope"
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	apiKeys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), userID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListAPIKeys,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgAPIKeysRetrieved,
+		Data:    apiKeys,
+	})
+}
+
+// RevokeAPIKey handles DELETE /api/v1/api-keys/:id
+// @Summary      Revoke an API key
+// @Description  Revokes one of the authenticated user's API keys; requests made with it are rejected from then on
+// @Tags         APIKeyService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID of the API key"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.APIKeyResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing api_keys:manage scope"
+// @Failure      404  {object}  dto.ErrorResponse  "API key not found"
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	id := c.Param(dto.ParamID)
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgAPIKeyIDInvalid,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	apiKey, err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), id, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgAPIKeyNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToRevokeAPIKey,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgAPIKeyRevoked,
+		Data:    apiKey,
+	})
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeadLetterHandler handles dead-lettered message requests
+type DeadLetterHandler struct {
+	deadLetterService portsapi.DeadLetter
+	authenticator     portsapi.Authenticator
+	log               *zap.Logger
+}
+
+// NewDeadLetterHandler creates a new DeadLetterHandler instance with injected dependencies
+func NewDeadLetterHandler(
+	deadLetterService portsapi.DeadLetter,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetterService: deadLetterService,
+		authenticator:     authenticator,
+		log:               log,
+	}
+}
+
+// GetRoutes returns all dead-letter route definitions
+func (h *DeadLetterHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "GET",
+			Path:   dto.PathDeadLettersList,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeRequestsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListDeadLetters),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathDeadLetterReplay,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ReplayDeadLetter),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathAdminDeadLettersList,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeadLettersAdmin, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListAllDeadLetters),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathAdminDeadLetterReplay,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeadLettersAdmin, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ReplayAnyDeadLetter),
+		},
+	}
+}
+
+// ListDeadLetters handles GET /api/v1/dead-letters
+// @Summary      List dead-lettered messages for the authenticated user
+// @Description  Returns messages whose retries were exhausted and that were moved to the dead-letter channel
+// @Tags         DeadLetterService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200        {object}  dto.SuccessResponse{data=[]dto.DeadLetterResponse}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing requests:read scope"
+// @Router       /dead-letters [get]
+func (h *DeadLetterHandler) ListDeadLetters(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	deadLetters, err := h.deadLetterService.ListDeadLetters(c.Request.Context(), userID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListDeadLetters,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeadLettersRetrieved,
+		Data:    deadLetters,
+	})
+}
+
+// ReplayDeadLetter handles POST /api/v1/dead-letters/:id/replay
+// @Summary      Replay a dead-lettered message
+// @Description  Republishes the original payload and headers onto the original channel. A FAILURE deployment request is reset to CREATED first.
+// @Tags         DeadLetterService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID of the dead-lettered message"
+// @Success      200        {object}  dto.SuccessResponse{data=dto.DeadLetterResponse}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:write scope"
+// @Failure      404        {object}  dto.ErrorResponse  "Dead-lettered message not found"
+// @Router       /dead-letters/{id}/replay [post]
+func (h *DeadLetterHandler) ReplayDeadLetter(c *gin.Context) {
+	h.replay(c, h.deadLetterService.ReplayDeadLetter)
+}
+
+// ListAllDeadLetters handles GET /api/v1/admin/dead-letters
+// @Summary      List every dead-lettered message
+// @Description  Returns dead-lettered messages of all users, including messages recorded without an owner (e.g. watcher events)
+// @Tags         DeadLetterService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200        {object}  dto.SuccessResponse{data=[]dto.DeadLetterResponse}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing dead_letters:admin scope"
+// @Router       /admin/dead-letters [get]
+func (h *DeadLetterHandler) ListAllDeadLetters(c *gin.Context) {
+	deadLetters, err := h.deadLetterService.ListAllDeadLetters(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListDeadLetters,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeadLettersRetrieved,
+		Data:    deadLetters,
+	})
+}
+
+// ReplayAnyDeadLetter handles POST /api/v1/admin/dead-letters/:id/replay
+// @Summary      Replay any dead-lettered message
+// @Description  Replays a dead-lettered message regardless of its owner, including messages recorded without one
+// @Tags         DeadLetterService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id         path      string  true  "ID of the dead-lettered message"
+// @Success      200        {object}  dto.SuccessResponse{data=dto.DeadLetterResponse}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing dead_letters:admin scope"
+// @Failure      404        {object}  dto.ErrorResponse  "Dead-lettered message not found"
+// @Router       /admin/dead-letters/{id}/replay [post]
+func (h *DeadLetterHandler) ReplayAnyDeadLetter(c *gin.Context) {
+	h.replay(c, h.deadLetterService.ReplayAnyDeadLetter)
+}
+
+// replay runs a replay service call for the :id path parameter and writes the response
+func (h *DeadLetterHandler) replay(
+	c *gin.Context,
+	replay func(ctx context.Context, id string, userID string) (*dto.DeadLetterResponse, error),
+) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	id := c.Param(dto.ParamID)
+	if id == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgDeadLetterIDInvalid,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	deadLetter, err := replay(c.Request.Context(), id, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrDeadLetterNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeadLetterNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToReplayDeadLetter,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeadLetterReplayed,
+		Data:    deadLetter,
+	})
+}
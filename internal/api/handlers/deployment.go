@@ -3,10 +3,10 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
-	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -15,19 +15,19 @@ import (
 // DeploymentHandler handles deployment-related requests
 type DeploymentHandler struct {
 	deploymentService portsapi.Deployment
-	userRepo          portsdb.User
+	authenticator     portsapi.Authenticator
 	log               *zap.Logger
 }
 
 // NewDeploymentHandler creates a new DeploymentHandler instance with injected dependencies
 func NewDeploymentHandler(
 	deploymentService portsapi.Deployment,
-	userRepo portsdb.User,
+	authenticator portsapi.Authenticator,
 	log *zap.Logger,
 ) *DeploymentHandler {
 	return &DeploymentHandler{
 		deploymentService: deploymentService,
-		userRepo:          userRepo,
+		authenticator:     authenticator,
 		log:               log,
 	}
 }
@@ -39,39 +39,76 @@ func (h *DeploymentHandler) GetRoutes() []dto.RouteDefinition {
 			Method: "GET",
 			Path:   dto.PathDeploymentsList,
 			Middlewares: []gin.HandlerFunc{
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
 			},
-			Handler: middleware.NoBodyHandler(h.ListDeployments),
+			Handler: middleware.ValidateQuery[dto.ListDeploymentsQuery](h.ListDeployments),
 		},
 		{
 			Method: "GET",
 			Path:   dto.PathDeploymentByID,
 			Middlewares: []gin.HandlerFunc{
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
 			},
 			Handler: middleware.NoBodyHandler(h.GetDeployment),
 		},
+		{
+			Method: "GET",
+			Path:   dto.PathDeploymentRevisions,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListDeploymentRevisions),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathDeploymentRevisionDiff,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.DiffDeploymentRevisions),
+		},
 	}
 }
 
 // ListDeployments handles GET /api/v1/deployments
 // @Summary      List deployments for the authenticated user
-// @Description  Returns all deployments for the user identified by X-User-ID header. Returns limited fields: identifier, createdAt, UpdatedAt, status, name, namespace.
+// @Description  Returns a page of the deployments visible to the authenticated user: their personal deployments and those of their teams. Returns limited fields: identifier, createdAt, UpdatedAt, status, name, namespace. next_cursor is set when more pages follow; pass it as cursor with the same filters and sort.
 // @Tags         DeploymentService
 // @Accept       json
 // @Produce      json
-// @Param        X-User-ID  header    string  true  "User ID for authentication"
-// @Success      200        {object}  dto.SuccessResponse{data=[]dto.DeploymentListResponse}
-// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
-// @Failure      403        {object}  dto.ErrorResponse  "User not found"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        limit           query     int     false  "Page size (default 50, max 200)"
+// @Param        cursor          query     string  false  "next_cursor of the previous page"
+// @Param        sort            query     string  false  "created_at, -created_at (default), name or -name"
+// @Param        status          query     string  false  "Only this status"
+// @Param        namespace       query     string  false  "Only this namespace"
+// @Param        name_prefix     query     string  false  "Only names starting with this prefix"
+// @Param        image           query     string  false  "Only this exact image reference"
+// @Param        created_after   query     string  false  "Only created after this RFC3339 time"
+// @Param        created_before  query     string  false  "Only created before this RFC3339 time"
+// @Success      200        {object}  dto.PagedResponse{data=[]dto.DeploymentListResponse}
+// @Failure      400        {object}  dto.ErrorResponse  "Invalid query parameters or cursor"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:read scope"
 // @Router       /deployments [get]
-func (h *DeploymentHandler) ListDeployments(c *gin.Context) {
+func (h *DeploymentHandler) ListDeployments(c *gin.Context, query *dto.ListDeploymentsQuery) {
 	userID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -81,8 +118,15 @@ func (h *DeploymentHandler) ListDeployments(c *gin.Context) {
 		return
 	}
 
-	deployments, err := h.deploymentService.ListDeployments(c.Request.Context(), userID.String())
+	deployments, nextCursor, err := h.deploymentService.ListDeployments(c.Request.Context(), userID.String(), query)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   dto.ErrMsgInvalidCursor,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   dto.ErrMsgFailedToListDeployments,
 			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
@@ -90,23 +134,25 @@ func (h *DeploymentHandler) ListDeployments(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Message: dto.MsgDeploymentsRetrieved,
-		Data:    deployments,
+	c.JSON(http.StatusOK, dto.PagedResponse{
+		Message:    dto.MsgDeploymentsRetrieved,
+		Data:       deployments,
+		NextCursor: nextCursor,
 	})
 }
 
 // GetDeployment handles GET /api/v1/deployments/:id
 // @Summary      Get a deployment by identifier
-// @Description  Returns the full deployment including metadata for the given identifier. Only returns if owned by the authenticated user.
+// @Description  Returns the full deployment including metadata for the given identifier. Only returns if the deployment is personal to the authenticated user or belongs to one of their teams.
 // @Tags         DeploymentService
 // @Accept       json
 // @Produce      json
-// @Param        X-User-ID  header    string  true   "User ID for authentication"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        id         path      string  true   "Identifier of the deployment"
 // @Success      200        {object}  dto.SuccessResponse{data=dto.DeploymentResponse}
-// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
-// @Failure      403        {object}  dto.ErrorResponse  "User not found"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:read scope"
 // @Failure      404        {object}  dto.ErrorResponse  "Deployment not found"
 // @Router       /deployments/{id} [get]
 func (h *DeploymentHandler) GetDeployment(c *gin.Context) {
@@ -149,3 +195,133 @@ func (h *DeploymentHandler) GetDeployment(c *gin.Context) {
 		Data:    deployment,
 	})
 }
+
+// ListDeploymentRevisions handles GET /api/v1/deployments/:id/revisions
+// @Summary      List the revisions of a deployment
+// @Description  Returns the recorded revisions (one per spec generation) of the deployment, newest first. Only returns if the deployment is visible to the authenticated user.
+// @Tags         DeploymentService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id         path      string  true   "Identifier of the deployment"
+// @Success      200        {object}  dto.SuccessResponse{data=[]dto.DeploymentRevisionResponse}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:read scope"
+// @Failure      404        {object}  dto.ErrorResponse  "Deployment not found"
+// @Router       /deployments/{id}/revisions [get]
+func (h *DeploymentHandler) ListDeploymentRevisions(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	identifier := c.Param(dto.ParamID)
+	if identifier == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgIdentifierRequired,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	revisions, err := h.deploymentService.ListDeploymentRevisions(c.Request.Context(), identifier, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrDeploymentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListDeploymentRevisions,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeploymentRevisionsRetrieved,
+		Data:    revisions,
+	})
+}
+
+// DiffDeploymentRevisions handles GET /api/v1/deployments/:id/revisions/diff
+// @Summary      Diff two revisions of a deployment
+// @Description  Returns the spec fields that changed between two recorded revisions of the deployment. Only returns if the deployment is visible to the authenticated user.
+// @Tags         DeploymentService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id         path      string  true   "Identifier of the deployment"
+// @Param        from       query     int     true   "Revision to diff from"
+// @Param        to         query     int     true   "Revision to diff to"
+// @Success      200        {object}  dto.SuccessResponse{data=dto.DeploymentRevisionDiffResponse}
+// @Failure      400        {object}  dto.ErrorResponse  "Invalid revision numbers"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:read scope"
+// @Failure      404        {object}  dto.ErrorResponse  "Deployment or revision not found"
+// @Router       /deployments/{id}/revisions/diff [get]
+func (h *DeploymentHandler) DiffDeploymentRevisions(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	identifier := c.Param(dto.ParamID)
+	if identifier == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgIdentifierRequired,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	from, fromErr := strconv.ParseInt(c.Query(dto.QueryParamFrom), 10, 64)
+	to, toErr := strconv.ParseInt(c.Query(dto.QueryParamTo), 10, 64)
+	if fromErr != nil || toErr != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgInvalidRevisionParams,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.QueryParamFrom + "," + dto.QueryParamTo},
+		})
+		return
+	}
+
+	diff, err := h.deploymentService.DiffDeploymentRevisions(c.Request.Context(), identifier, from, to, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrDeploymentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if errors.Is(err, dto.ErrDeploymentRevisionNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentRevisionNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToDiffDeploymentRevisions,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeploymentRevisionDiff,
+		Data:    diff,
+	})
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
@@ -16,7 +19,7 @@ import (
 // DeploymentRequestHandler handles deployment request-related requests
 type DeploymentRequestHandler struct {
 	deploymentRequest     portsapi.DeploymentRequest
-	userRepo              portsdb.User
+	authenticator         portsapi.Authenticator
 	deploymentRequestRepo portsdb.DeploymentRequest
 	log                   *zap.Logger
 }
@@ -24,13 +27,13 @@ type DeploymentRequestHandler struct {
 // NewDeploymentRequestHandler creates a new DeploymentRequestHandler instance with injected dependencies
 func NewDeploymentRequestHandler(
 	deploymentRequest portsapi.DeploymentRequest,
-	userRepo portsdb.User,
+	authenticator portsapi.Authenticator,
 	deploymentRequestRepo portsdb.DeploymentRequest,
 	log *zap.Logger,
 ) *DeploymentRequestHandler {
 	return &DeploymentRequestHandler{
 		deploymentRequest:     deploymentRequest,
-		userRepo:              userRepo,
+		authenticator:         authenticator,
 		deploymentRequestRepo: deploymentRequestRepo,
 		log:                   log,
 	}
@@ -43,24 +46,38 @@ func (h *DeploymentRequestHandler) GetRoutes() []dto.RouteDefinition {
 			Method: "GET",
 			Path:   dto.PathDeploymentRequestsList,
 			Middlewares: []gin.HandlerFunc{
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeRequestsRead, h.log),
 			},
-			Handler: middleware.NoBodyHandler(h.ListDeploymentRequests),
+			Handler: middleware.ValidateQuery[dto.ListDeploymentRequestsQuery](h.ListDeploymentRequests),
 		},
 		{
 			Method: "GET",
 			Path:   dto.PathDeploymentRequestByID,
 			Middlewares: []gin.HandlerFunc{
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeRequestsRead, h.log),
 			},
 			Handler: middleware.NoBodyHandler(h.GetDeploymentRequest),
 		},
+		{
+			Method: "GET",
+			Path:   dto.PathDeploymentRequestEvents,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeRequestsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.StreamDeploymentRequestEvents),
+		},
 		{
 			Method: "POST",
 			Path:   dto.PathDeploymentsCreate,
@@ -70,10 +87,11 @@ func (h *DeploymentRequestHandler) GetRoutes() []dto.RouteDefinition {
 				middleware.RequestIDMiddleware(
 					h.deploymentRequestRepo,
 				),
-				middleware.AuthReadWriteMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
 			},
 			// Handler wrapped with ValidateRequest to get validated body directly
 			Handler: middleware.ValidateRequest[dto.CreateDeploymentRequestWithMetadata](
@@ -88,10 +106,11 @@ func (h *DeploymentRequestHandler) GetRoutes() []dto.RouteDefinition {
 				middleware.RequestIDMiddleware(
 					h.deploymentRequestRepo,
 				),
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
 			},
 			// Handler wrapped with ValidateRequest to get validated body directly
 			Handler: middleware.ValidateRequest[dto.UpdateDeploymentRequestMetadata](
@@ -106,28 +125,107 @@ func (h *DeploymentRequestHandler) GetRoutes() []dto.RouteDefinition {
 				middleware.RequestIDMiddleware(
 					h.deploymentRequestRepo,
 				),
-				middleware.AuthReadMiddleware(
-					h.userRepo,
+				middleware.AuthMiddleware(
+					h.authenticator,
 					h.log,
 				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
 			},
 			Handler: middleware.NoBodyHandler(h.DeleteDeploymentRequest),
 		},
+		{
+			Method: "POST",
+			Path:   dto.PathDeploymentRollback,
+			// Middlewares are applied in order: RequestID -> Auth -> Validation -> Handler
+			Middlewares: []gin.HandlerFunc{
+				middleware.RequestIDMiddleware(
+					h.deploymentRequestRepo,
+				),
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.RollbackDeploymentRequest](
+				h.RollbackDeployment,
+			),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathDeploymentRestart,
+			// Middlewares are applied in order: RequestID -> Auth -> Handler
+			Middlewares: []gin.HandlerFunc{
+				middleware.RequestIDMiddleware(
+					h.deploymentRequestRepo,
+				),
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.RestartDeployment),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathDeploymentPause,
+			// Middlewares are applied in order: RequestID -> Auth -> Handler
+			Middlewares: []gin.HandlerFunc{
+				middleware.RequestIDMiddleware(
+					h.deploymentRequestRepo,
+				),
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.PauseDeployment),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathDeploymentResume,
+			// Middlewares are applied in order: RequestID -> Auth -> Handler
+			Middlewares: []gin.HandlerFunc{
+				middleware.RequestIDMiddleware(
+					h.deploymentRequestRepo,
+				),
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ResumeDeployment),
+		},
 	}
 }
 
 // ListDeploymentRequests handles GET /api/v1/deployments/requests
 // @Summary      List deployment requests for the authenticated user
-// @Description  Returns all deployment requests for the user identified by X-User-ID header
+// @Description  Returns a page of the deployment requests visible to the authenticated user: their personal requests and those of their teams. next_cursor is set when more pages follow; pass it as cursor with the same filters and sort.
 // @Tags         DeploymentRequestService
 // @Accept       json
 // @Produce      json
-// @Param        X-User-ID  header    string  true  "User ID for authentication"
-// @Success      200        {object}  dto.SuccessResponse{data=[]dto.DeploymentRequestListResponse}
-// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
-// @Failure      403        {object}  dto.ErrorResponse  "User not found"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        limit           query     int     false  "Page size (default 50, max 200)"
+// @Param        cursor          query     string  false  "next_cursor of the previous page"
+// @Param        sort            query     string  false  "created_at, -created_at (default), name or -name"
+// @Param        status          query     string  false  "Only this status"
+// @Param        namespace       query     string  false  "Only this namespace"
+// @Param        name_prefix     query     string  false  "Only names starting with this prefix"
+// @Param        request_type    query     string  false  "Only this request type"
+// @Param        image           query     string  false  "Only this exact image reference"
+// @Param        created_after   query     string  false  "Only created after this RFC3339 time"
+// @Param        created_before  query     string  false  "Only created before this RFC3339 time"
+// @Success      200        {object}  dto.PagedResponse{data=[]dto.DeploymentRequestListResponse}
+// @Failure      400        {object}  dto.ErrorResponse  "Invalid query parameters or cursor"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing requests:read scope"
 // @Router       /deployments/requests [get]
-func (h *DeploymentRequestHandler) ListDeploymentRequests(c *gin.Context) {
+func (h *DeploymentRequestHandler) ListDeploymentRequests(c *gin.Context, query *dto.ListDeploymentRequestsQuery) {
 	userID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -137,8 +235,15 @@ func (h *DeploymentRequestHandler) ListDeploymentRequests(c *gin.Context) {
 		return
 	}
 
-	requests, err := h.deploymentRequest.ListDeploymentRequests(c.Request.Context(), userID.String())
+	requests, nextCursor, err := h.deploymentRequest.ListDeploymentRequests(c.Request.Context(), userID.String(), query)
 	if err != nil {
+		if errors.Is(err, dto.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   dto.ErrMsgInvalidCursor,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   dto.ErrMsgFailedToListDeploymentRequests,
 			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
@@ -146,23 +251,25 @@ func (h *DeploymentRequestHandler) ListDeploymentRequests(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse{
-		Message: dto.MsgDeploymentRequestsRetrieved,
-		Data:    requests,
+	c.JSON(http.StatusOK, dto.PagedResponse{
+		Message:    dto.MsgDeploymentRequestsRetrieved,
+		Data:       requests,
+		NextCursor: nextCursor,
 	})
 }
 
 // GetDeploymentRequest handles GET /api/v1/deployment/requests/:id
 // @Summary      Get a deployment request by request ID
-// @Description  Returns the full deployment request including metadata for the given request_id. Only returns if owned by the authenticated user.
+// @Description  Returns the full deployment request including metadata for the given request_id. Only returns if the request is personal to the authenticated user or belongs to one of their teams.
 // @Tags         DeploymentRequestService
 // @Accept       json
 // @Produce      json
-// @Param        X-User-ID  header    string  true   "User ID for authentication"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        id         path      string  true   "Request ID of the deployment request"
 // @Success      200        {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
-// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
-// @Failure      403        {object}  dto.ErrorResponse  "User not found"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing requests:read scope"
 // @Failure      404        {object}  dto.ErrorResponse  "Deployment request not found"
 // @Router       /deployments/requests/{id} [get]
 func (h *DeploymentRequestHandler) GetDeploymentRequest(c *gin.Context) {
@@ -206,6 +313,78 @@ func (h *DeploymentRequestHandler) GetDeploymentRequest(c *gin.Context) {
 	})
 }
 
+// StreamDeploymentRequestEvents handles GET /api/v1/deployments/requests/:id/events
+// @Summary      Stream the status of a deployment request
+// @Description  Server-Sent Events stream of the request's status. The first "status" event carries the current status, then one is sent per transition (CREATED to SUCCESS or FAILURE, with the failure reason). The stream closes after a terminal status. Idle streams receive keepalive comments.
+// @Tags         DeploymentRequestService
+// @Produce      text/event-stream
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id         path      string  true   "Request ID of the deployment request"
+// @Success      200        {object}  dto.DeploymentStatusEvent  "data of each status event"
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing requests:read scope"
+// @Failure      404        {object}  dto.ErrorResponse  "Deployment request not found"
+// @Router       /deployments/requests/{id}/events [get]
+func (h *DeploymentRequestHandler) StreamDeploymentRequestEvents(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	requestID := c.Param(dto.ParamID)
+	if requestID == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgRequestIDRequired,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	events, err := h.deploymentRequest.WatchDeploymentRequest(c.Request.Context(), requestID, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrDeploymentRequestNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentRequestNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToStreamDeploymentRequest,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop reverse proxies (nginx) from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(dto.StatusStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(dto.SSEEventStatus, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": keepalive\n\n")
+			return err == nil
+		}
+	})
+}
+
 // CreateDeploymentRequest handles POST /api/v1/deployments/requests/create
 // @Summary      Create a deployment request
 // @Description  Create a new deployment request with metadata
@@ -213,9 +392,15 @@ func (h *DeploymentRequestHandler) GetDeploymentRequest(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        X-Request-ID  header    string                              true  "Request ID for idempotency"
-// @Param        X-User-ID     header    string                              true  "User ID for authentication"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        request       body      dto.CreateDeploymentRequestWithMetadata  true  "Deployment request details"
 // @Success      201           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope, team role below DEPLOYER or namespace not allowed (details.violation)"
+// @Failure      404           {object}  dto.ErrorResponse  "Team not found"
+// @Failure      409           {object}  dto.ErrorResponse  "Deployment already exists"
+// @Failure      422           {object}  dto.ErrorResponse  "Quota exceeded (details.violation)"
 // @Router       /deployments/requests/create [post]
 // Request body is validated and provided by ValidateRequest middleware
 // RequestID and UserID are available in context from previous middlewares
@@ -230,7 +415,7 @@ func (h *DeploymentRequestHandler) CreateDeploymentRequest(c *gin.Context, req *
 		return
 	}
 
-	// Extract user ID from context (set by AuthReadWriteMiddleware)
+	// Extract user ID from context (set by AuthMiddleware)
 	userID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -248,6 +433,33 @@ func (h *DeploymentRequestHandler) CreateDeploymentRequest(c *gin.Context, req *
 		userID.String(),
 	)
 	if err != nil {
+		if errors.Is(err, dto.ErrUnsupportedImage) || errors.Is(err, dto.ErrMetadataKeyNotAllowed) ||
+			errors.Is(err, dto.ErrInvalidResourceQuantity) || errors.Is(err, dto.ErrInvalidStrategy) ||
+			errors.Is(err, dto.ErrInvalidAutoscaling) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   dto.ErrMsgInvalidDeploymentRequest,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if errors.Is(err, dto.ErrTeamNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgTeamNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if errors.Is(err, dto.ErrInsufficientTeamRole) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientTeamRole,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if writeAdmissionError(c, err) {
+			return
+		}
+
 		// Check if it's a conflict error (deployment already exists)
 		if err.Error() != "" && strings.Contains(err.Error(), dto.StrAlreadyExists) {
 			c.JSON(http.StatusConflict, dto.ErrorResponse{
@@ -277,13 +489,16 @@ func (h *DeploymentRequestHandler) CreateDeploymentRequest(c *gin.Context, req *
 // @Accept       json
 // @Produce      json
 // @Param        X-Request-ID  header    string                              true  "Request ID for idempotency"
-// @Param        X-User-ID     header    string                              true  "User ID for authentication"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        id            path      string                              true  "Deployment identifier"
 // @Param        request       body      dto.UpdateDeploymentRequestMetadata true  "Deployment request update details"
 // @Success      200           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
-// @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
-// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid request, image of another template, or replica_count on an autoscaled deployment"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
 // @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
+// @Failure      422           {object}  dto.ErrorResponse  "Quota exceeded (details.violation)"
 // @Router       /deployments/requests/{id} [patch]
 // Request body is validated and provided by ValidateRequest middleware
 // RequestID and UserID are available in context from previous middlewares
@@ -298,7 +513,7 @@ func (h *DeploymentRequestHandler) UpdateDeploymentRequest(c *gin.Context, req *
 		return
 	}
 
-	// Extract user ID from context (set by AuthReadMiddleware)
+	// Extract user ID from context (set by AuthMiddleware)
 	userID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -334,6 +549,25 @@ func (h *DeploymentRequestHandler) UpdateDeploymentRequest(c *gin.Context, req *
 			})
 			return
 		}
+		if errors.Is(err, dto.ErrInsufficientTeamRole) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientTeamRole,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if writeAdmissionError(c, err) {
+			return
+		}
+		if errors.Is(err, dto.ErrUnsupportedImage) || errors.Is(err, dto.ErrMetadataKeyNotAllowed) ||
+			errors.Is(err, dto.ErrInvalidResourceQuantity) || errors.Is(err, dto.ErrImageTemplateMismatch) ||
+			errors.Is(err, dto.ErrInvalidStrategy) || errors.Is(err, dto.ErrInvalidAutoscaling) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   dto.ErrMsgInvalidDeploymentRequest,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to update deployment request",
@@ -355,11 +589,13 @@ func (h *DeploymentRequestHandler) UpdateDeploymentRequest(c *gin.Context, req *
 // @Accept       json
 // @Produce      json
 // @Param        X-Request-ID  header    string  true  "Request ID for idempotency"
-// @Param        X-User-ID     header    string  true  "User ID for authentication"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
 // @Param        id            path      string  true  "Deployment identifier"
 // @Success      200           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
 // @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
-// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid X-User-ID"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
 // @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
 // @Router       /deployments/requests/{id} [delete]
 // RequestID and UserID are available in context from previous middlewares
@@ -374,7 +610,7 @@ func (h *DeploymentRequestHandler) DeleteDeploymentRequest(c *gin.Context) {
 		return
 	}
 
-	// Extract user ID from context (set by AuthReadMiddleware)
+	// Extract user ID from context (set by AuthMiddleware)
 	userID, err := middleware.GetUserIDFromContext(c)
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
@@ -409,6 +645,13 @@ func (h *DeploymentRequestHandler) DeleteDeploymentRequest(c *gin.Context) {
 			})
 			return
 		}
+		if errors.Is(err, dto.ErrInsufficientTeamRole) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientTeamRole,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
 			Error:   "Failed to delete deployment request",
@@ -422,3 +665,231 @@ func (h *DeploymentRequestHandler) DeleteDeploymentRequest(c *gin.Context) {
 		Data:    deploymentRequest,
 	})
 }
+
+// RollbackDeployment handles POST /api/v1/deployments/:id/rollback
+// @Summary      Roll back a deployment
+// @Description  Create a ROLLBACK request restoring the replica count, resource limits, image, doc_html and autoscaling the deployment had after an earlier successful request
+// @Tags         DeploymentRequestService
+// @Accept       json
+// @Produce      json
+// @Param        X-Request-ID  header    string                         true  "Request ID for idempotency"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id            path      string                         true  "Deployment identifier"
+// @Param        request       body      dto.RollbackDeploymentRequest  true  "Rollback target"
+// @Success      201           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid rollback target"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
+// @Failure      422           {object}  dto.ErrorResponse  "Quota exceeded (details.violation)"
+// @Router       /deployments/{id}/rollback [post]
+// Request body is validated and provided by ValidateRequest middleware
+// RequestID and UserID are available in context from previous middlewares
+func (h *DeploymentRequestHandler) RollbackDeployment(c *gin.Context, req *dto.RollbackDeploymentRequest) {
+	// Extract request ID from context (set by RequestIDMiddleware)
+	requestID, err := middleware.GetRequestIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgRequestIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	// Extract user ID from context (set by AuthMiddleware)
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	// Extract identifier from path parameter
+	identifier := c.Param(dto.ParamID)
+	if identifier == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgIdentifierRequired,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	deploymentRequest, err := h.deploymentRequest.RollbackDeployment(
+		c.Request.Context(),
+		identifier,
+		req,
+		requestID,
+		userID.String(),
+	)
+	if err != nil {
+		if errors.Is(err, dto.ErrDeploymentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if errors.Is(err, dto.ErrInsufficientTeamRole) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientTeamRole,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if writeAdmissionError(c, err) {
+			return
+		}
+		if errors.Is(err, dto.ErrInvalidRollbackTarget) || errors.Is(err, dto.ErrUnsupportedImage) ||
+			errors.Is(err, dto.ErrImageTemplateMismatch) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   dto.ErrMsgInvalidDeploymentRequest,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToCreateRollbackRequest,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: dto.MsgDeploymentRollbackRequested,
+		Data:    deploymentRequest,
+	})
+}
+
+// RestartDeployment handles POST /api/v1/deployments/:id/restart
+// @Summary      Restart a deployment
+// @Description  Create a RESTART request that restarts the deployment's pods with a rolling update (sets the restartedAt pod template annotation)
+// @Tags         DeploymentRequestService
+// @Accept       json
+// @Produce      json
+// @Param        X-Request-ID  header    string  true  "Request ID for idempotency"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id            path      string  true  "Deployment identifier"
+// @Success      201           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
+// @Router       /deployments/{id}/restart [post]
+// RequestID and UserID are available in context from previous middlewares
+func (h *DeploymentRequestHandler) RestartDeployment(c *gin.Context) {
+	h.createOperationRequest(c, h.deploymentRequest.RestartDeployment, dto.MsgDeploymentRestartRequested)
+}
+
+// PauseDeployment handles POST /api/v1/deployments/:id/pause
+// @Summary      Pause a deployment's rollouts
+// @Description  Create a PAUSE request that sets spec.paused, so pod template changes are not rolled out until the deployment is resumed
+// @Tags         DeploymentRequestService
+// @Accept       json
+// @Produce      json
+// @Param        X-Request-ID  header    string  true  "Request ID for idempotency"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id            path      string  true  "Deployment identifier"
+// @Success      201           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
+// @Router       /deployments/{id}/pause [post]
+// RequestID and UserID are available in context from previous middlewares
+func (h *DeploymentRequestHandler) PauseDeployment(c *gin.Context) {
+	h.createOperationRequest(c, h.deploymentRequest.PauseDeployment, dto.MsgDeploymentPauseRequested)
+}
+
+// ResumeDeployment handles POST /api/v1/deployments/:id/resume
+// @Summary      Resume a deployment's rollouts
+// @Description  Create a RESUME request that clears spec.paused and rolls out pending pod template changes
+// @Tags         DeploymentRequestService
+// @Accept       json
+// @Produce      json
+// @Param        X-Request-ID  header    string  true  "Request ID for idempotency"
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id            path      string  true  "Deployment identifier"
+// @Success      201           {object}  dto.SuccessResponse{data=dto.DeploymentRequestResponse}
+// @Failure      400           {object}  dto.ErrorResponse  "Invalid request"
+// @Failure      401           {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403           {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404           {object}  dto.ErrorResponse  "Deployment not found"
+// @Router       /deployments/{id}/resume [post]
+// RequestID and UserID are available in context from previous middlewares
+func (h *DeploymentRequestHandler) ResumeDeployment(c *gin.Context) {
+	h.createOperationRequest(c, h.deploymentRequest.ResumeDeployment, dto.MsgDeploymentResumeRequested)
+}
+
+// createOperationRequest creates a RESTART, PAUSE or RESUME request for the deployment in the path with
+// the given service method and responds with the queued request
+func (h *DeploymentRequestHandler) createOperationRequest(
+	c *gin.Context,
+	operate func(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error),
+	message string,
+) {
+	// Extract request ID from context (set by RequestIDMiddleware)
+	requestID, err := middleware.GetRequestIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgRequestIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	// Extract user ID from context (set by AuthMiddleware)
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	// Extract identifier from path parameter
+	identifier := c.Param(dto.ParamID)
+	if identifier == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   dto.ErrMsgIdentifierRequired,
+			Details: map[string]interface{}{dto.ResponseKeyParam: dto.ParamID},
+		})
+		return
+	}
+
+	deploymentRequest, err := operate(c.Request.Context(), identifier, requestID, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrDeploymentNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse{
+				Error:   dto.ErrMsgDeploymentNotFound,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		if errors.Is(err, dto.ErrInsufficientTeamRole) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error:   dto.ErrMsgInsufficientTeamRole,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToCreateOperationRequest,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: message,
+		Data:    deploymentRequest,
+	})
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DeploymentScheduleHandler handles the scaling schedules of deployments
+type DeploymentScheduleHandler struct {
+	scheduleService portsapi.DeploymentSchedule
+	authenticator   portsapi.Authenticator
+	log             *zap.Logger
+}
+
+// NewDeploymentScheduleHandler creates a new DeploymentScheduleHandler instance with injected dependencies
+func NewDeploymentScheduleHandler(
+	scheduleService portsapi.DeploymentSchedule,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *DeploymentScheduleHandler {
+	return &DeploymentScheduleHandler{
+		scheduleService: scheduleService,
+		authenticator:   authenticator,
+		log:             log,
+	}
+}
+
+// GetRoutes returns all deployment schedule route definitions
+func (h *DeploymentScheduleHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "POST",
+			Path:   dto.PathDeploymentSchedules,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.CreateDeploymentScheduleRequest](h.CreateDeploymentSchedule),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathDeploymentSchedules,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListDeploymentSchedules),
+		},
+		{
+			Method: "DELETE",
+			Path:   dto.PathDeploymentSchedule,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsWrite, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.DeleteDeploymentSchedule),
+		},
+	}
+}
+
+// CreateDeploymentSchedule handles POST /api/v1/deployments/:id/schedules
+// @Summary      Create a scaling schedule
+// @Description  Scales the deployment to replica_count whenever the five-field cron expression matches in the timezone (default UTC). Each run creates an UPDATE request on behalf of the caller with request_id "schedule-<schedule id>-<unix time>"; runs missed while the worker was down are skipped. A replica_count of 0 scales the deployment to zero.
+// @Tags         DeploymentScheduleService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string                               true  "Deployment identifier"
+// @Param        request  body      dto.CreateDeploymentScheduleRequest  true  "Cron expression, timezone and replica count"
+// @Success      201      {object}  dto.SuccessResponse{data=dto.DeploymentScheduleResponse}
+// @Failure      400      {object}  dto.ErrorResponse  "Invalid cron expression or timezone, replica_count not accepted by the template, or deployment autoscaled"
+// @Failure      401      {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403      {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404      {object}  dto.ErrorResponse  "Deployment not found"
+// @Failure      422      {object}  dto.ErrorResponse  "Quota exceeded (details.violation) or validation failed"
+// @Router       /deployments/{id}/schedules [post]
+func (h *DeploymentScheduleHandler) CreateDeploymentSchedule(c *gin.Context, req *dto.CreateDeploymentScheduleRequest) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	schedule, err := h.scheduleService.CreateDeploymentSchedule(c.Request.Context(), c.Param(dto.ParamID), userID.String(), req)
+	if err != nil {
+		h.writeScheduleError(c, err, dto.ErrMsgFailedToCreateDeploymentSchedule)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: dto.MsgDeploymentScheduleCreated,
+		Data:    schedule,
+	})
+}
+
+// ListDeploymentSchedules handles GET /api/v1/deployments/:id/schedules
+// @Summary      List scaling schedules
+// @Description  Returns the scaling schedules of a deployment, oldest first, with their next and last runs
+// @Tags         DeploymentScheduleService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Deployment identifier"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.DeploymentScheduleResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing deployments:read scope"
+// @Failure      404  {object}  dto.ErrorResponse  "Deployment not found"
+// @Router       /deployments/{id}/schedules [get]
+func (h *DeploymentScheduleHandler) ListDeploymentSchedules(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	schedules, err := h.scheduleService.ListDeploymentSchedules(c.Request.Context(), c.Param(dto.ParamID), userID.String())
+	if err != nil {
+		h.writeScheduleError(c, err, dto.ErrMsgFailedToListDeploymentSchedules)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeploymentSchedulesRetrieved,
+		Data:    schedules,
+	})
+}
+
+// DeleteDeploymentSchedule handles DELETE /api/v1/deployments/:id/schedules/:schedule
+// @Summary      Delete a scaling schedule
+// @Description  Deletes a scaling schedule of a deployment; requests it already created are kept
+// @Tags         DeploymentScheduleService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id        path      string  true  "Deployment identifier"
+// @Param        schedule  path      string  true  "ID of the schedule"
+// @Success      200       {object}  dto.SuccessResponse
+// @Failure      401       {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403       {object}  dto.ErrorResponse  "Missing deployments:write scope or team role below DEPLOYER"
+// @Failure      404       {object}  dto.ErrorResponse  "Deployment or schedule not found"
+// @Router       /deployments/{id}/schedules/{schedule} [delete]
+func (h *DeploymentScheduleHandler) DeleteDeploymentSchedule(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	if err := h.scheduleService.DeleteDeploymentSchedule(c.Request.Context(), c.Param(dto.ParamID), c.Param(dto.ParamSchedule), userID.String()); err != nil {
+		h.writeScheduleError(c, err, dto.ErrMsgFailedToDeleteDeploymentSchedule)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgDeploymentScheduleDeleted,
+	})
+}
+
+// writeScheduleError maps deployment schedule service errors to HTTP responses; unknown errors become 500
+// with fallback
+func (h *DeploymentScheduleHandler) writeScheduleError(c *gin.Context, err error, fallback string) {
+	if writeAdmissionError(c, err) {
+		return
+	}
+	status, message := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, dto.ErrDeploymentNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgDeploymentNotFound
+	case errors.Is(err, dto.ErrDeploymentScheduleNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgDeploymentScheduleNotFound
+	case errors.Is(err, dto.ErrInsufficientTeamRole):
+		status, message = http.StatusForbidden, dto.ErrMsgInsufficientTeamRole
+	case errors.Is(err, dto.ErrInvalidSchedule) || errors.Is(err, dto.ErrInvalidAutoscaling):
+		status, message = http.StatusBadRequest, dto.ErrMsgInvalidSchedule
+	case errors.Is(err, dto.ErrUnsupportedImage) || errors.Is(err, dto.ErrMetadataKeyNotAllowed):
+		status, message = http.StatusBadRequest, dto.ErrMsgInvalidSchedule
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error:   message,
+		Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+	})
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// QuotaHandler handles quota requests
+type QuotaHandler struct {
+	admission     portsapi.Admission
+	authenticator portsapi.Authenticator
+	log           *zap.Logger
+}
+
+// NewQuotaHandler creates a new QuotaHandler instance with injected dependencies
+func NewQuotaHandler(
+	admission portsapi.Admission,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *QuotaHandler {
+	return &QuotaHandler{
+		admission:     admission,
+		authenticator: authenticator,
+		log:           log,
+	}
+}
+
+// GetRoutes returns all quota route definitions
+func (h *QuotaHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "GET",
+			Path:   dto.PathQuotas,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.GetQuotas),
+		},
+	}
+}
+
+// GetQuotas handles GET /api/v1/quotas
+// @Summary      Get quotas and usage
+// @Description  Returns the allowed namespaces, quotas and current usage of the authenticated user's personal deployments, followed by those of each of their teams. Usage includes CREATE, UPDATE and ROLLBACK requests not processed yet.
+// @Tags         QuotaService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.QuotaResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing deployments:read scope"
+// @Router       /quotas [get]
+func (h *QuotaHandler) GetQuotas(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	quotas, err := h.admission.GetQuotas(c.Request.Context(), userID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToGetQuotas,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgQuotasRetrieved,
+		Data:    quotas,
+	})
+}
+
+// writeAdmissionError answers an admission violation with 403 (namespace not allowed) or 422 (quota
+// exceeded) and the violation as detail. It returns false, writing nothing, for other errors.
+func writeAdmissionError(c *gin.Context, err error) bool {
+	var violation *dto.AdmissionViolation
+	if !errors.As(err, &violation) {
+		return false
+	}
+
+	status, message := http.StatusUnprocessableEntity, dto.ErrMsgQuotaExceeded
+	if errors.Is(err, dto.ErrNamespaceNotAllowed) {
+		status, message = http.StatusForbidden, dto.ErrMsgNamespaceNotAllowed
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error: message,
+		Details: map[string]interface{}{
+			dto.ResponseKeyError:     err.Error(),
+			dto.ResponseKeyViolation: violation,
+		},
+	})
+	return true
+}
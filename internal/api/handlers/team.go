@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TeamHandler handles team and membership management requests
+type TeamHandler struct {
+	teamService   portsapi.Team
+	authenticator portsapi.Authenticator
+	log           *zap.Logger
+}
+
+// NewTeamHandler creates a new TeamHandler instance with injected dependencies
+func NewTeamHandler(
+	teamService portsapi.Team,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *TeamHandler {
+	return &TeamHandler{
+		teamService:   teamService,
+		authenticator: authenticator,
+		log:           log,
+	}
+}
+
+// GetRoutes returns all team route definitions
+func (h *TeamHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "POST",
+			Path:   dto.PathTeams,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeTeamsManage, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.CreateTeamRequest](h.CreateTeam),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathTeams,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeTeamsManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListTeams),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathTeamMembers,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeTeamsManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListTeamMembers),
+		},
+		{
+			Method: "PUT",
+			Path:   dto.PathTeamMembers,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeTeamsManage, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.SetTeamMemberRequest](h.SetTeamMember),
+		},
+		{
+			Method: "DELETE",
+			Path:   dto.PathTeamMember,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeTeamsManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.RemoveTeamMember),
+		},
+	}
+}
+
+// CreateTeam handles POST /api/v1/teams
+// @Summary      Create a team
+// @Description  Creates a team with the authenticated user as its first ADMIN
+// @Tags         TeamService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      dto.CreateTeamRequest  true  "Team name"
+// @Success      201      {object}  dto.SuccessResponse{data=dto.TeamResponse}
+// @Failure      401      {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403      {object}  dto.ErrorResponse  "Missing teams:manage scope"
+// @Failure      409      {object}  dto.ErrorResponse  "Team name is already taken"
+// @Failure      422      {object}  dto.ErrorResponse  "Validation failed"
+// @Router       /teams [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context, req *dto.CreateTeamRequest) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	team, err := h.teamService.CreateTeam(c.Request.Context(), req, userID.String())
+	if err != nil {
+		if errors.Is(err, dto.ErrTeamNameTaken) {
+			c.JSON(http.StatusConflict, dto.ErrorResponse{
+				Error:   dto.ErrMsgTeamNameTaken,
+				Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToCreateTeam,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: dto.MsgTeamCreated,
+		Data:    team,
+	})
+}
+
+// ListTeams handles GET /api/v1/teams
+// @Summary      List teams
+// @Description  Returns the teams the authenticated user is a member of, with their role in each
+// @Tags         TeamService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.TeamResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing teams:manage scope"
+// @Router       /teams [get]
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	teams, err := h.teamService.ListTeams(c.Request.Context(), userID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListTeams,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgTeamsRetrieved,
+		Data:    teams,
+	})
+}
+
+// ListTeamMembers handles GET /api/v1/teams/:id/members
+// @Summary      List team members
+// @Description  Returns the members of a team the authenticated user belongs to
+// @Tags         TeamService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID of the team"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.TeamMemberResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing teams:manage scope"
+// @Failure      404  {object}  dto.ErrorResponse  "Team not found"
+// @Router       /teams/{id}/members [get]
+func (h *TeamHandler) ListTeamMembers(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	members, err := h.teamService.ListTeamMembers(c.Request.Context(), c.Param(dto.ParamID), userID.String())
+	if err != nil {
+		h.writeTeamError(c, err, dto.ErrMsgFailedToListTeamMembers)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgTeamMembersRetrieved,
+		Data:    members,
+	})
+}
+
+// SetTeamMember handles PUT /api/v1/teams/:id/members
+// @Summary      Add or update a team member
+// @Description  Adds a user to the team or changes their role. Only team ADMINs may manage members; the last ADMIN cannot be demoted.
+// @Tags         TeamService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id       path      string                    true  "ID of the team"
+// @Param        request  body      dto.SetTeamMemberRequest  true  "Member external ID and role"
+// @Success      200      {object}  dto.SuccessResponse{data=dto.TeamMemberResponse}
+// @Failure      401      {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403      {object}  dto.ErrorResponse  "Missing teams:manage scope or caller is not a team ADMIN"
+// @Failure      404      {object}  dto.ErrorResponse  "Team not found"
+// @Failure      409      {object}  dto.ErrorResponse  "Team must keep at least one admin"
+// @Failure      422      {object}  dto.ErrorResponse  "Validation failed"
+// @Router       /teams/{id}/members [put]
+func (h *TeamHandler) SetTeamMember(c *gin.Context, req *dto.SetTeamMemberRequest) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	member, err := h.teamService.SetTeamMember(c.Request.Context(), c.Param(dto.ParamID), req, userID.String())
+	if err != nil {
+		h.writeTeamError(c, err, dto.ErrMsgFailedToSetTeamMember)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgTeamMemberSet,
+		Data:    member,
+	})
+}
+
+// RemoveTeamMember handles DELETE /api/v1/teams/:id/members/:member
+// @Summary      Remove a team member
+// @Description  Removes a user from the team. Only team ADMINs may manage members; the last ADMIN cannot be removed.
+// @Tags         TeamService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id      path      string  true  "ID of the team"
+// @Param        member  path      string  true  "External user ID of the member"
+// @Success      200     {object}  dto.SuccessResponse
+// @Failure      401     {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403     {object}  dto.ErrorResponse  "Missing teams:manage scope or caller is not a team ADMIN"
+// @Failure      404     {object}  dto.ErrorResponse  "Team or member not found"
+// @Failure      409     {object}  dto.ErrorResponse  "Team must keep at least one admin"
+// @Router       /teams/{id}/members/{member} [delete]
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	err = h.teamService.RemoveTeamMember(c.Request.Context(), c.Param(dto.ParamID), c.Param(dto.ParamMember), userID.String())
+	if err != nil {
+		h.writeTeamError(c, err, dto.ErrMsgFailedToRemoveTeamMember)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgTeamMemberRemoved,
+	})
+}
+
+// writeTeamError maps team service errors to HTTP responses; unknown errors become 500 with fallback
+func (h *TeamHandler) writeTeamError(c *gin.Context, err error, fallback string) {
+	status, message := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, dto.ErrTeamNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgTeamNotFound
+	case errors.Is(err, dto.ErrTeamMemberNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgTeamMemberNotFound
+	case errors.Is(err, dto.ErrInsufficientTeamRole):
+		status, message = http.StatusForbidden, dto.ErrMsgInsufficientTeamRole
+	case errors.Is(err, dto.ErrLastTeamAdmin):
+		status, message = http.StatusConflict, dto.ErrMsgLastTeamAdmin
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error:   message,
+		Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+	})
+}
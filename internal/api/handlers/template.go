@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TemplateHandler handles deployment template requests
+type TemplateHandler struct {
+	templateService portsapi.Template
+	authenticator   portsapi.Authenticator
+	log             *zap.Logger
+}
+
+// NewTemplateHandler creates a new TemplateHandler instance with injected dependencies
+func NewTemplateHandler(
+	templateService portsapi.Template,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		authenticator:   authenticator,
+		log:             log,
+	}
+}
+
+// GetRoutes returns all template route definitions
+func (h *TemplateHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "GET",
+			Path:   dto.PathTemplatesList,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeDeploymentsRead, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListTemplates),
+		},
+	}
+}
+
+// ListTemplates handles GET /api/v1/templates
+// @Summary      List deployment templates
+// @Description  Returns the available templates with the image patterns they serve, container port, content mount path and accepted metadata keys
+// @Tags         TemplateService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200        {object}  dto.SuccessResponse{data=[]dto.TemplateManifest}
+// @Failure      401        {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403        {object}  dto.ErrorResponse  "Missing deployments:read scope"
+// @Router       /templates [get]
+func (h *TemplateHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.templateService.ListTemplates(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   dto.ErrMsgFailedToListTemplates,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgTemplatesRetrieved,
+		Data:    templates,
+	})
+}
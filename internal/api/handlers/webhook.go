@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/api/middleware"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// WebhookHandler handles webhook management requests
+type WebhookHandler struct {
+	webhookService portsapi.Webhook
+	authenticator  portsapi.Authenticator
+	log            *zap.Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance with injected dependencies
+func NewWebhookHandler(
+	webhookService portsapi.Webhook,
+	authenticator portsapi.Authenticator,
+	log *zap.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		authenticator:  authenticator,
+		log:            log,
+	}
+}
+
+// GetRoutes returns all webhook route definitions
+func (h *WebhookHandler) GetRoutes() []dto.RouteDefinition {
+	return []dto.RouteDefinition{
+		{
+			Method: "POST",
+			Path:   dto.PathWebhooks,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeWebhooksManage, h.log),
+			},
+			Handler: middleware.ValidateRequest[dto.CreateWebhookRequest](h.CreateWebhook),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathWebhooks,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeWebhooksManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListWebhooks),
+		},
+		{
+			Method: "DELETE",
+			Path:   dto.PathWebhookByID,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeWebhooksManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.DeleteWebhook),
+		},
+		{
+			Method: "GET",
+			Path:   dto.PathWebhookDeliveries,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeWebhooksManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.ListWebhookDeliveries),
+		},
+		{
+			Method: "POST",
+			Path:   dto.PathWebhookRedeliver,
+			Middlewares: []gin.HandlerFunc{
+				middleware.AuthMiddleware(
+					h.authenticator,
+					h.log,
+				),
+				middleware.RequireScope(dto.ScopeWebhooksManage, h.log),
+			},
+			Handler: middleware.NoBodyHandler(h.RedeliverWebhook),
+		},
+	}
+}
+
+// CreateWebhook handles POST /api/v1/webhooks
+// @Summary      Create a webhook
+// @Description  Subscribes a URL to deployment events of the caller's personal deployments, or of a team's deployments when team_id is set. Every delivery is POSTed as JSON with an X-Webhook-Signature header: "sha256=" and the hex HMAC-SHA256, keyed with the secret, of "<X-Webhook-Timestamp>.<body>".
+// @Tags         WebhookService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        request  body      dto.CreateWebhookRequest  true  "URL, event types, signing secret and optional team"
+// @Success      201      {object}  dto.SuccessResponse{data=dto.WebhookResponse}
+// @Failure      401      {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403      {object}  dto.ErrorResponse  "Missing webhooks:manage scope"
+// @Failure      404      {object}  dto.ErrorResponse  "Team not found"
+// @Failure      422      {object}  dto.ErrorResponse  "Validation failed"
+// @Router       /webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context, req *dto.CreateWebhookRequest) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	webhook, err := h.webhookService.CreateWebhook(c.Request.Context(), userID.String(), req)
+	if err != nil {
+		h.writeWebhookError(c, err, dto.ErrMsgFailedToCreateWebhook)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: dto.MsgWebhookCreated,
+		Data:    webhook,
+	})
+}
+
+// ListWebhooks handles GET /api/v1/webhooks
+// @Summary      List webhooks
+// @Description  Returns the webhooks the authenticated user created. Secrets are never returned.
+// @Tags         WebhookService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.WebhookResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing webhooks:manage scope"
+// @Router       /webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), userID.String())
+	if err != nil {
+		h.writeWebhookError(c, err, dto.ErrMsgFailedToListWebhooks)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgWebhooksRetrieved,
+		Data:    webhooks,
+	})
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/:id
+// @Summary      Delete a webhook
+// @Description  Deletes one of the authenticated user's webhooks with its deliveries; pending deliveries are not sent
+// @Tags         WebhookService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID of the webhook"
+// @Success      200  {object}  dto.SuccessResponse
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing webhooks:manage scope"
+// @Failure      404  {object}  dto.ErrorResponse  "Webhook not found"
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), c.Param(dto.ParamID), userID.String()); err != nil {
+		h.writeWebhookError(c, err, dto.ErrMsgFailedToDeleteWebhook)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgWebhookDeleted,
+	})
+}
+
+// ListWebhookDeliveries handles GET /api/v1/webhooks/:id/deliveries
+// @Summary      List webhook deliveries
+// @Description  Returns the latest deliveries of one of the authenticated user's webhooks, newest first, each with its payload and attempt log
+// @Tags         WebhookService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id   path      string  true  "ID of the webhook"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.WebhookDeliveryResponse}
+// @Failure      401  {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403  {object}  dto.ErrorResponse  "Missing webhooks:manage scope"
+// @Failure      404  {object}  dto.ErrorResponse  "Webhook not found"
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	deliveries, err := h.webhookService.ListWebhookDeliveries(c.Request.Context(), c.Param(dto.ParamID), userID.String())
+	if err != nil {
+		h.writeWebhookError(c, err, dto.ErrMsgFailedToListWebhookDeliveries)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgWebhookDeliveriesRetrieved,
+		Data:    deliveries,
+	})
+}
+
+// RedeliverWebhook handles POST /api/v1/webhooks/:id/deliveries/:delivery/redeliver
+// @Summary      Redeliver a webhook delivery
+// @Description  Schedules a delivery to be sent again right away, whatever its status, with its attempt count reset. The event ID and X-Webhook-Delivery header stay the same, so receivers can deduplicate.
+// @Tags         WebhookService
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Security     BearerAuth
+// @Param        id        path      string  true  "ID of the webhook"
+// @Param        delivery  path      string  true  "ID of the delivery"
+// @Success      200       {object}  dto.SuccessResponse{data=dto.WebhookDeliveryResponse}
+// @Failure      401       {object}  dto.ErrorResponse  "Missing or invalid credentials"
+// @Failure      403       {object}  dto.ErrorResponse  "Missing webhooks:manage scope"
+// @Failure      404       {object}  dto.ErrorResponse  "Webhook or delivery not found"
+// @Router       /webhooks/{id}/deliveries/{delivery}/redeliver [post]
+func (h *WebhookHandler) RedeliverWebhook(c *gin.Context) {
+	userID, err := middleware.GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   dto.ErrMsgUserIDNotFound,
+			Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.RedeliverWebhook(c.Request.Context(), c.Param(dto.ParamID), c.Param(dto.ParamDelivery), userID.String())
+	if err != nil {
+		h.writeWebhookError(c, err, dto.ErrMsgFailedToRedeliverWebhook)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: dto.MsgWebhookRedelivered,
+		Data:    delivery,
+	})
+}
+
+// writeWebhookError maps webhook service errors to HTTP responses; unknown errors become 500 with fallback
+func (h *WebhookHandler) writeWebhookError(c *gin.Context, err error, fallback string) {
+	status, message := http.StatusInternalServerError, fallback
+	switch {
+	case errors.Is(err, dto.ErrWebhookNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgWebhookNotFound
+	case errors.Is(err, dto.ErrWebhookDeliveryNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgWebhookDeliveryNotFound
+	case errors.Is(err, dto.ErrTeamNotFound):
+		status, message = http.StatusNotFound, dto.ErrMsgTeamNotFound
+	case errors.Is(err, dto.ErrInsufficientTeamRole):
+		status, message = http.StatusForbidden, dto.ErrMsgInsufficientTeamRole
+	}
+	c.JSON(status, dto.ErrorResponse{
+		Error:   message,
+		Details: map[string]interface{}{dto.ResponseKeyError: err.Error()},
+	})
+}
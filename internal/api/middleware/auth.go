@@ -1,96 +1,88 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
-	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
-	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-// AuthReadMiddleware validates that the user exists in the database
-// Returns 401 if header is missing/invalid, 403 if user not found
-func AuthReadMiddleware(
-	userRepo portsdb.User,
+// AuthMiddleware authenticates the request with the configured authenticator and stores the user in context
+// Returns 401 if the request has no valid credentials
+func AuthMiddleware(
+	authenticator portsapi.Authenticator,
 	logger *zap.Logger,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userExternalID := c.GetHeader(dto.UserIDHeader)
-
-		if userExternalID == "" {
-			logger.Warn("X-User-ID header is missing")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				dto.ResponseKeyError: dto.ErrMsgUserIDHeaderRequired,
-			})
-			c.Abort()
-			return
-		}
-
-		// Get user from database
-		user, err := userRepo.GetByExternalID(c.Request.Context(), userExternalID)
+		principal, err := authenticator.Authenticate(c.Request.Context(), c.Request)
 		if err != nil {
-			logger.Warn("User not found", zap.String("external_id", userExternalID), zap.Error(err))
-			c.JSON(http.StatusForbidden, gin.H{
-				dto.ResponseKeyError: dto.ErrMsgUserNotFoundResponse,
-			})
+			switch {
+			case errors.Is(err, dto.ErrNoCredentials):
+				logger.Warn("Request has no credentials", zap.String("path", c.FullPath()))
+				c.JSON(http.StatusUnauthorized, gin.H{
+					dto.ResponseKeyError: dto.ErrMsgAuthenticationRequired,
+				})
+			case errors.Is(err, dto.ErrInvalidCredentials):
+				logger.Warn("Invalid credentials", zap.String("path", c.FullPath()), zap.Error(err))
+				c.JSON(http.StatusUnauthorized, gin.H{
+					dto.ResponseKeyError: dto.ErrMsgInvalidCredentials,
+				})
+			default:
+				logger.Error("Failed to authenticate request", zap.Error(err))
+				c.JSON(http.StatusInternalServerError, gin.H{
+					dto.ResponseKeyError: dto.ErrMsgFailedToAuthenticate,
+				})
+			}
 			c.Abort()
 			return
 		}
 
-		// Store user ID and user object in context
-		c.Set(dto.UserIDKey, user.ID)
-		c.Set(dto.UserKey, user)
+		// Store user ID, user object and principal in context
+		c.Set(dto.UserIDKey, principal.User.ID)
+		c.Set(dto.UserKey, principal.User)
+		c.Set(dto.PrincipalKey, principal)
 		c.Next()
 	}
 }
 
-// AuthReadWriteMiddleware validates user and creates if not exists
-// Returns 401 if header is missing/invalid, creates user if not found
-func AuthReadWriteMiddleware(
-	userRepo portsdb.User,
+// RequireScope rejects requests whose credentials do not grant scope
+// Must run after AuthMiddleware; returns 403 if the scope is missing
+func RequireScope(
+	scope string,
 	logger *zap.Logger,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userExternalID := c.GetHeader(dto.UserIDHeader)
-
-		if userExternalID == "" {
-			logger.Warn("X-User-ID header is missing")
-			c.JSON(http.StatusUnauthorized, gin.H{
-				dto.ResponseKeyError: dto.ErrMsgUserIDHeaderRequired,
+		principal, err := GetPrincipalFromContext(c)
+		if err != nil || !principal.HasScope(scope) {
+			logger.Warn("Credentials lack required scope", zap.String("scope", scope), zap.String("path", c.FullPath()))
+			c.JSON(http.StatusForbidden, gin.H{
+				dto.ResponseKeyError: dto.ErrMsgInsufficientScope,
+				dto.ResponseKeyScope: scope,
 			})
 			c.Abort()
 			return
 		}
+		c.Next()
+	}
+}
 
-		// Try to get user from database
-		user, err := userRepo.GetByExternalID(c.Request.Context(), userExternalID)
-		if err != nil {
-			// User doesn't exist, create it
-			logger.Info("User not found, creating new user", zap.String("external_id", userExternalID))
-			user = &models.User{
-				UserExternalID: userExternalID,
-			}
-
-			if err := userRepo.Create(c.Request.Context(), user); err != nil {
-				logger.Error("Failed to create user", zap.String("external_id", userExternalID), zap.Error(err))
-				c.JSON(http.StatusInternalServerError, gin.H{
-					dto.ResponseKeyError: dto.ErrMsgFailedToCreateUser,
-				})
-				c.Abort()
-				return
-			}
-
-			logger.Info("User created successfully", zap.String("external_id", userExternalID), zap.String("user_id", user.ID.String()))
-		}
+// GetPrincipalFromContext extracts the authenticated principal from gin context
+func GetPrincipalFromContext(c *gin.Context) (*dto.Principal, error) {
+	principal, exists := c.Get(dto.PrincipalKey)
+	if !exists {
+		return nil, dto.ErrPrincipalNotFoundInContext
+	}
 
-		// Store user ID and user object in context
-		c.Set(dto.UserIDKey, user.ID)
-		c.Set(dto.UserKey, user)
-		c.Next()
+	p, ok := principal.(*dto.Principal)
+	if !ok {
+		return nil, dto.ErrPrincipalNotFoundInContext
 	}
+
+	return p, nil
 }
 
 // GetUserIDFromContext extracts user ID from gin context
@@ -5,6 +5,7 @@ import (
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,6 +24,14 @@ func RequestIDMiddleware(
 			c.Abort()
 			return
 		}
+		// The request ID is a token of the request's status subject, so it must not hold NATS wildcards
+		if !utils.ValidRequestID(requestID) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				dto.ResponseKeyError: dto.ErrMsgInvalidRequestIDHeader,
+			})
+			c.Abort()
+			return
+		}
 		_, found, err := deploymentRequestRepo.GetByRequestID(c.Request.Context(), requestID)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
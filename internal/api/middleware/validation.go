@@ -47,6 +47,45 @@ func ValidateRequest[T any](handler func(c *gin.Context, req *T)) gin.HandlerFun
 	}
 }
 
+// ValidateQuery binds the query parameters to the DTO and validates them against its schema
+// Returns 400 (Bad Request) if binding or validation fails
+func ValidateQuery[T any](handler func(c *gin.Context, query *T)) gin.HandlerFunc {
+	validate := validator.New()
+
+	return func(c *gin.Context) {
+		var query T
+
+		// Bind query parameters to the query struct
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid query parameters",
+				"details": err.Error(),
+			})
+			c.Abort()
+			return
+		}
+
+		// Validate the struct using validator tags
+		if err := validate.Struct(&query); err != nil {
+			errors := make(map[string]string)
+			for _, err := range err.(validator.ValidationErrors) {
+				field := err.Field()
+				tag := err.Tag()
+				errors[field] = getValidationErrorMessage(field, tag)
+			}
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Validation failed",
+				"details": errors,
+			})
+			c.Abort()
+			return
+		}
+
+		// Call the handler with validated query
+		handler(c, &query)
+	}
+}
+
 // NoBodyHandler wraps a handler that doesn't require a request body
 func NoBodyHandler(handler func(c *gin.Context)) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -21,7 +21,14 @@ func SetupRouter(
 	log *zap.Logger,
 	deploymentRequest portsapi.DeploymentRequest,
 	deployment portsapi.Deployment,
-	userRepo portsdb.User,
+	deadLetter portsapi.DeadLetter,
+	template portsapi.Template,
+	apiKey portsapi.APIKey,
+	team portsapi.Team,
+	admission portsapi.Admission,
+	webhook portsapi.Webhook,
+	deploymentSchedule portsapi.DeploymentSchedule,
+	authenticator portsapi.Authenticator,
 	deploymentRequestRepo portsdb.DeploymentRequest,
 ) *gin.Engine {
 	router := gin.New()
@@ -39,7 +46,14 @@ func SetupRouter(
 		router,
 		deploymentRequest,
 		deployment,
-		userRepo,
+		deadLetter,
+		template,
+		apiKey,
+		team,
+		admission,
+		webhook,
+		deploymentSchedule,
+		authenticator,
 		deploymentRequestRepo,
 		log,
 	)
@@ -52,7 +66,14 @@ func initHandlers(
 	router *gin.Engine,
 	deploymentRequest portsapi.DeploymentRequest,
 	deployment portsapi.Deployment,
-	userRepo portsdb.User,
+	deadLetter portsapi.DeadLetter,
+	template portsapi.Template,
+	apiKey portsapi.APIKey,
+	team portsapi.Team,
+	admission portsapi.Admission,
+	webhook portsapi.Webhook,
+	deploymentSchedule portsapi.DeploymentSchedule,
+	authenticator portsapi.Authenticator,
 	deploymentRequestRepo portsdb.DeploymentRequest,
 	log *zap.Logger,
 ) {
@@ -60,7 +81,14 @@ func initHandlers(
 	allHandlers := getHandlers(
 		deploymentRequest,
 		deployment,
-		userRepo,
+		deadLetter,
+		template,
+		apiKey,
+		team,
+		admission,
+		webhook,
+		deploymentSchedule,
+		authenticator,
 		deploymentRequestRepo,
 		log,
 	)
@@ -99,20 +127,62 @@ func initHandlers(
 func getHandlers(
 	deploymentRequest portsapi.DeploymentRequest,
 	deployment portsapi.Deployment,
-	userRepo portsdb.User,
+	deadLetter portsapi.DeadLetter,
+	template portsapi.Template,
+	apiKey portsapi.APIKey,
+	team portsapi.Team,
+	admission portsapi.Admission,
+	webhook portsapi.Webhook,
+	deploymentSchedule portsapi.DeploymentSchedule,
+	authenticator portsapi.Authenticator,
 	deploymentRequestRepo portsdb.DeploymentRequest,
 	log *zap.Logger,
 ) []ports.Handler {
 	return []ports.Handler{
 		handlers.NewDeploymentRequestHandler(
 			deploymentRequest,
-			userRepo,
+			authenticator,
 			deploymentRequestRepo,
 			log,
 		),
 		handlers.NewDeploymentHandler(
 			deployment,
-			userRepo,
+			authenticator,
+			log,
+		),
+		handlers.NewDeadLetterHandler(
+			deadLetter,
+			authenticator,
+			log,
+		),
+		handlers.NewTemplateHandler(
+			template,
+			authenticator,
+			log,
+		),
+		handlers.NewAPIKeyHandler(
+			apiKey,
+			authenticator,
+			log,
+		),
+		handlers.NewTeamHandler(
+			team,
+			authenticator,
+			log,
+		),
+		handlers.NewQuotaHandler(
+			admission,
+			authenticator,
+			log,
+		),
+		handlers.NewWebhookHandler(
+			webhook,
+			authenticator,
+			log,
+		),
+		handlers.NewDeploymentScheduleHandler(
+			deploymentSchedule,
+			authenticator,
 			log,
 		),
 		handlers.NewHealthHandler(),
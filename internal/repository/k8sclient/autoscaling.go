@@ -0,0 +1,104 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	autoscalingv2ac "k8s.io/client-go/applyconfigurations/autoscaling/v2"
+)
+
+// GetAutoscaler returns the HorizontalPodAutoscaler of the deployment with the given identifier if it has one.
+// It returns (nil, false, nil) when the deployment is not autoscaled.
+func (dm *DeploymentManager) GetAutoscaler(ctx context.Context, namespace, identifier string) (*autoscalingv2.HorizontalPodAutoscaler, bool, error) {
+	hpa, err := dm.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).Get(ctx, identifier, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("get horizontal pod autoscaler: %w", err)
+	}
+	return hpa, true, nil
+}
+
+// applyAutoscaler applies the HorizontalPodAutoscaler of the request's deployment, named after its identifier
+// and scaling it on the average CPU and/or memory utilization of its pods. Disabled settings delete it; an
+// autoscaler that does not exist is not an error.
+func (dm *DeploymentManager) applyAutoscaler(ctx context.Context, req *models.DeploymentRequest, settings *dto.AutoscalingSettings) error {
+	client := dm.clientset.AutoscalingV2().HorizontalPodAutoscalers(req.Namespace)
+	if settings.Disabled {
+		if err := client.Delete(ctx, req.Identifier, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete horizontal pod autoscaler: %w", err)
+		}
+		return nil
+	}
+
+	spec := autoscalingv2ac.HorizontalPodAutoscalerSpec().
+		WithScaleTargetRef(autoscalingv2ac.CrossVersionObjectReference().
+			WithAPIVersion("apps/v1").
+			WithKind("Deployment").
+			WithName(req.Identifier)).
+		WithMinReplicas(int32(settings.MinReplicas)).
+		WithMaxReplicas(int32(settings.MaxReplicas))
+	if settings.TargetCPUUtilization > 0 {
+		spec.WithMetrics(utilizationMetric(corev1.ResourceCPU, settings.TargetCPUUtilization))
+	}
+	if settings.TargetMemoryUtilization > 0 {
+		spec.WithMetrics(utilizationMetric(corev1.ResourceMemory, settings.TargetMemoryUtilization))
+	}
+	hpa := autoscalingv2ac.HorizontalPodAutoscaler(req.Identifier, req.Namespace).
+		WithLabels(dm.resourceLabels(req.Identifier)).
+		WithSpec(spec)
+
+	if _, err := client.Apply(ctx, hpa, dm.applyOptions(req.ForceOwnership)); err != nil {
+		return applyError("HorizontalPodAutoscaler", req.Identifier, err)
+	}
+	return nil
+}
+
+// autoscaled reports whether the deployment has a HorizontalPodAutoscaler once settings (nil when the request
+// does not change autoscaling) are applied. The replica count of an autoscaled deployment is left to its
+// autoscaler.
+func (dm *DeploymentManager) autoscaled(ctx context.Context, req *models.DeploymentRequest, settings *dto.AutoscalingSettings) (bool, error) {
+	if settings != nil {
+		return !settings.Disabled, nil
+	}
+	_, found, err := dm.GetAutoscaler(ctx, req.Namespace, req.Identifier)
+	return found, err
+}
+
+// utilizationMetric returns a metric targeting the average utilization of a resource, in percent of the pods' requests.
+func utilizationMetric(name corev1.ResourceName, utilization int) *autoscalingv2ac.MetricSpecApplyConfiguration {
+	return autoscalingv2ac.MetricSpec().
+		WithType(autoscalingv2.ResourceMetricSourceType).
+		WithResource(autoscalingv2ac.ResourceMetricSource().
+			WithName(name).
+			WithTarget(autoscalingv2ac.MetricTarget().
+				WithType(autoscalingv2.UtilizationMetricType).
+				WithAverageUtilization(int32(utilization))))
+}
+
+// autoscalingSettings returns the settings of a HorizontalPodAutoscaler in the form of the request metadata.
+func autoscalingSettings(hpa *autoscalingv2.HorizontalPodAutoscaler) *dto.AutoscalingSettings {
+	settings := &dto.AutoscalingSettings{MinReplicas: 1, MaxReplicas: int(hpa.Spec.MaxReplicas)}
+	if hpa.Spec.MinReplicas != nil {
+		settings.MinReplicas = int(*hpa.Spec.MinReplicas)
+	}
+	for _, metric := range hpa.Spec.Metrics {
+		if metric.Type != autoscalingv2.ResourceMetricSourceType || metric.Resource == nil || metric.Resource.Target.AverageUtilization == nil {
+			continue
+		}
+		switch metric.Resource.Name {
+		case corev1.ResourceCPU:
+			settings.TargetCPUUtilization = int(*metric.Resource.Target.AverageUtilization)
+		case corev1.ResourceMemory:
+			settings.TargetMemoryUtilization = int(*metric.Resource.Target.AverageUtilization)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,139 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ApplyCanary applies the <identifier>-canary deployment of a canary UPDATE: the existing deployment's pod
+// template with the image and resource limits of the request, scaled to replicas. The canary and its pods
+// are labelled track=canary; the pods keep the labels the deployment's Service selects, so they receive a
+// share of its traffic. The canary belongs to this manager, so the apply always takes over its fields.
+func (dm *DeploymentManager) ApplyCanary(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment, replicas int) (*appsv1.Deployment, error) {
+	updateMetadata, err := decodeUpdateMetadata(req)
+	if err != nil {
+		return nil, err
+	}
+
+	template := existingDeployment.Spec.Template.DeepCopy()
+	if len(template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("deployment has no containers")
+	}
+	container := &template.Spec.Containers[0]
+	if updateMetadata.Image != nil {
+		container.Image = *updateMetadata.Image
+	}
+	if updateMetadata.ResourceLimit != nil {
+		if container.Resources, err = resourceRequirements(updateMetadata.ResourceLimit); err != nil {
+			return nil, fmt.Errorf("canary resource limits: %w", err)
+		}
+	}
+	if template.Labels == nil {
+		template.Labels = map[string]string{}
+	}
+	template.Labels[dto.LabelKeyTrack] = dto.TrackCanary
+
+	selector := existingDeployment.Spec.Selector.DeepCopy()
+	if selector.MatchLabels == nil {
+		selector.MatchLabels = map[string]string{}
+	}
+	selector.MatchLabels[dto.LabelKeyTrack] = dto.TrackCanary
+
+	labels := dm.resourceLabels(req.Identifier)
+	labels[dto.LabelKeyTrack] = dto.TrackCanary
+	canaryReplicas := int32(replicas)
+	canary := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Identifier + dto.CanaryDeploymentSuffix,
+			Namespace:   req.Namespace,
+			Labels:      labels,
+			Annotations: map[string]string{dto.AnnotationKeyLastRequestID: req.RequestID},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &canaryReplicas,
+			Selector: selector,
+			Template: *template,
+		},
+	}
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(canary)
+	if err != nil {
+		return nil, fmt.Errorf("convert canary deployment: %w", err)
+	}
+	// Drop the zero values the conversion fills in, so the apply only claims fields the canary sets
+	delete(object, "status")
+	unstructured.RemoveNestedField(object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(object, "spec", "template", "metadata", "creationTimestamp")
+
+	applied, err := dm.dynamic.Resource(deploymentResource).Namespace(req.Namespace).
+		Apply(ctx, canary.Name, &unstructured.Unstructured{Object: object}, dm.applyOptions(true))
+	if err != nil {
+		return nil, applyError("Deployment", canary.Name, err)
+	}
+	return dm.toDeployment(applied)
+}
+
+// CheckCanary checks the canary deployment at the end of its soak period. It returns an error wrapping
+// dto.ErrCanaryUnhealthy when a replica is not available or a container restarted or waits for a failure
+// reason such as CrashLoopBackOff.
+func (dm *DeploymentManager) CheckCanary(ctx context.Context, canary *appsv1.Deployment) error {
+	latest, err := dm.Get(ctx, canary.Namespace, canary.Name)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	desired := int32(1)
+	if latest.Spec.Replicas != nil {
+		desired = *latest.Spec.Replicas
+	}
+	if latest.Status.AvailableReplicas < desired {
+		problems = append(problems, fmt.Sprintf("%d/%d replicas available", latest.Status.AvailableReplicas, desired))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(latest.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("canary selector: %w", err)
+	}
+	pods, err := dm.clientset.CoreV1().Pods(latest.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("list canary pods: %w", err)
+	}
+	for _, pod := range pods.Items {
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if reason := containerFailure(status); reason != "" {
+				problems = append(problems, fmt.Sprintf("pod %s container %s: %s", pod.Name, status.Name, reason))
+			} else if status.RestartCount > 0 {
+				problems = append(problems, fmt.Sprintf("pod %s container %s restarted %d times", pod.Name, status.Name, status.RestartCount))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", dto.ErrCanaryUnhealthy, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// DeleteCanary deletes the canary deployment of the deployment with the given identifier; a canary that
+// does not exist is not an error.
+func (dm *DeploymentManager) DeleteCanary(ctx context.Context, namespace, identifier string) error {
+	deletePolicy := metav1.DeletePropagationForeground
+	err := dm.clientset.AppsV1().Deployments(namespace).Delete(ctx, identifier+dto.CanaryDeploymentSuffix, metav1.DeleteOptions{
+		PropagationPolicy: &deletePolicy,
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete canary deployment: %w", err)
+	}
+	return nil
+}
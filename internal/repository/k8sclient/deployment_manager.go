@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"text/template"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portstemplate "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/template"
 	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/go-viper/mapstructure/v2"
 	"go.uber.org/zap"
@@ -15,7 +17,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -24,15 +29,21 @@ import (
 // DeploymentManager handles Kubernetes deployment operations.
 type DeploymentManager struct {
 	templatesBasePath string
+	templates         portstemplate.Registry
 	clientset         *kubernetes.Clientset
+	dynamic           dynamic.Interface
 	logger            *zap.Logger
 	managerTag        string
+	// namespaceTemplate renders the objects applied to namespaces the manager creates; nil applies none
+	namespaceTemplate *template.Template
+	namespaceGC       bool
 }
 
 // NewDeploymentManager creates a new DeploymentManager.
-// templatesBasePath is the directory containing the templates folder (e.g. project root or ".").
+// templatesBasePath is the directory containing the templates folder (e.g. project root or ".");
+// templates resolves the template for a request's image.
 // cfg controls whether to use in-cluster config or kubeconfig. If nil, in-cluster is used.
-func NewDeploymentManager(templatesBasePath string, cfg *dto.K8sConfig, logger *zap.Logger) (*DeploymentManager, error) {
+func NewDeploymentManager(templatesBasePath string, templates portstemplate.Registry, cfg *dto.K8sConfig, logger *zap.Logger) (*DeploymentManager, error) {
 	restConfig, err := buildRestConfig(cfg)
 	if err != nil {
 		return nil, err
@@ -43,6 +54,11 @@ func NewDeploymentManager(templatesBasePath string, cfg *dto.K8sConfig, logger *
 		return nil, fmt.Errorf("kubernetes client: %w", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes dynamic client: %w", err)
+	}
+
 	basePath, err := filepath.Abs(templatesBasePath)
 	if err != nil {
 		return nil, fmt.Errorf("resolve templates path: %w", err)
@@ -52,11 +68,20 @@ func NewDeploymentManager(templatesBasePath string, cfg *dto.K8sConfig, logger *
 		return nil, fmt.Errorf("%s", dto.ErrMsgK8sManagerTagRequired)
 	}
 
+	namespaceTemplate, err := loadNamespaceTemplate(basePath, cfg.NamespaceTemplate)
+	if err != nil {
+		return nil, err
+	}
+
 	return &DeploymentManager{
 		templatesBasePath: basePath,
+		templates:         templates,
 		clientset:         clientset,
+		dynamic:           dynamicClient,
 		logger:            logger,
 		managerTag:        cfg.ManagerTag,
+		namespaceTemplate: namespaceTemplate,
+		namespaceGC:       cfg.NamespaceGC,
 	}, nil
 }
 
@@ -83,20 +108,33 @@ func NewClientSet(cfg *dto.K8sConfig) (kubernetes.Interface, error) {
 	return kubernetes.NewForConfig(restConfig)
 }
 
-// Create fetches the template for the image, replaces placeholders with DeploymentRequest
-// details, validates the manifest, and creates the deployment in Kubernetes.
-// When metadata contains inline HTML (keys: "html", "content", or "body"), a ConfigMap is created and mounted into the nginx container.
+// Create resolves the template for the image from the registry, replaces placeholders with
+// DeploymentRequest details, validates the manifest, and server-side applies the deployment
+// together with the companion objects the template renders (ConfigMap, Service, PDB, Ingress)
+// using the manager tag as field manager, and the HorizontalPodAutoscaler of the request's autoscaling settings. When the template has a content mount path and metadata
+// contains doc_html, a ConfigMap is applied and mounted into the container at that path, and the hash of
+// the content is set as a pod template annotation so later doc_html changes restart the pods.
+// Fields owned by another field manager fail with dto.ErrFieldOwnershipConflict unless the request
+// sets ForceOwnership.
 func (dm *DeploymentManager) Create(ctx context.Context, req *models.DeploymentRequest) (*appsv1.Deployment, error) {
-	renderer := utils.NewTemplateRenderer[dto.CreateTemplateData](dm.templatesBasePath, req.Image)
-	if renderer.TemplateName() != dto.TemplateNginx {
-		return nil, fmt.Errorf("unsupported image: only nginx is supported, got %q", req.Image)
+	tmpl, ok := dm.templates.Match(req.Image)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", dto.ErrUnsupportedImage, req.Image)
 	}
 
+	renderer := utils.NewTemplateRenderer[dto.CreateTemplateData](dm.templatesBasePath, tmpl.Name)
 	if err := renderer.Load(); err != nil {
 		return nil, fmt.Errorf("load template: %w", err)
 	}
 
-	indexHTML := dm.extractIndexHTML(req.Metadata)
+	createMetadata, err := decodeCreateMetadata(req)
+	if err != nil {
+		return nil, err
+	}
+	indexHTML := ""
+	if tmpl.ContentMountPath != "" {
+		indexHTML = createMetadata.DocHTML
+	}
 
 	data := dto.CreateTemplateData{
 		Name:                req.Name,
@@ -108,6 +146,11 @@ func (dm *DeploymentManager) Create(ctx context.Context, req *models.DeploymentR
 		DeploymentRequestID: req.ID.String(),
 		HasCustomHTML:       indexHTML != "",
 		ManagedBy:           dm.managerTag,
+		ContainerPort:       tmpl.ContainerPort,
+		ContentMountPath:    tmpl.ContentMountPath,
+	}
+	if req.TeamID != nil {
+		data.TeamID = req.TeamID.String()
 	}
 
 	manifest, err := renderer.Execute(data)
@@ -115,90 +158,77 @@ func (dm *DeploymentManager) Create(ctx context.Context, req *models.DeploymentR
 		return nil, fmt.Errorf("execute template: %w", err)
 	}
 
-	deployment, err := dm.parseAndValidate(manifest)
+	rendered, err := dm.parseManifest(manifest, req.Namespace, req.Identifier)
 	if err != nil {
 		return nil, fmt.Errorf("parse and validate: %w", err)
 	}
 
-	if err := dm.getOrCreateNamespace(ctx, req.Namespace); err != nil {
+	if err := dm.getOrCreateNamespace(ctx, req); err != nil {
 		return nil, fmt.Errorf("failed to get or create namespace: %w", err)
 	}
 
-	if indexHTML != "" {
-		configMap := dm.buildHTMLConfigMap(req.Identifier, req.Namespace, indexHTML)
-		if _, err := dm.clientset.CoreV1().ConfigMaps(req.Namespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
-			return nil, fmt.Errorf("create configmap for %s: %w", dto.ConfigMapIndexHTML, err)
-		}
-	}
-
-	created, err := dm.clientset.AppsV1().Deployments(req.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	// Apply is idempotent, so a redelivered request converges; a deployment created by another request is left alone
+	existing, found, err := dm.GetOptional(ctx, req.Namespace, rendered.deployment.GetName())
 	if err != nil {
-		return nil, fmt.Errorf("create deployment in cluster: %w", err)
+		return nil, err
 	}
-
-	return created, nil
-}
-
-func (dm *DeploymentManager) getOrCreateNamespace(ctx context.Context, namespace string) error {
-	_, err := dm.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
-	if err == nil || !apierrors.IsNotFound(err) {
-		return err
+	if found && existing.Labels[dto.LabelKeyRequestID] != req.RequestID {
+		return nil, fmt.Errorf("deployment %s/%s %s (created by request %q)",
+			req.Namespace, existing.Name, dto.StrAlreadyExists, existing.Labels[dto.LabelKeyRequestID])
 	}
 
-	_, err = dm.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{Name: namespace},
-	}, metav1.CreateOptions{})
-
-	return err
-}
-
-// parseAndValidate decodes the YAML manifest into an appsv1.Deployment and validates it.
-func (dm *DeploymentManager) parseAndValidate(manifest string) (*appsv1.Deployment, error) {
-	var depl appsv1.Deployment
-	if err := yaml.Unmarshal([]byte(manifest), &depl); err != nil {
-		return nil, fmt.Errorf("yaml unmarshal: %w", err)
+	if indexHTML != "" {
+		if err := dm.applyHTMLConfigMap(ctx, req, indexHTML); err != nil {
+			return nil, err
+		}
+	}
+	for _, obj := range rendered.configMaps {
+		if err := dm.applyObject(ctx, obj, req.ForceOwnership); err != nil {
+			return nil, err
+		}
 	}
 
-	if depl.Name == "" {
-		return nil, fmt.Errorf("deployment name is required")
+	if err := unstructured.SetNestedField(rendered.deployment.Object, req.RequestID, "metadata", "annotations", dto.AnnotationKeyLastRequestID); err != nil {
+		return nil, fmt.Errorf("set request annotation: %w", err)
 	}
-	if depl.Namespace == "" {
-		depl.Namespace = corev1.NamespaceDefault
+	if createMetadata.ReplicaCount != nil {
+		// The template's replica count is a default; the request's count may be 0 to create it scaled to zero.
+		// An autoscaled deployment starts with it and its autoscaler takes over the field once it scales.
+		if err := unstructured.SetNestedField(rendered.deployment.Object, int64(*createMetadata.ReplicaCount), "spec", "replicas"); err != nil {
+			return nil, fmt.Errorf("set replicas: %w", err)
+		}
 	}
-	if len(depl.Spec.Template.Spec.Containers) == 0 {
-		return nil, fmt.Errorf("deployment must have at least one container")
+	if indexHTML != "" {
+		if err := unstructured.SetNestedField(rendered.deployment.Object, contentHash(indexHTML), "spec", "template", "metadata", "annotations", dto.AnnotationKeyContentHash); err != nil {
+			return nil, fmt.Errorf("set content hash annotation: %w", err)
+		}
 	}
-	if depl.Spec.Template.Spec.Containers[0].Image == "" {
-		return nil, fmt.Errorf("container image is required")
+	if createMetadata.Strategy != nil {
+		if strategy := strategyObject(createMetadata.Strategy); strategy != nil {
+			if err := unstructured.SetNestedField(rendered.deployment.Object, strategy, "spec", "strategy"); err != nil {
+				return nil, fmt.Errorf("set strategy: %w", err)
+			}
+		}
 	}
-
-	return &depl, nil
-}
-
-// extractIndexHTML returns the inline HTML content from metadata if present.
-// Supports keys: "html", "content", "body"
-func (dm *DeploymentManager) extractIndexHTML(metadata models.JSONB) string {
-	if metadata == nil {
-		return ""
+	applied, err := dm.dynamic.Resource(deploymentResource).Namespace(req.Namespace).
+		Apply(ctx, rendered.deployment.GetName(), rendered.deployment, dm.applyOptions(req.ForceOwnership))
+	if err != nil {
+		return nil, applyError("Deployment", rendered.deployment.GetName(), err)
 	}
 
-	var deploymentMetadata dto.DeploymentMetadata
-	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-		Result:  &deploymentMetadata,
-		TagName: dto.MapstructureTagJSON,
-	})
-	if err != nil {
-		dm.logger.Error("failed to decode deployment metadata", zap.Error(err))
-		return ""
+	for _, obj := range rendered.others {
+		if err := dm.applyObject(ctx, obj, req.ForceOwnership); err != nil {
+			return nil, err
+		}
 	}
 
-	err = decoder.Decode(metadata)
-	if err != nil {
-		dm.logger.Error("failed to decode deployment metadata", zap.Error(err))
-		return ""
+	if createMetadata.Autoscaling != nil && !createMetadata.Autoscaling.Disabled {
+		if err := dm.applyAutoscaler(ctx, req, createMetadata.Autoscaling); err != nil {
+			return nil, err
+		}
 	}
 
-	return deploymentMetadata.DocHTML
+	return dm.toDeployment(applied)
 }
 
 // Get retrieves a deployment from Kubernetes by namespace and name
@@ -223,104 +253,167 @@ func (dm *DeploymentManager) GetOptional(ctx context.Context, namespace, name st
 	return deployment, true, nil
 }
 
-// buildHTMLConfigMap creates a ConfigMap with index.html content for nginx to serve.
-func (dm *DeploymentManager) buildHTMLConfigMap(identifier, namespace, indexHTML string) *corev1.ConfigMap {
-	return &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      identifier + dto.ConfigMapHTMLSuffix,
-			Namespace: namespace,
-		},
-		Data: map[string]string{
-			dto.ConfigMapIndexHTML: indexHTML,
-		},
+// applyHTMLConfigMap applies the ConfigMap with index.html content mounted at the template's content path.
+func (dm *DeploymentManager) applyHTMLConfigMap(ctx context.Context, req *models.DeploymentRequest, indexHTML string) error {
+	configMapName := req.Identifier + dto.ConfigMapHTMLSuffix
+	configMap := corev1ac.ConfigMap(configMapName, req.Namespace).
+		WithLabels(dm.resourceLabels(req.Identifier)).
+		WithData(map[string]string{dto.ConfigMapIndexHTML: indexHTML})
+
+	if _, err := dm.clientset.CoreV1().ConfigMaps(req.Namespace).Apply(ctx, configMap, dm.applyOptions(req.ForceOwnership)); err != nil {
+		return applyError("ConfigMap", configMapName, err)
 	}
+	return nil
 }
 
-// Update updates an existing deployment in Kubernetes based on the deployment request metadata.
-// It applies changes to replica count, resource limits, and doc_html (ConfigMap) if provided.
+// Update applies the changes in the deployment request metadata (replica count, resource limits, image,
+// rollout strategy and doc_html) with server-side apply. A doc_html change updates the content hash pod
+// template annotation, which rolls out pods serving the new content. Autoscaling settings apply or delete the
+// deployment's HorizontalPodAutoscaler; while it has one, the replica count is left to the autoscaler and the
+// request's replica count is ignored. The apply configuration starts from the fields
+// this manager already owns, so fields managed by others (e.g. replicas set by an HPA) are left alone
+// unless the request changes them; changing such a field fails with dto.ErrFieldOwnershipConflict unless
+// ForceOwnership is set.
 func (dm *DeploymentManager) Update(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (*appsv1.Deployment, error) {
-	// Make a copy to avoid modifying the original
-	updatedDeployment := existingDeployment.DeepCopy()
-
 	// Extract metadata from request
-	var updateMetadata dto.UpdateDeploymentRequestMetadata
-	if req.Metadata != nil {
-		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-			Result:  &updateMetadata,
-			TagName: dto.MapstructureTagJSON,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create decoder: %w", err)
-		}
-		if err := decoder.Decode(req.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to decode update metadata: %w", err)
+	updateMetadata, err := decodeUpdateMetadata(req)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := dm.ownedDeployment(req, existingDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	// An autoscaler removed by the request hands the replica count back before it is applied
+	autoscaled, err := dm.autoscaled(ctx, req, updateMetadata.Autoscaling)
+	if err != nil {
+		return nil, err
+	}
+	if updateMetadata.Autoscaling != nil {
+		if err := dm.applyAutoscaler(ctx, req, updateMetadata.Autoscaling); err != nil {
+			return nil, fmt.Errorf("update autoscaling: %w", err)
 		}
 	}
 
 	// Apply updates based on provided metadata fields
-	if updateMetadata.ReplicaCount != nil {
-		if err := dm.updateReplicaCount(updatedDeployment, *updateMetadata.ReplicaCount); err != nil {
-			return nil, fmt.Errorf("update replica count: %w", err)
-		}
+	if updateMetadata.ReplicaCount != nil && !autoscaled {
+		deployment.Spec.WithReplicas(int32(*updateMetadata.ReplicaCount))
 	}
 
 	if updateMetadata.ResourceLimit != nil {
-		if err := dm.updateResourceLimits(updatedDeployment, updateMetadata.ResourceLimit); err != nil {
+		if err := dm.applyResourceLimits(deployment, existingDeployment, updateMetadata.ResourceLimit); err != nil {
 			return nil, fmt.Errorf("update resource limits: %w", err)
 		}
 	}
 
-	if updateMetadata.DocHTML != nil {
-		if err := dm.updateConfigMap(ctx, req, *updateMetadata.DocHTML); err != nil {
+	if updateMetadata.Image != nil {
+		// A new pod template image is rolled out by the deployment's rolling update strategy
+		if len(existingDeployment.Spec.Template.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("update image: deployment has no containers")
+		}
+		setContainerImage(deployment, existingDeployment.Spec.Template.Spec.Containers[0].Name, *updateMetadata.Image)
+	}
+
+	if updateMetadata.Strategy != nil {
+		if err := dm.applyStrategy(ctx, deployment, existingDeployment, updateMetadata.Strategy); err != nil {
+			return nil, fmt.Errorf("update strategy: %w", err)
+		}
+	}
+
+	if updateMetadata.DocHTML != nil && *updateMetadata.DocHTML != "" {
+		// Empty doc_html means no update needed
+		if err := dm.applyHTMLConfigMap(ctx, req, *updateMetadata.DocHTML); err != nil {
 			return nil, fmt.Errorf("update configmap: %w", err)
 		}
+		setPodTemplateAnnotation(deployment, dto.AnnotationKeyContentHash, contentHash(*updateMetadata.DocHTML))
 	}
 
-	// Update the deployment in Kubernetes
-	updated, err := dm.clientset.AppsV1().Deployments(req.Namespace).Update(ctx, updatedDeployment, metav1.UpdateOptions{})
+	updated, err := dm.clientset.AppsV1().Deployments(req.Namespace).Apply(ctx, deployment, dm.applyOptions(req.ForceOwnership))
 	if err != nil {
-		return nil, fmt.Errorf("update deployment in cluster: %w", err)
+		return nil, applyError("Deployment", existingDeployment.Name, err)
 	}
 
 	return updated, nil
 }
 
-// updateReplicaCount updates the replica count of a deployment.
-func (dm *DeploymentManager) updateReplicaCount(deployment *appsv1.Deployment, replicaCount int) error {
-	replicas := int32(replicaCount)
-	deployment.Spec.Replicas = &replicas
-	return nil
+// decodeCreateMetadata decodes the metadata of a CREATE request.
+func decodeCreateMetadata(req *models.DeploymentRequest) (*dto.DeploymentMetadata, error) {
+	var createMetadata dto.DeploymentMetadata
+	if err := decodeRequestMetadata(req, &createMetadata); err != nil {
+		return nil, fmt.Errorf("failed to decode deployment metadata: %w", err)
+	}
+	return &createMetadata, nil
+}
+
+// decodeUpdateMetadata decodes the metadata of an UPDATE or ROLLBACK request.
+func decodeUpdateMetadata(req *models.DeploymentRequest) (*dto.UpdateDeploymentRequestMetadata, error) {
+	var updateMetadata dto.UpdateDeploymentRequestMetadata
+	if err := decodeRequestMetadata(req, &updateMetadata); err != nil {
+		return nil, fmt.Errorf("failed to decode update metadata: %w", err)
+	}
+	return &updateMetadata, nil
 }
 
-// updateResourceLimits updates the resource limits and requests of the first container in a deployment.
-func (dm *DeploymentManager) updateResourceLimits(deployment *appsv1.Deployment, resourceLimit *dto.ResourceMetadata) error {
-	if len(deployment.Spec.Template.Spec.Containers) == 0 {
+// decodeRequestMetadata decodes the metadata of a request into out by JSON field names.
+func decodeRequestMetadata(req *models.DeploymentRequest, out interface{}) error {
+	if req.Metadata == nil {
+		return nil
+	}
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  out,
+		TagName: dto.MapstructureTagJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	return decoder.Decode(map[string]interface{}(req.Metadata))
+}
+
+// applyResourceLimits sets the resource limits and requests of the deployment's first container on the
+// apply configuration.
+func (dm *DeploymentManager) applyResourceLimits(deployment *appsv1ac.DeploymentApplyConfiguration, existingDeployment *appsv1.Deployment, resourceLimit *dto.ResourceMetadata) error {
+	if len(existingDeployment.Spec.Template.Spec.Containers) == 0 {
 		return fmt.Errorf("deployment has no containers")
 	}
+	containerName := existingDeployment.Spec.Template.Spec.Containers[0].Name
 
-	container := &deployment.Spec.Template.Spec.Containers[0]
+	requirements, err := resourceRequirements(resourceLimit)
+	if err != nil {
+		return err
+	}
+	resources := corev1ac.ResourceRequirements().
+		WithRequests(requirements.Requests).
+		WithLimits(requirements.Limits)
 
+	setContainerResources(deployment, containerName, resources)
+	return nil
+}
+
+// resourceRequirements parses the CPU and memory requests and limits of the request metadata.
+func resourceRequirements(resourceLimit *dto.ResourceMetadata) (corev1.ResourceRequirements, error) {
 	// Parse CPU and memory for requests
 	requestCPU, err := resource.ParseQuantity(resourceLimit.Request.CPU)
 	if err != nil {
-		return fmt.Errorf("invalid CPU request value: %w", err)
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid CPU request value: %w", err)
 	}
 	requestMemory, err := resource.ParseQuantity(resourceLimit.Request.Memory)
 	if err != nil {
-		return fmt.Errorf("invalid memory request value: %w", err)
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid memory request value: %w", err)
 	}
 
 	// Parse CPU and memory for limits
 	limitCPU, err := resource.ParseQuantity(resourceLimit.Limit.CPU)
 	if err != nil {
-		return fmt.Errorf("invalid CPU limit value: %w", err)
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid CPU limit value: %w", err)
 	}
 	limitMemory, err := resource.ParseQuantity(resourceLimit.Limit.Memory)
 	if err != nil {
-		return fmt.Errorf("invalid memory limit value: %w", err)
+		return corev1.ResourceRequirements{}, fmt.Errorf("invalid memory limit value: %w", err)
 	}
 
-	container.Resources = corev1.ResourceRequirements{
+	return corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
 			corev1.ResourceCPU:    requestCPU,
 			corev1.ResourceMemory: requestMemory,
@@ -329,50 +422,60 @@ func (dm *DeploymentManager) updateResourceLimits(deployment *appsv1.Deployment,
 			corev1.ResourceCPU:    limitCPU,
 			corev1.ResourceMemory: limitMemory,
 		},
-	}
-
-	return nil
+	}, nil
 }
 
-// updateConfigMap updates or creates a ConfigMap with HTML content for the deployment.
-func (dm *DeploymentManager) updateConfigMap(ctx context.Context, req *models.DeploymentRequest, docHTML string) error {
-	if docHTML == "" {
-		// Empty doc_html means no update needed
-		return nil
-	}
+// setContainerResources sets the resources of the named container on the apply configuration. Containers
+// are merged by name, so only the resources are claimed for a container this manager does not own yet.
+// Nil resources drop this manager's resources of the container.
+func setContainerResources(deployment *appsv1ac.DeploymentApplyConfiguration, containerName string, resources *corev1ac.ResourceRequirementsApplyConfiguration) {
+	containerApplyConfiguration(deployment, containerName).Resources = resources
+}
 
-	configMapName := req.Identifier + dto.ConfigMapHTMLSuffix
-	configMap := dm.buildHTMLConfigMap(req.Identifier, req.Namespace, docHTML)
+// setContainerImage sets the image of the named container on the apply configuration
+func setContainerImage(deployment *appsv1ac.DeploymentApplyConfiguration, containerName, image string) {
+	containerApplyConfiguration(deployment, containerName).WithImage(image)
+}
 
-	// Try to update existing ConfigMap, or create if it doesn't exist
-	_, err := dm.clientset.CoreV1().ConfigMaps(req.Namespace).Update(ctx, configMap, metav1.UpdateOptions{})
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			// Create if it doesn't exist
-			_, err = dm.clientset.CoreV1().ConfigMaps(req.Namespace).Create(ctx, configMap, metav1.CreateOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to create configmap %s: %w", configMapName, err)
-			}
-		} else {
-			return fmt.Errorf("failed to update configmap %s: %w", configMapName, err)
+// containerApplyConfiguration returns the named container of the apply configuration, adding it with just
+// its name when this manager does not own any of its fields yet.
+func containerApplyConfiguration(deployment *appsv1ac.DeploymentApplyConfiguration, containerName string) *corev1ac.ContainerApplyConfiguration {
+	if deployment.Spec.Template == nil {
+		deployment.Spec.WithTemplate(corev1ac.PodTemplateSpec())
+	}
+	if deployment.Spec.Template.Spec == nil {
+		deployment.Spec.Template.WithSpec(corev1ac.PodSpec())
+	}
+	podSpec := deployment.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name != nil && *podSpec.Containers[i].Name == containerName {
+			return &podSpec.Containers[i]
 		}
 	}
-
-	return nil
+	podSpec.WithContainers(corev1ac.Container().WithName(containerName))
+	return &podSpec.Containers[len(podSpec.Containers)-1]
 }
 
-// Delete deletes a deployment from Kubernetes by namespace and name.
+// Delete deletes a deployment from Kubernetes by namespace and name (the identifier), together with its
+// canary and every companion object labelled with its identifier (ConfigMaps, Services, PDBs, HPAs, Ingresses).
+// The namespace is left to CollectNamespace.
 func (dm *DeploymentManager) Delete(ctx context.Context, namespace, name string) error {
 	deletePolicy := metav1.DeletePropagationForeground
 	err := dm.clientset.AppsV1().Deployments(namespace).Delete(ctx, name, metav1.DeleteOptions{
 		PropagationPolicy: &deletePolicy,
 	})
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			// Deployment already deleted, consider it success
-			return nil
-		}
+	// Deployment already deleted is considered success; companion objects are still cleaned up
+	if err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("delete deployment from cluster: %w", err)
 	}
+
+	if err := dm.DeleteCanary(ctx, namespace, name); err != nil {
+		return err
+	}
+
+	if err := dm.deleteCompanionObjects(ctx, namespace, name); err != nil {
+		return fmt.Errorf("delete companion objects: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,211 @@
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// namespaceKinds are the kinds a namespace template may contain, applied in namespaceKindOrder.
+var namespaceKinds = map[string]templateKind{
+	"ResourceQuota": {"v1", schema.GroupVersionResource{Version: "v1", Resource: "resourcequotas"}},
+	"LimitRange":    {"v1", schema.GroupVersionResource{Version: "v1", Resource: "limitranges"}},
+	"NetworkPolicy": {"networking.k8s.io/v1", schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"}},
+}
+
+// namespaceKindOrder is the apply order of the namespace template kinds.
+var namespaceKindOrder = []string{"ResourceQuota", "LimitRange", "NetworkPolicy"}
+
+// loadNamespaceTemplate parses the namespace template at file (relative to basePath unless absolute).
+// An empty file disables namespace provisioning and returns nil.
+func loadNamespaceTemplate(basePath, file string) (*template.Template, error) {
+	if file == "" {
+		return nil, nil
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(basePath, file)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read namespace template: %w", err)
+	}
+	tmpl, err := template.New("namespace").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse namespace template %q: %w", file, err)
+	}
+	return tmpl, nil
+}
+
+// getOrCreateNamespace creates the request's namespace, labelled with the manager tag and the owner of the
+// deployment, when it does not exist. Namespaces the manager created then receive the objects of the
+// namespace template; namespaces created by others are used as they are.
+func (dm *DeploymentManager) getOrCreateNamespace(ctx context.Context, req *models.DeploymentRequest) error {
+	namespace, err := dm.clientset.CoreV1().Namespaces().Get(ctx, req.Namespace, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		labels := map[string]string{
+			dto.LabelKeyManagedBy: dm.managerTag,
+			dto.LabelKeyUserID:    req.UserID.String(),
+		}
+		if req.TeamID != nil {
+			labels[dto.LabelKeyTeamID] = req.TeamID.String()
+		}
+		namespace, err = dm.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Namespace, Labels: labels},
+		}, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		if err != nil {
+			// Created concurrently by another request
+			if namespace, err = dm.clientset.CoreV1().Namespaces().Get(ctx, req.Namespace, metav1.GetOptions{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !dm.managesNamespace(namespace) {
+		return nil
+	}
+	// Applied on every create so a namespace whose provisioning failed, or an updated template, converges
+	return dm.provisionNamespace(ctx, namespace)
+}
+
+// managesNamespace reports whether the namespace was created by this manager.
+func (dm *DeploymentManager) managesNamespace(namespace *corev1.Namespace) bool {
+	return namespace.Labels[dto.LabelKeyManagedBy] == dm.managerTag
+}
+
+// provisionNamespace renders the namespace template for the namespace and server-side applies its objects.
+func (dm *DeploymentManager) provisionNamespace(ctx context.Context, namespace *corev1.Namespace) error {
+	if dm.namespaceTemplate == nil {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	data := dto.NamespaceTemplateData{
+		Namespace: namespace.Name,
+		ManagedBy: dm.managerTag,
+		UserID:    namespace.Labels[dto.LabelKeyUserID],
+		TeamID:    namespace.Labels[dto.LabelKeyTeamID],
+	}
+	if err := dm.namespaceTemplate.Execute(&buf, data); err != nil {
+		return fmt.Errorf("execute namespace template: %w", err)
+	}
+
+	objects, err := dm.parseNamespaceManifest(buf.String(), namespace.Name)
+	if err != nil {
+		return fmt.Errorf("parse namespace template: %w", err)
+	}
+	for _, obj := range objects {
+		client := dm.dynamic.Resource(namespaceKinds[obj.GetKind()].resource).Namespace(namespace.Name)
+		if _, err := client.Apply(ctx, obj.GetName(), obj, dm.applyOptions(true)); err != nil {
+			return applyError(obj.GetKind(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// parseNamespaceManifest decodes the rendered namespace template, validates the kinds and forces the
+// namespace and managed-by label on every object. Objects are returned in namespaceKindOrder.
+func (dm *DeploymentManager) parseNamespaceManifest(manifest, namespace string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(manifest)), 4096)
+	byKind := make(map[string][]*unstructured.Unstructured)
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("yaml decode: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		kind := obj.GetKind()
+		k, ok := namespaceKinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported kind %q in namespace template", kind)
+		}
+		if obj.GetAPIVersion() != k.apiVersion {
+			return nil, fmt.Errorf("unsupported apiVersion %q for %s: expected %s", obj.GetAPIVersion(), kind, k.apiVersion)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("%s name is required", kind)
+		}
+		obj.SetNamespace(namespace)
+		if err := unstructured.SetNestedField(obj.Object, dm.managerTag, "metadata", "labels", dto.LabelKeyManagedBy); err != nil {
+			return nil, fmt.Errorf("set label %s on %s: %w", dto.LabelKeyManagedBy, kind, err)
+		}
+		byKind[kind] = append(byKind[kind], obj)
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, kind := range namespaceKindOrder {
+		objects = append(objects, byKind[kind]...)
+	}
+	return objects, nil
+}
+
+// CollectNamespace deletes the namespace when namespace GC is enabled, the manager created it and no
+// deployment is left in it (deployments being deleted do not count). Failures are only logged, so a
+// deployment delete never fails because of its namespace. The caller must make sure no CREATE request
+// for the namespace is pending, since its worker may have provisioned the namespace but not yet applied
+// the deployment.
+func (dm *DeploymentManager) CollectNamespace(ctx context.Context, name string) {
+	if !dm.namespaceGC {
+		return
+	}
+
+	namespace, err := dm.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			dm.logger.Warn("Failed to get namespace for garbage collection", zap.String("namespace", name), zap.Error(err))
+		}
+		return
+	}
+	if !dm.managesNamespace(namespace) || namespace.DeletionTimestamp != nil {
+		return
+	}
+
+	deployments, err := dm.clientset.AppsV1().Deployments(name).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		dm.logger.Warn("Failed to list deployments for namespace garbage collection", zap.String("namespace", name), zap.Error(err))
+		return
+	}
+	for _, d := range deployments.Items {
+		if d.DeletionTimestamp == nil {
+			return
+		}
+	}
+
+	// Only delete the namespace version that was checked, in case it was recreated meanwhile
+	err = dm.clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &namespace.UID},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		dm.logger.Warn("Failed to delete empty namespace", zap.String("namespace", name), zap.Error(err))
+		return
+	}
+	dm.logger.Info("Deleted empty managed namespace", zap.String("namespace", name))
+}
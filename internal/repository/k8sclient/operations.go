@@ -0,0 +1,81 @@
+package k8sclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// contentHashLength is the number of hex characters of the doc_html hash kept in the pod template annotation.
+const contentHashLength = 16
+
+// Restart restarts the pods of the deployment with a rolling update by setting the restartedAt pod template
+// annotation, as `kubectl rollout restart` does. The annotation holds the time the request was created, so a
+// redelivered request applies the same pod template and does not restart the pods again.
+func (dm *DeploymentManager) Restart(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	deployment, err := dm.ownedDeployment(req, existingDeployment)
+	if err != nil {
+		return nil, err
+	}
+	setPodTemplateAnnotation(deployment, dto.AnnotationKeyRestartedAt, req.CreatedOn.UTC().Format(time.RFC3339))
+
+	updated, err := dm.clientset.AppsV1().Deployments(req.Namespace).Apply(ctx, deployment, dm.applyOptions(req.ForceOwnership))
+	if err != nil {
+		return nil, applyError("Deployment", existingDeployment.Name, err)
+	}
+	return updated, nil
+}
+
+// SetPaused sets spec.paused of the deployment. While paused, pod template changes are recorded but not
+// rolled out; resuming rolls out the pending changes.
+func (dm *DeploymentManager) SetPaused(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment, paused bool) (*appsv1.Deployment, error) {
+	deployment, err := dm.ownedDeployment(req, existingDeployment)
+	if err != nil {
+		return nil, err
+	}
+	deployment.Spec.WithPaused(paused)
+
+	updated, err := dm.clientset.AppsV1().Deployments(req.Namespace).Apply(ctx, deployment, dm.applyOptions(req.ForceOwnership))
+	if err != nil {
+		return nil, applyError("Deployment", existingDeployment.Name, err)
+	}
+	return updated, nil
+}
+
+// ownedDeployment returns an apply configuration holding the fields of the deployment this manager owns,
+// annotated with the request ID.
+func (dm *DeploymentManager) ownedDeployment(req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (*appsv1ac.DeploymentApplyConfiguration, error) {
+	deployment, err := appsv1ac.ExtractDeployment(existingDeployment, dm.managerTag)
+	if err != nil {
+		return nil, fmt.Errorf("extract owned deployment fields: %w", err)
+	}
+	if deployment.Spec == nil {
+		deployment.WithSpec(appsv1ac.DeploymentSpec())
+	}
+	deployment.WithAnnotations(map[string]string{dto.AnnotationKeyLastRequestID: req.RequestID})
+	return deployment, nil
+}
+
+// setPodTemplateAnnotation sets an annotation of the pod template on the apply configuration. Changing a
+// pod template annotation rolls out new pods.
+func setPodTemplateAnnotation(deployment *appsv1ac.DeploymentApplyConfiguration, key, value string) {
+	if deployment.Spec.Template == nil {
+		deployment.Spec.WithTemplate(corev1ac.PodTemplateSpec())
+	}
+	deployment.Spec.Template.WithAnnotations(map[string]string{key: value})
+}
+
+// contentHash returns the truncated SHA-256 hex digest of the doc_html content. The ConfigMap volume is
+// updated in place, so the hash in the pod template is what restarts the pods when the content changes.
+func contentHash(indexHTML string) string {
+	sum := sha256.Sum256([]byte(indexHTML))
+	return hex.EncodeToString(sum[:])[:contentHashLength]
+}
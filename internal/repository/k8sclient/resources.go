@@ -0,0 +1,211 @@
+package k8sclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// templateKind describes a kind that templates may render, in the order objects are applied.
+type templateKind struct {
+	apiVersion string
+	resource   schema.GroupVersionResource
+}
+
+// templateKinds are the companion kinds of a Deployment, deleted together with it. ConfigMaps are
+// applied first so pods can mount them; objects that reference the Deployment come after it.
+// HorizontalPodAutoscaler is listed for cleanup only: templates cannot render one, since the
+// autoscaler is managed from the request's autoscaling settings (applyAutoscaler).
+var templateKinds = map[string]templateKind{
+	"ConfigMap":               {"v1", schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}},
+	"Service":                 {"v1", schema.GroupVersionResource{Version: "v1", Resource: "services"}},
+	"PodDisruptionBudget":     {"policy/v1", schema.GroupVersionResource{Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}},
+	"HorizontalPodAutoscaler": {"autoscaling/v2", schema.GroupVersionResource{Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"}},
+	"Ingress":                 {"networking.k8s.io/v1", schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}},
+}
+
+// deploymentResource is the resource the rendered Deployment is applied to.
+var deploymentResource = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+
+// templateKindOrder is the apply order of the extra kinds; the Deployment is applied after ConfigMaps.
+var templateKindOrder = []string{"ConfigMap", "Service", "PodDisruptionBudget", "HorizontalPodAutoscaler", "Ingress"}
+
+// renderedObjects is a parsed multi-document template: the Deployment plus its companion objects.
+// The Deployment is kept as rendered for server-side apply, so only the fields the template sets
+// are owned by this manager.
+type renderedObjects struct {
+	deployment *unstructured.Unstructured
+	configMaps []*unstructured.Unstructured
+	others     []*unstructured.Unstructured
+}
+
+// parseManifest decodes every document of the rendered manifest, validates the kinds and forces the
+// namespace and the managed-by/identifier labels on every object so they can be found for cleanup.
+// Exactly one Deployment is required.
+func (dm *DeploymentManager) parseManifest(manifest, namespace, identifier string) (*renderedObjects, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(manifest)), 4096)
+	rendered := &renderedObjects{}
+
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("yaml decode: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			// Empty document (e.g. a section disabled by a template condition)
+			continue
+		}
+
+		obj.SetNamespace(namespace)
+		// Set labels key by key: GetLabels drops the whole map if any rendered value is not a string
+		for key, value := range dm.resourceLabels(identifier) {
+			if err := unstructured.SetNestedField(obj.Object, value, "metadata", "labels", key); err != nil {
+				return nil, fmt.Errorf("set label %s on %s: %w", key, obj.GetKind(), err)
+			}
+		}
+
+		kind := obj.GetKind()
+		if kind == "Deployment" {
+			if rendered.deployment != nil {
+				return nil, fmt.Errorf("template must contain exactly one Deployment")
+			}
+			if _, err := dm.toDeployment(obj); err != nil {
+				return nil, err
+			}
+			rendered.deployment = obj
+			continue
+		}
+
+		if kind == "HorizontalPodAutoscaler" {
+			// A second autoscaler would fight the request's one over spec.replicas
+			return nil, fmt.Errorf("templates cannot render a HorizontalPodAutoscaler: use the request's autoscaling settings")
+		}
+		k, ok := templateKinds[kind]
+		if !ok {
+			return nil, fmt.Errorf("unsupported kind %q in template", kind)
+		}
+		if obj.GetAPIVersion() != k.apiVersion {
+			return nil, fmt.Errorf("unsupported apiVersion %q for %s: expected %s", obj.GetAPIVersion(), kind, k.apiVersion)
+		}
+		if obj.GetName() == "" {
+			return nil, fmt.Errorf("%s name is required", kind)
+		}
+		if kind == "ConfigMap" {
+			rendered.configMaps = append(rendered.configMaps, obj)
+		} else {
+			rendered.others = append(rendered.others, obj)
+		}
+	}
+
+	if rendered.deployment == nil {
+		return nil, fmt.Errorf("template must contain a Deployment")
+	}
+
+	// Apply companion objects in a stable kind order
+	order := make(map[string]int, len(templateKindOrder))
+	for i, kind := range templateKindOrder {
+		order[kind] = i
+	}
+	sort.SliceStable(rendered.others, func(i, j int) bool {
+		return order[rendered.others[i].GetKind()] < order[rendered.others[j].GetKind()]
+	})
+	return rendered, nil
+}
+
+// toDeployment converts the Deployment document and validates it.
+func (dm *DeploymentManager) toDeployment(obj *unstructured.Unstructured) (*appsv1.Deployment, error) {
+	var depl appsv1.Deployment
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &depl); err != nil {
+		return nil, fmt.Errorf("decode deployment: %w", err)
+	}
+
+	if depl.Name == "" {
+		return nil, fmt.Errorf("deployment name is required")
+	}
+	if depl.Namespace == "" {
+		depl.Namespace = corev1.NamespaceDefault
+	}
+	if len(depl.Spec.Template.Spec.Containers) == 0 {
+		return nil, fmt.Errorf("deployment must have at least one container")
+	}
+	if depl.Spec.Template.Spec.Containers[0].Image == "" {
+		return nil, fmt.Errorf("container image is required")
+	}
+
+	return &depl, nil
+}
+
+// resourceLabels returns the labels every object created for a deployment carries.
+func (dm *DeploymentManager) resourceLabels(identifier string) map[string]string {
+	return map[string]string{
+		dto.LabelKeyManagedBy:  dm.managerTag,
+		dto.LabelKeyIdentifier: identifier,
+	}
+}
+
+// applyOptions returns the server-side apply options for this manager. Force takes over fields
+// owned by other field managers instead of failing with a conflict.
+func (dm *DeploymentManager) applyOptions(force bool) metav1.ApplyOptions {
+	return metav1.ApplyOptions{FieldManager: dm.managerTag, Force: force}
+}
+
+// applyObject server-side applies obj, creating it or updating the fields this manager owns.
+func (dm *DeploymentManager) applyObject(ctx context.Context, obj *unstructured.Unstructured, force bool) error {
+	client := dm.dynamic.Resource(templateKinds[obj.GetKind()].resource).Namespace(obj.GetNamespace())
+	if _, err := client.Apply(ctx, obj.GetName(), obj, dm.applyOptions(force)); err != nil {
+		return applyError(obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// applyError wraps an apply error; conflicts with other field managers wrap dto.ErrFieldOwnershipConflict
+// so the worker can fail the request immediately instead of retrying.
+func applyError(kind, name string, err error) error {
+	if apierrors.IsConflict(err) {
+		return fmt.Errorf("%w: apply %s %s: %v", dto.ErrFieldOwnershipConflict, kind, name, err)
+	}
+	return fmt.Errorf("apply %s %s: %w", kind, name, err)
+}
+
+// deleteCompanionObjects deletes every companion object (ConfigMap, Service, PDB, HPA, Ingress) labelled
+// with the deployment's identifier and this manager's tag. Objects already gone are ignored.
+func (dm *DeploymentManager) deleteCompanionObjects(ctx context.Context, namespace, identifier string) error {
+	selector := labels.SelectorFromSet(dm.resourceLabels(identifier)).String()
+
+	for _, kind := range templateKindOrder {
+		client := dm.dynamic.Resource(templateKinds[kind].resource).Namespace(namespace)
+		list, err := client.List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return fmt.Errorf("list %s objects: %w", kind, err)
+		}
+		for _, item := range list.Items {
+			if err := client.Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("delete %s %s: %w", kind, item.GetName(), err)
+			}
+			dm.logger.Debug("Deleted companion object",
+				zap.String("kind", kind),
+				zap.String("namespace", namespace),
+				zap.String("name", item.GetName()),
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package k8sclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// podFailureReasons are container waiting reasons reported when a rollout fails.
+var podFailureReasons = map[string]bool{
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CrashLoopBackOff":           true,
+	"CreateContainerError":       true,
+	"CreateContainerConfigError": true,
+	"RunContainerError":          true,
+}
+
+// WaitForRollout polls the deployment until the rollout of the given generation completes (all replicas
+// updated and available, no old replicas left). It returns an error wrapping dto.ErrRolloutFailed when the
+// deployment reports ProgressDeadlineExceeded or the timeout elapses; the error lists the failing pods.
+func (dm *DeploymentManager) WaitForRollout(ctx context.Context, deployment *appsv1.Deployment, timeout time.Duration) error {
+	var (
+		latest *appsv1.Deployment
+		stuck  string
+	)
+
+	err := wait.PollUntilContextTimeout(ctx, dto.RolloutPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		d, err := dm.clientset.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, fmt.Errorf("get deployment from cluster: %w", err)
+		}
+		latest = d
+
+		done, reason := rolloutStatus(d, deployment.Generation)
+		if reason != "" {
+			stuck = reason
+			return false, dto.ErrRolloutFailed
+		}
+		return done, nil
+	})
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, dto.ErrRolloutFailed):
+	case wait.Interrupted(err) && ctx.Err() == nil:
+		stuck = fmt.Sprintf("rollout did not complete within %s", timeout)
+	default:
+		return err
+	}
+
+	if latest != nil {
+		stuck += rolloutProgress(latest)
+		if pods := dm.podFailures(ctx, latest); pods != "" {
+			stuck += "; " + pods
+		}
+	}
+	return fmt.Errorf("%w: %s", dto.ErrRolloutFailed, stuck)
+}
+
+// rolloutStatus reports whether the rollout of generation (or a later one) is complete,
+// or the stuck reason when the deployment exceeded its progress deadline.
+func rolloutStatus(d *appsv1.Deployment, generation int64) (bool, string) {
+	if d.Status.ObservedGeneration < generation || d.Status.ObservedGeneration < d.Generation {
+		return false, ""
+	}
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == dto.ReasonProgressDeadlineExceeded {
+			return false, fmt.Sprintf("%s: %s", dto.ReasonProgressDeadlineExceeded, cond.Message)
+		}
+	}
+
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < desired {
+		return false, ""
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		// Old replicas are still terminating
+		return false, ""
+	}
+	return d.Status.AvailableReplicas >= d.Status.UpdatedReplicas, ""
+}
+
+// rolloutProgress summarises the replica counts of a deployment for a failure reason.
+func rolloutProgress(d *appsv1.Deployment) string {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	return fmt.Sprintf(" (%d/%d updated, %d available)", d.Status.UpdatedReplicas, desired, d.Status.AvailableReplicas)
+}
+
+// podFailures lists the containers of the deployment's pods that wait for a failure reason such as
+// ImagePullBackOff or CrashLoopBackOff. Pods that cannot be listed are only logged.
+func (dm *DeploymentManager) podFailures(ctx context.Context, d *appsv1.Deployment) string {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return ""
+	}
+	pods, err := dm.clientset.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		dm.logger.Warn("Failed to list pods for rollout failure reason",
+			zap.String("namespace", d.Namespace),
+			zap.String("name", d.Name),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	var failures []string
+	for _, pod := range pods.Items {
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if reason := containerFailure(status); reason != "" {
+				failures = append(failures, fmt.Sprintf("pod %s container %s: %s", pod.Name, status.Name, reason))
+			}
+		}
+	}
+	return strings.Join(failures, "; ")
+}
+
+// containerFailure returns the failure reason and message of a waiting container, or "" if it is not failing.
+func containerFailure(status corev1.ContainerStatus) string {
+	waiting := status.State.Waiting
+	if waiting == nil || !podFailureReasons[waiting.Reason] {
+		return ""
+	}
+	if waiting.Message == "" {
+		return waiting.Reason
+	}
+	return fmt.Sprintf("%s: %s", waiting.Reason, waiting.Message)
+}
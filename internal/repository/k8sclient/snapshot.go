@@ -0,0 +1,190 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/go-viper/mapstructure/v2"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// Snapshot records the fields the UPDATE or ROLLBACK request will change (replica count, resources and image
+// of the first container, rollout strategy, doc_html, autoscaling) as they are on the existing deployment, in the
+// form decoded by dto.DeploymentSnapshot. doc_html is only recorded when the HTML ConfigMap exists.
+func (dm *DeploymentManager) Snapshot(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (models.JSONB, error) {
+	updateMetadata, err := decodeUpdateMetadata(req)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := models.JSONB{}
+	if updateMetadata.ReplicaCount != nil {
+		replicas := 1
+		if existingDeployment.Spec.Replicas != nil {
+			replicas = int(*existingDeployment.Spec.Replicas)
+		}
+		snapshot["replicas"] = replicas
+	}
+
+	if updateMetadata.ResourceLimit != nil && len(existingDeployment.Spec.Template.Spec.Containers) > 0 {
+		container := existingDeployment.Spec.Template.Spec.Containers[0]
+		snapshot["container"] = container.Name
+		snapshot["resources"] = map[string]interface{}{
+			"requests": utils.ResourceListToMap(container.Resources.Requests),
+			"limits":   utils.ResourceListToMap(container.Resources.Limits),
+		}
+	}
+
+	if updateMetadata.Image != nil && len(existingDeployment.Spec.Template.Spec.Containers) > 0 {
+		container := existingDeployment.Spec.Template.Spec.Containers[0]
+		snapshot["container"] = container.Name
+		snapshot["image"] = container.Image
+	}
+
+	if updateMetadata.Strategy != nil && updateMetadata.Strategy.Type != dto.StrategyCanary {
+		snapshot["strategy"] = deploymentStrategy(existingDeployment)
+	}
+
+	if updateMetadata.DocHTML != nil && *updateMetadata.DocHTML != "" {
+		configMap, err := dm.clientset.CoreV1().ConfigMaps(req.Namespace).Get(ctx, req.Identifier+dto.ConfigMapHTMLSuffix, metav1.GetOptions{})
+		switch {
+		case err == nil:
+			snapshot["doc_html"] = configMap.Data[dto.ConfigMapIndexHTML]
+		case !apierrors.IsNotFound(err):
+			return nil, fmt.Errorf("get configmap %s: %w", req.Identifier+dto.ConfigMapHTMLSuffix, err)
+		}
+	}
+
+	if updateMetadata.Autoscaling != nil {
+		hpa, found, err := dm.GetAutoscaler(ctx, req.Namespace, req.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			snapshot["autoscaling"] = autoscalingSettings(hpa)
+		} else {
+			snapshot["autoscaling"] = &dto.AutoscalingSettings{Disabled: true}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Restore applies a snapshot taken by Snapshot, putting back the replica count, container resources, image,
+// rollout strategy, doc_html and autoscaler the deployment had before the request. Resources the container did not
+// have are dropped; the replica count of a deployment that is autoscaled again is left to its autoscaler.
+func (dm *DeploymentManager) Restore(ctx context.Context, req *models.DeploymentRequest, snapshot models.JSONB) error {
+	var state dto.DeploymentSnapshot
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  &state,
+		TagName: dto.MapstructureTagJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if err := decoder.Decode(map[string]interface{}(snapshot)); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	if state.DocHTML != nil {
+		if err := dm.applyHTMLConfigMap(ctx, req, *state.DocHTML); err != nil {
+			return fmt.Errorf("restore configmap: %w", err)
+		}
+	}
+
+	autoscaled, err := dm.autoscaled(ctx, req, state.Autoscaling)
+	if err != nil {
+		return err
+	}
+	if state.Autoscaling != nil {
+		if err := dm.applyAutoscaler(ctx, req, state.Autoscaling); err != nil {
+			return fmt.Errorf("restore autoscaling: %w", err)
+		}
+	}
+
+	if state.Replicas == nil && state.Resources == nil && state.Image == nil && state.Strategy == nil && state.DocHTML == nil {
+		return nil
+	}
+
+	existingDeployment, err := dm.Get(ctx, req.Namespace, req.Identifier)
+	if err != nil {
+		return err
+	}
+	deployment, err := dm.ownedDeployment(req, existingDeployment)
+	if err != nil {
+		return err
+	}
+
+	if state.Replicas != nil && !autoscaled {
+		deployment.Spec.WithReplicas(int32(*state.Replicas))
+	}
+	if state.Resources != nil && state.Container != "" {
+		resources, err := snapshotResources(state.Resources)
+		if err != nil {
+			return err
+		}
+		setContainerResources(deployment, state.Container, resources)
+	}
+	if state.Image != nil && state.Container != "" {
+		setContainerImage(deployment, state.Container, *state.Image)
+	}
+	if state.Strategy != nil {
+		if err := dm.applyStrategy(ctx, deployment, existingDeployment, state.Strategy); err != nil {
+			return err
+		}
+	}
+	if state.DocHTML != nil {
+		setPodTemplateAnnotation(deployment, dto.AnnotationKeyContentHash, contentHash(*state.DocHTML))
+	}
+
+	if _, err := dm.clientset.AppsV1().Deployments(req.Namespace).Apply(ctx, deployment, dm.applyOptions(req.ForceOwnership)); err != nil {
+		return applyError("Deployment", req.Identifier, err)
+	}
+	return nil
+}
+
+// snapshotResources builds the resources apply configuration of a snapshot; nil when the container had none.
+func snapshotResources(snapshot *dto.SnapshotResources) (*corev1ac.ResourceRequirementsApplyConfiguration, error) {
+	if len(snapshot.Requests) == 0 && len(snapshot.Limits) == 0 {
+		return nil, nil
+	}
+
+	requests, err := toResourceList(snapshot.Requests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot requests: %w", err)
+	}
+	limits, err := toResourceList(snapshot.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot limits: %w", err)
+	}
+
+	resources := corev1ac.ResourceRequirements()
+	if len(requests) > 0 {
+		resources.WithRequests(requests)
+	}
+	if len(limits) > 0 {
+		resources.WithLimits(limits)
+	}
+	return resources, nil
+}
+
+// toResourceList parses resource name -> quantity strings.
+func toResourceList(values map[string]string) (corev1.ResourceList, error) {
+	list := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, nil
+}
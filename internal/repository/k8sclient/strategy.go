@@ -0,0 +1,94 @@
+package k8sclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	appsv1ac "k8s.io/client-go/applyconfigurations/apps/v1"
+)
+
+// dropRollingUpdatePatch switches a deployment to Recreate and removes its rollingUpdate parameters.
+var dropRollingUpdatePatch = []byte(`{"spec":{"strategy":{"type":"Recreate","rollingUpdate":null}}}`)
+
+// applyStrategy sets a RollingUpdate or Recreate strategy on the apply configuration; a Canary strategy
+// leaves the deployment's strategy alone. Switching to Recreate first drops the rollingUpdate parameters
+// with a strategic merge patch: Kubernetes rejects them together with Recreate, and server-side apply
+// keeps them when they were defaulted or set by another manager.
+func (dm *DeploymentManager) applyStrategy(ctx context.Context, deployment *appsv1ac.DeploymentApplyConfiguration, existingDeployment *appsv1.Deployment, strategy *dto.RolloutStrategy) error {
+	switch strategy.Type {
+	case dto.StrategyRecreate:
+		if existingDeployment.Spec.Strategy.RollingUpdate != nil {
+			_, err := dm.clientset.AppsV1().Deployments(existingDeployment.Namespace).Patch(ctx, existingDeployment.Name,
+				types.StrategicMergePatchType, dropRollingUpdatePatch, metav1.PatchOptions{FieldManager: dm.managerTag})
+			if err != nil {
+				return fmt.Errorf("drop rolling update parameters of deployment %s: %w", existingDeployment.Name, err)
+			}
+		}
+		deployment.Spec.WithStrategy(appsv1ac.DeploymentStrategy().WithType(appsv1.RecreateDeploymentStrategyType))
+	case dto.StrategyRollingUpdate:
+		rollingUpdate := appsv1ac.RollingUpdateDeployment()
+		if strategy.MaxSurge != "" {
+			rollingUpdate.WithMaxSurge(intstr.Parse(strategy.MaxSurge))
+		}
+		if strategy.MaxUnavailable != "" {
+			rollingUpdate.WithMaxUnavailable(intstr.Parse(strategy.MaxUnavailable))
+		}
+		deployment.Spec.WithStrategy(appsv1ac.DeploymentStrategy().
+			WithType(appsv1.RollingUpdateDeploymentStrategyType).
+			WithRollingUpdate(rollingUpdate))
+	}
+	return nil
+}
+
+// strategyObject returns the spec.strategy of a RollingUpdate or Recreate strategy for a rendered
+// (unstructured) deployment; nil for a Canary strategy.
+func strategyObject(strategy *dto.RolloutStrategy) map[string]interface{} {
+	switch strategy.Type {
+	case dto.StrategyRecreate:
+		return map[string]interface{}{"type": string(appsv1.RecreateDeploymentStrategyType)}
+	case dto.StrategyRollingUpdate:
+		rollingUpdate := map[string]interface{}{}
+		if strategy.MaxSurge != "" {
+			rollingUpdate["maxSurge"] = intOrStringValue(strategy.MaxSurge)
+		}
+		if strategy.MaxUnavailable != "" {
+			rollingUpdate["maxUnavailable"] = intOrStringValue(strategy.MaxUnavailable)
+		}
+		return map[string]interface{}{
+			"type":          string(appsv1.RollingUpdateDeploymentStrategyType),
+			"rollingUpdate": rollingUpdate,
+		}
+	}
+	return nil
+}
+
+// intOrStringValue returns a number of pods as int64 and a percentage as string, as unstructured objects hold them.
+func intOrStringValue(value string) interface{} {
+	parsed := intstr.Parse(value)
+	if parsed.Type == intstr.Int {
+		return int64(parsed.IntVal)
+	}
+	return parsed.StrVal
+}
+
+// deploymentStrategy returns the strategy of a deployment in the form of the request metadata.
+func deploymentStrategy(d *appsv1.Deployment) *dto.RolloutStrategy {
+	strategy := &dto.RolloutStrategy{Type: string(d.Spec.Strategy.Type)}
+	if strategy.Type == "" {
+		strategy.Type = dto.StrategyRollingUpdate
+	}
+	if rollingUpdate := d.Spec.Strategy.RollingUpdate; rollingUpdate != nil {
+		if rollingUpdate.MaxSurge != nil {
+			strategy.MaxSurge = rollingUpdate.MaxSurge.String()
+		}
+		if rollingUpdate.MaxUnavailable != nil {
+			strategy.MaxUnavailable = rollingUpdate.MaxUnavailable.String()
+		}
+	}
+	return strategy
+}
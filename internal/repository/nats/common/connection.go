@@ -42,24 +42,138 @@ func NewNATS(cfg *dto.NatsConfig, log *zap.Logger) (*NATS, error) {
 	return natsInstance, nil
 }
 
-// EnsureStream creates a JetStream stream with the given name and subjects if it does not exist.
-// Idempotent: if the stream already exists (name in use), no error is returned.
-func (n *NATS) EnsureStream(streamName string, subjects []string) error {
-	if streamName == "" || len(subjects) == 0 {
+// EnsureStream makes the JetStream stream match the producer config: it is created with the
+// configured subjects and stream settings if it does not exist. If it exists, missing subjects
+// are added and settings that drifted from the config (duplicates window, retention, max age,
+// replicas, storage) are updated.
+func (n *NATS) EnsureStream(cfg *dto.ProducerConfig) error {
+	subjects := cfg.Subjects()
+	if cfg.StreamName == "" || len(subjects) == 0 {
 		return fmt.Errorf("stream name and at least one subject are required")
 	}
-	_, err := n.JS.AddStream(&nats.StreamConfig{
-		Name:     streamName,
-		Subjects: subjects,
-	})
+	desired, err := streamConfig(cfg.StreamName, subjects, &cfg.Stream)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.JS.AddStream(desired)
 	if err != nil {
 		if errors.Is(err, nats.ErrStreamNameAlreadyInUse) || strings.Contains(err.Error(), "stream name already in use") {
-			n.logger.Debug("JetStream stream already exists", zap.String("stream", streamName))
-			return nil
+			n.logger.Debug("JetStream stream already exists", zap.String("stream", cfg.StreamName))
+			return n.reconcileStream(desired)
+		}
+		return fmt.Errorf("failed to ensure JetStream stream %s: %w", cfg.StreamName, err)
+	}
+	n.logger.Info("JetStream stream created",
+		zap.String("stream", cfg.StreamName),
+		zap.Strings("subjects", subjects),
+		zap.Duration("duplicates", desired.Duplicates),
+		zap.String("retention", desired.Retention.String()),
+		zap.String("storage", desired.Storage.String()),
+	)
+	return nil
+}
+
+// streamConfig builds the desired stream config, applying the server defaults for unset values
+// so it can be compared with the config of an existing stream.
+func streamConfig(name string, subjects []string, cfg *dto.StreamConfig) (*nats.StreamConfig, error) {
+	sc := &nats.StreamConfig{
+		Name:       name,
+		Subjects:   subjects,
+		Duplicates: cfg.Duplicates,
+		MaxAge:     cfg.MaxAge,
+		Replicas:   cfg.Replicas,
+	}
+	if sc.Duplicates <= 0 {
+		sc.Duplicates = dto.DefaultStreamDuplicates
+	}
+	if sc.Replicas <= 0 {
+		sc.Replicas = 1
+	}
+
+	switch strings.ToLower(cfg.Retention) {
+	case "", "limits":
+		sc.Retention = nats.LimitsPolicy
+	case "interest":
+		sc.Retention = nats.InterestPolicy
+	case "workqueue":
+		sc.Retention = nats.WorkQueuePolicy
+	default:
+		return nil, fmt.Errorf("invalid stream retention %q: must be limits, interest or workqueue", cfg.Retention)
+	}
+
+	switch strings.ToLower(cfg.Storage) {
+	case "", "file":
+		sc.Storage = nats.FileStorage
+	case "memory":
+		sc.Storage = nats.MemoryStorage
+	default:
+		return nil, fmt.Errorf("invalid stream storage %q: must be file or memory", cfg.Storage)
+	}
+	return sc, nil
+}
+
+// reconcileStream updates an existing stream when its subjects or settings drifted from desired.
+// Subjects are only ever added, so channels bound by other deployments are kept. Storage and retention
+// cannot be changed on an existing stream, so their drift is only logged with how to recreate it.
+func (n *NATS) reconcileStream(desired *nats.StreamConfig) error {
+	info, err := n.JS.StreamInfo(desired.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get JetStream stream info %s: %w", desired.Name, err)
+	}
+
+	cfg := info.Config
+	var drift []string
+
+	existing := make(map[string]struct{}, len(cfg.Subjects))
+	for _, s := range cfg.Subjects {
+		existing[s] = struct{}{}
+	}
+	var missing []string
+	for _, s := range desired.Subjects {
+		if _, ok := existing[s]; !ok {
+			missing = append(missing, s)
 		}
-		return fmt.Errorf("failed to ensure JetStream stream %s: %w", streamName, err)
 	}
-	n.logger.Info("JetStream stream created", zap.String("stream", streamName), zap.Strings("subjects", subjects))
+	if len(missing) > 0 {
+		cfg.Subjects = append(cfg.Subjects, missing...)
+		drift = append(drift, "subjects")
+	}
+	if cfg.Duplicates != desired.Duplicates {
+		cfg.Duplicates = desired.Duplicates
+		drift = append(drift, "duplicates")
+	}
+	if cfg.MaxAge != desired.MaxAge {
+		cfg.MaxAge = desired.MaxAge
+		drift = append(drift, "max_age")
+	}
+	if cfg.Replicas != desired.Replicas {
+		cfg.Replicas = desired.Replicas
+		drift = append(drift, "replicas")
+	}
+	if cfg.Retention != desired.Retention || cfg.Storage != desired.Storage {
+		n.logger.Warn("JetStream stream retention or storage differs from config and cannot be updated in place; "+
+			"keeping the existing settings. To apply them, back up pending messages, delete the stream "+
+			"(nats stream rm <stream>) and restart so it is recreated",
+			zap.String("stream", desired.Name),
+			zap.String("retention", cfg.Retention.String()),
+			zap.String("configured_retention", desired.Retention.String()),
+			zap.String("storage", cfg.Storage.String()),
+			zap.String("configured_storage", desired.Storage.String()),
+		)
+	}
+	if len(drift) == 0 {
+		return nil
+	}
+
+	if _, err := n.JS.UpdateStream(&cfg); err != nil {
+		return fmt.Errorf("failed to update JetStream stream %s (%s): %w", desired.Name, strings.Join(drift, ", "), err)
+	}
+	n.logger.Info("JetStream stream updated",
+		zap.String("stream", desired.Name),
+		zap.Strings("changed", drift),
+		zap.Strings("subjects_added", missing),
+	)
 	return nil
 }
 
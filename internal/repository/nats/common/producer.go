@@ -11,6 +11,7 @@ import (
 // Producer handles publishing messages to NATS
 type Producer struct {
 	js     nats.JetStreamContext
+	conn   *nats.Conn
 	logger *zap.Logger
 }
 
@@ -18,6 +19,7 @@ type Producer struct {
 func NewProducer(nats *NATS) *Producer {
 	return &Producer{
 		js:     nats.JS,
+		conn:   nats.Conn,
 		logger: nats.logger,
 	}
 }
@@ -81,3 +83,51 @@ func (p *Producer) PublishWithHeader(subject string, data interface{}, header na
 
 	return nil
 }
+
+// PublishRaw publishes an already-encoded payload to a NATS subject with metadata headers.
+// Used when republishing messages verbatim (e.g. replaying dead-lettered messages).
+func (p *Producer) PublishRaw(subject string, payload []byte, header nats.Header) error {
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    payload,
+		Header:  header,
+	}
+
+	ack, err := p.js.PublishMsg(msg)
+	if err != nil {
+		return fmt.Errorf("failed to publish message to subject %s: %w", subject, err)
+	}
+
+	p.logger.Info("Raw message published successfully",
+		zap.String("subject", subject),
+		zap.Int("payload_size", len(payload)),
+		zap.Any("nats_ack", map[string]interface{}{
+			"stream":    ack.Stream,
+			"sequence":  ack.Sequence,
+			"domain":    ack.Domain,
+			"duplicate": ack.Duplicate,
+		}),
+	)
+
+	return nil
+}
+
+// PublishCore publishes a message to a core NATS subject, outside JetStream. The message is not stored:
+// only the subscribers connected at that moment receive it.
+func (p *Producer) PublishCore(subject string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	if err := p.conn.Publish(subject, payload); err != nil {
+		return fmt.Errorf("failed to publish message to subject %s: %w", subject, err)
+	}
+
+	p.logger.Debug("Core message published successfully",
+		zap.String("subject", subject),
+		zap.Int("payload_size", len(payload)),
+	)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package nats
+
+import (
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
+	"github.com/nats-io/nats.go"
+)
+
+// DeadLetterProducer republishes dead-lettered messages onto their original channel
+type DeadLetterProducer struct {
+	producer *common.Producer
+}
+
+// NewDeadLetterProducer creates a new dead-letter producer
+func NewDeadLetterProducer(producer *common.Producer) *DeadLetterProducer {
+	return &DeadLetterProducer{
+		producer: producer,
+	}
+}
+
+// Replay publishes the original payload and headers to subject. Dead-letter headers
+// (original subject, attempts, last error) and the message ID are stripped so the message looks
+// like a fresh delivery and is not dropped by the stream's duplicate window.
+func (p *DeadLetterProducer) Replay(subject string, payload []byte, headers map[string][]string) error {
+	header := nats.Header{}
+	for k, v := range headers {
+		header[k] = append([]string(nil), v...)
+	}
+	header.Del(consumer.HeaderDeadLetterOriginalSubject)
+	header.Del(consumer.HeaderDeadLetterAttempts)
+	header.Del(consumer.HeaderDeadLetterError)
+	header.Del(nats.MsgIdHdr)
+	return p.producer.PublishRaw(subject, payload, header)
+}
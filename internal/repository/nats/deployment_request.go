@@ -1,12 +1,15 @@
 package nats
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
-	"github.com/nats-io/nats.go"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 )
 
-// DeploymentRequestProducer produces deployment request messages to the producer channel
+// DeploymentRequestProducer builds deployment request messages for the producer channel
 type DeploymentRequestProducer struct {
 	producer *common.Producer
 	channel  string
@@ -20,11 +23,21 @@ func NewDeploymentRequestProducer(producer *common.Producer, cfg *dto.ProducerCo
 	}
 }
 
-// Publish sends a message with headers request_id and user_id and body DeploymentRequestMessage to the deployment request channel
-func (p *DeploymentRequestProducer) Publish(requestID, userID string) error {
-	header := nats.Header{}
-	header.Set(dto.HeaderKeyRequestID, requestID)
-	header.Set(dto.HeaderKeyUserID, userID)
-	body := &dto.DeploymentRequestMessage{RequestID: requestID}
-	return p.producer.PublishWithHeader(p.channel, body, header)
+// Message builds an outbox message with headers request_id and user_id and body DeploymentRequestMessage
+// for the deployment request channel. The request ID is used as the message ID for deduplication.
+func (p *DeploymentRequestProducer) Message(requestID, userID string) (*models.OutboxMessage, error) {
+	payload, err := json.Marshal(&dto.DeploymentRequestMessage{RequestID: requestID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return &models.OutboxMessage{
+		MsgID:   requestID,
+		Subject: p.channel,
+		Payload: payload,
+		Headers: models.JSONB{
+			dto.HeaderKeyRequestID: []string{requestID},
+			dto.HeaderKeyUserID:    []string{userID},
+		},
+		Status: models.OutboxStatusPending,
+	}, nil
 }
@@ -0,0 +1,77 @@
+package nats
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// DeploymentStatusProducer publishes deployment request status events to the deployment status channel
+type DeploymentStatusProducer struct {
+	producer *common.Producer
+	channel  string
+}
+
+// NewDeploymentStatusProducer creates a new deployment status producer
+func NewDeploymentStatusProducer(producer *common.Producer, cfg *dto.ProducerConfig) *DeploymentStatusProducer {
+	return &DeploymentStatusProducer{
+		producer: producer,
+		channel:  cfg.DeploymentStatusChannel,
+	}
+}
+
+// Publish sends the event to <channel>.<request_id>. It does nothing when the channel is not configured.
+func (p *DeploymentStatusProducer) Publish(event *dto.DeploymentStatusEvent) error {
+	if p.channel == "" {
+		return nil
+	}
+	return p.producer.PublishCore(p.channel+"."+event.RequestID, event)
+}
+
+// DeploymentStatusSubscriber subscribes to the status events of single deployment requests
+type DeploymentStatusSubscriber struct {
+	conn    *nats.Conn
+	channel string
+	logger  *zap.Logger
+}
+
+// NewDeploymentStatusSubscriber creates a new deployment status subscriber
+func NewDeploymentStatusSubscriber(n *common.NATS, cfg *dto.ProducerConfig, logger *zap.Logger) *DeploymentStatusSubscriber {
+	return &DeploymentStatusSubscriber{
+		conn:    n.Conn,
+		channel: cfg.DeploymentStatusChannel,
+		logger:  logger,
+	}
+}
+
+// Subscribe calls handler with every status event of the request until the returned unsubscribe func is
+// called. Events that cannot be decoded are logged and skipped. When the channel is not configured no event
+// is ever delivered.
+func (s *DeploymentStatusSubscriber) Subscribe(requestID string, handler func(*dto.DeploymentStatusEvent)) (func(), error) {
+	if s.channel == "" {
+		return func() {}, nil
+	}
+
+	subject := s.channel + "." + requestID
+	sub, err := s.conn.Subscribe(subject, func(msg *nats.Msg) {
+		var event dto.DeploymentStatusEvent
+		if err := json.Unmarshal(msg.Data, &event); err != nil {
+			s.logger.Warn("Failed to decode deployment status event", zap.String("subject", msg.Subject), zap.Error(err))
+			return
+		}
+		handler(&event)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to subject %s: %w", subject, err)
+	}
+
+	return func() {
+		if err := sub.Unsubscribe(); err != nil && err != nats.ErrConnectionClosed {
+			s.logger.Warn("Failed to unsubscribe from deployment status events", zap.String("subject", subject), zap.Error(err))
+		}
+	}, nil
+}
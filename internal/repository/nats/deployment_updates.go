@@ -3,6 +3,7 @@ package nats
 import (
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/nats-io/nats.go"
 )
 
 // DeploymentUpdateProducer produces deployment update messages to the producer channel
@@ -19,8 +20,12 @@ func NewDeploymentUpdateProducer(producer *common.Producer, cfg *dto.ProducerCon
 	}
 }
 
-// Publish sends a DeploymentUpdateMessage (identifier, eventType) to the deployment update channel
-func (p *DeploymentUpdateProducer) Publish(identifier, eventType string) error {
+// Publish sends a DeploymentUpdateMessage (identifier, eventType) to the deployment update channel.
+// The event identity (identifier, resource version and event type) is used as Nats-Msg-Id, so the
+// same event observed twice (e.g. by two watcher replicas) is only enqueued once.
+func (p *DeploymentUpdateProducer) Publish(identifier, resourceVersion, eventType string) error {
+	header := nats.Header{}
+	header.Set(nats.MsgIdHdr, identifier+"@"+resourceVersion+":"+eventType)
 	body := &dto.DeploymentUpdateMessage{Identifier: identifier, EventType: eventType}
-	return p.producer.Publish(p.channel, body)
+	return p.producer.PublishWithHeader(p.channel, body, header)
 }
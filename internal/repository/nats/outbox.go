@@ -0,0 +1,30 @@
+package nats
+
+import (
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
+	"github.com/nats-io/nats.go"
+)
+
+// OutboxProducer publishes relayed outbox messages
+type OutboxProducer struct {
+	producer *common.Producer
+}
+
+// NewOutboxProducer creates a new outbox producer
+func NewOutboxProducer(producer *common.Producer) *OutboxProducer {
+	return &OutboxProducer{
+		producer: producer,
+	}
+}
+
+// Publish publishes the stored payload and headers to subject with msgID as Nats-Msg-Id,
+// so a message relayed twice (e.g. the relay crashed before marking it published) is
+// dropped by the stream's duplicate window.
+func (p *OutboxProducer) Publish(msgID, subject string, payload []byte, headers map[string][]string) error {
+	header := nats.Header{}
+	for k, v := range headers {
+		header[k] = append([]string(nil), v...)
+	}
+	header.Set(nats.MsgIdHdr, msgID)
+	return p.producer.PublishRaw(subject, payload, header)
+}
@@ -0,0 +1,54 @@
+package nats
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/nats/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/nats-io/nats.go"
+)
+
+// WebhookEventProducer publishes webhook events to the deployment event channel
+type WebhookEventProducer struct {
+	producer *common.Producer
+	channel  string
+}
+
+// NewWebhookEventProducer creates a new webhook event producer
+func NewWebhookEventProducer(producer *common.Producer, cfg *dto.ProducerConfig) *WebhookEventProducer {
+	return &WebhookEventProducer{
+		producer: producer,
+		channel:  cfg.DeploymentEventChannel,
+	}
+}
+
+// Publish sends the event with its ID as Nats-Msg-Id, so an event published again (e.g. its message was
+// retried) is only enqueued once. It does nothing when the channel is not configured.
+func (p *WebhookEventProducer) Publish(event *dto.WebhookEvent) error {
+	if p.channel == "" {
+		return nil
+	}
+	header := nats.Header{}
+	header.Set(nats.MsgIdHdr, event.ID)
+	return p.producer.PublishWithHeader(p.channel, event, header)
+}
+
+// Message builds an outbox message with the JSON event for the deployment event channel, with the event ID
+// as the message ID. It returns nil when the channel is not configured.
+func (p *WebhookEventProducer) Message(event *dto.WebhookEvent) (*models.OutboxMessage, error) {
+	if p.channel == "" {
+		return nil, nil
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event: %w", err)
+	}
+	return &models.OutboxMessage{
+		MsgID:   event.ID,
+		Subject: p.channel,
+		Payload: payload,
+		Status:  models.OutboxStatusPending,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+)
+
+// APIKeyRepository implements the API key repository interface
+type APIKeyRepository struct {
+	db *common.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *common.DB) portsdb.APIKey {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+// Create creates a new API key in the database
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	q := query.Use(r.db.DB)
+	if err := q.APIKey.WithContext(ctx).Create(key); err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+	return nil
+}
+
+// GetByKeyID retrieves an API key by its public key ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *APIKeyRepository) GetByKeyID(ctx context.Context, keyID string) (*models.APIKey, bool, error) {
+	q := query.Use(r.db.DB).APIKey
+	keys, err := q.WithContext(ctx).
+		Where(q.KeyID.Eq(keyID)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query api key: %w", err)
+	}
+	if len(keys) > 0 {
+		return keys[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// GetByID retrieves an API key by ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *APIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey, bool, error) {
+	q := query.Use(r.db.DB).APIKey
+	keys, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query api key: %w", err)
+	}
+	if len(keys) > 0 {
+		return keys[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListByUserID retrieves the API keys of a user, newest first
+func (r *APIKeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error) {
+	q := query.Use(r.db.DB).APIKey
+	keys, err := q.WithContext(ctx).
+		Where(q.UserID.Eq(userID)).
+		Order(q.CreatedOn.Desc()).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Revoke sets the key's revoked timestamp unless it is already revoked
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB).APIKey
+	now := time.Now()
+	if _, err := q.WithContext(ctx).Where(q.ID.Eq(id), q.RevokedOn.IsNull()).UpdateSimple(
+		q.RevokedOn.Value(now),
+		q.UpdatedOn.Value(now),
+	); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+	return nil
+}
+
+// TouchLastUsed sets the key's last used timestamp
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB).APIKey
+	if _, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(q.LastUsedOn.Value(time.Now())); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+	return nil
+}
@@ -57,12 +57,30 @@ func (db *DB) Migrate() error {
 		&models.User{},
 		&models.DeploymentRequest{},
 		&models.Deployment{},
+		&models.DeadLetter{},
+		&models.OutboxMessage{},
+		&models.DeploymentRevision{},
+		&models.APIKey{},
+		&models.Team{},
+		&models.TeamMembership{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.WebhookDeliveryAttempt{},
+		&models.DeploymentSchedule{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
+	// Dead letters used to be unique on the stream sequence alone, which drops new dead letters once a
+	// recreated stream reuses sequences; idx_dead_letter_message replaces it
+	if db.Migrator().HasIndex(&models.DeadLetter{}, "idx_dead_letters_sequence") {
+		if err := db.Migrator().DropIndex(&models.DeadLetter{}, "idx_dead_letters_sequence"); err != nil {
+			return fmt.Errorf("migration failed: drop dead letter sequence index: %w", err)
+		}
+	}
+
 	db.logger.Info("Database migrations completed successfully")
 	return nil
 }
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// DeadLetterRepository implements the dead-letter repository interface
+type DeadLetterRepository struct {
+	db *common.DB
+}
+
+// NewDeadLetterRepository creates a new dead-letter repository
+func NewDeadLetterRepository(db *common.DB) portsdb.DeadLetter {
+	return &DeadLetterRepository{
+		db: db,
+	}
+}
+
+// Create stores a dead-lettered message. Idempotent on the dead-letter message's stream, sequence and
+// stored time, so a redelivered dead-letter message is not recorded twice while one with a sequence reused
+// by a recreated stream is; false is returned when nothing was stored.
+func (r *DeadLetterRepository) Create(ctx context.Context, deadLetter *models.DeadLetter) (bool, error) {
+	q := query.Use(r.db.DB).DeadLetter
+	result := q.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "stream"}, {Name: "sequence"}, {Name: "stored_on"}},
+			DoNothing: true,
+		}).
+		Create(deadLetter)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create dead-lettered message: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetByID retrieves a dead-lettered message by ID.
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetter, bool, error) {
+	q := query.Use(r.db.DB)
+	deadLetters, err := q.DeadLetter.WithContext(ctx).
+		Where(q.DeadLetter.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query dead-lettered message: %w", err)
+	}
+	if len(deadLetters) > 0 {
+		return deadLetters[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListByUserID retrieves all dead-lettered messages for a user, ordered by creation (newest first)
+func (r *DeadLetterRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DeadLetter, error) {
+	q := query.Use(r.db.DB)
+	deadLetters, err := q.DeadLetter.WithContext(ctx).
+		Where(q.DeadLetter.UserID.Eq(userID)).
+		Order(q.DeadLetter.CreatedOn.Desc()).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead-lettered messages: %w", err)
+	}
+	return deadLetters, nil
+}
+
+// List retrieves every dead-lettered message regardless of owner, ordered by creation (newest first)
+func (r *DeadLetterRepository) List(ctx context.Context) ([]*models.DeadLetter, error) {
+	q := query.Use(r.db.DB)
+	deadLetters, err := q.DeadLetter.WithContext(ctx).
+		Order(q.DeadLetter.CreatedOn.Desc()).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead-lettered messages: %w", err)
+	}
+	return deadLetters, nil
+}
+
+// MarkReplayed sets the status to REPLAYED and increments the replay count
+func (r *DeadLetterRepository) MarkReplayed(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB).DeadLetter
+	_, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(
+		q.Status.Value(string(models.DeadLetterStatusReplayed)),
+		q.ReplayCount.Add(1),
+		q.UpdatedOn.Value(time.Now()),
+	)
+	return err
+}
@@ -12,6 +12,7 @@ import (
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"gorm.io/gen/field"
 	"gorm.io/gorm"
 )
 
@@ -60,14 +61,68 @@ func (r *DeploymentRepository) GetByIdentifier(ctx context.Context, identifier s
 	return existing, true, nil
 }
 
-// ListByUserID retrieves all deployments for a given user ID
-func (r *DeploymentRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Deployment, error) {
-	q := query.Use(r.db.DB)
-	deployments, err := q.Deployment.WithContext(ctx).
-		Where(q.Deployment.UserID.Eq(userID)).
-		Find()
+// ListVisible retrieves a page of the user's personal deployments and those of the given teams that match
+// the filter, in the filter's sort order
+func (r *DeploymentRepository) ListVisible(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID, filter *dto.DeploymentFilter) ([]*models.Deployment, error) {
+	d := query.Use(r.db.DB).Deployment
+	visible := d.Where(d.TeamID.IsNull(), d.UserID.Eq(userID))
+	if len(teamIDs) > 0 {
+		visible = visible.Or(d.TeamID.In(uuidValues(teamIDs)...))
+	}
+	do := d.WithContext(ctx).Where(visible)
+
+	if filter.Status != "" {
+		do = do.Where(d.Status.Eq(filter.Status))
+	}
+	if filter.Namespace != "" {
+		do = do.Where(d.Namespace.Eq(filter.Namespace))
+	}
+	if filter.NamePrefix != "" {
+		do = do.Where(d.Name.Like(likePrefix(filter.NamePrefix)))
+	}
+	if filter.Image != "" {
+		do = do.Where(d.Image.Eq(filter.Image))
+	}
+	if filter.CreatedAfter != nil {
+		do = do.Where(d.CreatedOn.Gt(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		do = do.Where(d.CreatedOn.Lt(*filter.CreatedBefore))
+	}
+
+	columns := pageColumns{id: d.ID, createdOn: d.CreatedOn, name: d.Name}
+	if filter.After != nil {
+		after, err := columns.after(filter.Sort, filter.After)
+		if err != nil {
+			return nil, err
+		}
+		do = do.Where(after)
+	}
+	if filter.Limit > 0 {
+		do = do.Limit(filter.Limit)
+	}
+
+	deployments, err := do.Order(columns.order(filter.Sort)...).Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query visible deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// ListActiveByOwner retrieves the deployments that are not DELETED of a team, or the user's personal
+// deployments when teamID is nil
+func (r *DeploymentRepository) ListActiveByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.Deployment, error) {
+	d := query.Use(r.db.DB).Deployment
+	do := d.WithContext(ctx).
+		Where(d.Status.Neq(string(models.DeploymentStatusDeleted)))
+	if teamID != nil {
+		do = do.Where(d.TeamID.Eq(*teamID))
+	} else {
+		do = do.Where(d.TeamID.IsNull(), d.UserID.Eq(userID))
+	}
+	deployments, err := do.Find()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query deployments by user ID: %w", err)
+		return nil, fmt.Errorf("failed to query owner deployments: %w", err)
 	}
 	return deployments, nil
 }
@@ -121,5 +176,29 @@ func (r *DeploymentRepository) Upsert(ctx context.Context, deployment *models.De
 		return fmt.Errorf("failed to update deployment: %w", err)
 	}
 
+	// Updates skips zero values; write the ones a deployment can return to (scaled to zero, limits, team
+	// or autoscaler removed) explicitly
+	assignments := []field.AssignExpr{
+		q.Deployment.Replicas.Value(deployment.Replicas),
+		q.Deployment.PodCPUMillis.Value(deployment.PodCPUMillis),
+		q.Deployment.PodMemoryBytes.Value(deployment.PodMemoryBytes),
+	}
+	if deployment.TeamID == nil {
+		assignments = append(assignments, q.Deployment.TeamID.Null())
+	}
+	if deployment.AutoscalingMaxReplicas == nil {
+		assignments = append(assignments,
+			q.Deployment.AutoscalingMinReplicas.Null(),
+			q.Deployment.AutoscalingMaxReplicas.Null(),
+			q.Deployment.AutoscalingDesiredReplicas.Null(),
+			q.Deployment.AutoscalingCurrentReplicas.Null(),
+		)
+	}
+	if _, err := q.Deployment.WithContext(ctx).
+		Where(q.Deployment.ID.Eq(existing.ID)).
+		UpdateSimple(assignments...); err != nil {
+		return fmt.Errorf("failed to update deployment sizing: %w", err)
+	}
+
 	return nil
 }
@@ -7,9 +7,12 @@ import (
 
 	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	"github.com/google/uuid"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
 )
 
 // DeploymentRequestRepository implements the deployment request repository interface
@@ -30,6 +33,66 @@ func (r *DeploymentRequestRepository) Create(ctx context.Context, deployment *mo
 	return q.DeploymentRequest.WithContext(ctx).Create(deployment)
 }
 
+// CreateWithOutbox creates a new deployment request and its outbox message in one transaction,
+// so the request is never stored without the message that hands it to the workers
+func (r *DeploymentRequestRepository) CreateWithOutbox(ctx context.Context, deployment *models.DeploymentRequest, msg *models.OutboxMessage) error {
+	q := query.Use(r.db.DB)
+	return q.Transaction(func(tx *query.Query) error {
+		if err := tx.DeploymentRequest.WithContext(ctx).Create(deployment); err != nil {
+			return fmt.Errorf("failed to create deployment request: %w", err)
+		}
+		if err := tx.OutboxMessage.WithContext(ctx).Create(msg); err != nil {
+			return fmt.Errorf("failed to create outbox message: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateWithOutboxAdmitted creates a deployment request and its outbox message in one transaction that first
+// locks the owning team's row, or the user's for personal deployments, and runs admit while holding the lock.
+// Requests of the same owner are serialized up to the commit, so admit (e.g. a quota check) sees the requests
+// stored before it. An admit error is returned as is and nothing is stored.
+func (r *DeploymentRequestRepository) CreateWithOutboxAdmitted(
+	ctx context.Context,
+	owner dto.Ownership,
+	deployment *models.DeploymentRequest,
+	msg *models.OutboxMessage,
+	admit func(ctx context.Context) error,
+) error {
+	q := query.Use(r.db.DB)
+	return q.Transaction(func(tx *query.Query) error {
+		if err := lockOwner(ctx, tx, owner); err != nil {
+			return err
+		}
+		if err := admit(ctx); err != nil {
+			return err
+		}
+		if err := tx.DeploymentRequest.WithContext(ctx).Create(deployment); err != nil {
+			return fmt.Errorf("failed to create deployment request: %w", err)
+		}
+		if err := tx.OutboxMessage.WithContext(ctx).Create(msg); err != nil {
+			return fmt.Errorf("failed to create outbox message: %w", err)
+		}
+		return nil
+	})
+}
+
+// lockOwner locks the row of the owning team, or of the user for personal deployments, until tx ends
+func lockOwner(ctx context.Context, tx *query.Query, owner dto.Ownership) error {
+	if owner.TeamID != nil {
+		t := tx.Team
+		if _, err := t.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(t.ID.Eq(*owner.TeamID)).Find(); err != nil {
+			return fmt.Errorf("failed to lock team: %w", err)
+		}
+		return nil
+	}
+	u := tx.User
+	if _, err := u.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where(u.ID.Eq(owner.UserID)).Find(); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
 // GetByIdentifier retrieves a deployment request by identifier
 // Returns error if deployment exists and is in CREATED or SUCCESS status
 func (r *DeploymentRequestRepository) GetByIdentifier(ctx context.Context, identifier string) (*models.DeploymentRequest, error) {
@@ -63,13 +126,51 @@ func (r *DeploymentRequestRepository) GetByRequestID(ctx context.Context, reques
 	return nil, false, nil
 }
 
-// ListByUserID retrieves all deployment requests for a user, ordered by creation (newest first)
-func (r *DeploymentRequestRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DeploymentRequest, error) {
-	q := query.Use(r.db.DB)
-	deployments, err := q.DeploymentRequest.WithContext(ctx).
-		Where(q.DeploymentRequest.UserID.Eq(userID)).
-		Order(q.DeploymentRequest.CreatedOn.Desc()).
-		Find()
+// ListVisible retrieves a page of the user's personal deployment requests and those of the given teams
+// that match the filter, in the filter's sort order
+func (r *DeploymentRequestRepository) ListVisible(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID, filter *dto.DeploymentRequestFilter) ([]*models.DeploymentRequest, error) {
+	d := query.Use(r.db.DB).DeploymentRequest
+	visible := d.Where(d.TeamID.IsNull(), d.UserID.Eq(userID))
+	if len(teamIDs) > 0 {
+		visible = visible.Or(d.TeamID.In(uuidValues(teamIDs)...))
+	}
+	do := d.WithContext(ctx).Where(visible)
+
+	if filter.Status != "" {
+		do = do.Where(d.Status.Eq(filter.Status))
+	}
+	if filter.Namespace != "" {
+		do = do.Where(d.Namespace.Eq(filter.Namespace))
+	}
+	if filter.NamePrefix != "" {
+		do = do.Where(d.Name.Like(likePrefix(filter.NamePrefix)))
+	}
+	if filter.RequestType != "" {
+		do = do.Where(d.RequestType.Eq(filter.RequestType))
+	}
+	if filter.Image != "" {
+		do = do.Where(d.Image.Eq(filter.Image))
+	}
+	if filter.CreatedAfter != nil {
+		do = do.Where(d.CreatedOn.Gt(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		do = do.Where(d.CreatedOn.Lt(*filter.CreatedBefore))
+	}
+
+	columns := pageColumns{id: d.ID, createdOn: d.CreatedOn, name: d.Name}
+	if filter.After != nil {
+		after, err := columns.after(filter.Sort, filter.After)
+		if err != nil {
+			return nil, err
+		}
+		do = do.Where(after)
+	}
+	if filter.Limit > 0 {
+		do = do.Limit(filter.Limit)
+	}
+
+	deployments, err := do.Order(columns.order(filter.Sort)...).Find()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list deployment requests: %w", err)
 	}
@@ -77,7 +178,8 @@ func (r *DeploymentRequestRepository) ListByUserID(ctx context.Context, userID u
 }
 
 // UpdateStatus updates the status of a deployment request by ID.
-// failureReason is optional; when status is FAILURE it may be set. Uses Save to trigger BeforeUpdate hook for UpdatedOn.
+// failureReason is optional; when status is FAILURE it may be set. A nil failureReason clears any previous one
+// (e.g. when a FAILURE request is reset to CREATED for replay).
 func (r *DeploymentRequestRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.DeploymentRequestStatus, failureReason *string) error {
 	now := time.Now()
 	updateFields := models.DeploymentRequest{
@@ -87,6 +189,173 @@ func (r *DeploymentRequestRepository) UpdateStatus(ctx context.Context, id uuid.
 	}
 
 	q := query.Use(r.db.DB).DeploymentRequest
-	_, err := q.WithContext(ctx).Where(q.ID.Eq(id)).Updates(updateFields)
+	_, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Select(q.Status, q.FailureReason, q.UpdatedOn).
+		Updates(updateFields)
 	return err
 }
+
+// CompleteStatus moves a CREATED deployment request to status. The update is conditional on the request
+// still being CREATED, so a duplicate delivery (e.g. republished by the reaper) that finishes after another
+// worker already completed the request cannot overwrite its outcome; false is returned in that case. The
+// outbox message announcing the outcome, if any, is stored in the same transaction only when the update applies.
+func (r *DeploymentRequestRepository) CompleteStatus(ctx context.Context, id uuid.UUID, status models.DeploymentRequestStatus, failureReason *string, msg *models.OutboxMessage) (bool, error) {
+	now := time.Now()
+	updateFields := models.DeploymentRequest{
+		Status:        status,
+		FailureReason: failureReason,
+		Common:        models.Common{UpdatedOn: &now},
+	}
+
+	completed := false
+	q := query.Use(r.db.DB)
+	err := q.Transaction(func(tx *query.Query) error {
+		d := tx.DeploymentRequest
+		info, err := d.WithContext(ctx).
+			Where(d.ID.Eq(id)).
+			Where(d.Status.Eq(string(models.DeploymentRequestStatusCreated))).
+			Select(d.Status, d.FailureReason, d.UpdatedOn).
+			Updates(updateFields)
+		if err != nil {
+			return err
+		}
+		if info.RowsAffected == 0 {
+			return nil
+		}
+		if msg != nil {
+			if err := tx.OutboxMessage.WithContext(ctx).Create(msg); err != nil {
+				return fmt.Errorf("failed to create outbox message: %w", err)
+			}
+		}
+		completed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return completed, nil
+}
+
+// UpdatePhase updates the phase of a deployment request by ID
+func (r *DeploymentRequestRepository) UpdatePhase(ctx context.Context, id uuid.UUID, phase models.DeploymentRequestPhase) error {
+	q := query.Use(r.db.DB).DeploymentRequest
+	_, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		UpdateSimple(q.Phase.Value(string(phase)), q.UpdatedOn.Value(time.Now()))
+	return err
+}
+
+// ListStale retrieves up to limit CREATED deployment requests that have not been touched since olderThan
+// (last update, or creation if never updated), oldest first
+func (r *DeploymentRequestRepository) ListStale(ctx context.Context, olderThan time.Time, limit int) ([]*models.DeploymentRequest, error) {
+	q := query.Use(r.db.DB).DeploymentRequest
+	deployments, err := q.WithContext(ctx).
+		Where(q.Status.Eq(string(models.DeploymentRequestStatusCreated))).
+		Where(field.Or(
+			q.UpdatedOn.Lt(olderThan),
+			field.And(q.UpdatedOn.IsNull(), q.CreatedOn.Lt(olderThan)),
+		)).
+		Order(q.CreatedOn).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stale deployment requests: %w", err)
+	}
+	return deployments, nil
+}
+
+// Requeue increments the reap count of a CREATED deployment request and stores its outbox message
+// in one transaction. The update is conditional on the reap count read by the caller, so when several
+// workers reap the same request only one republishes it; false is returned for the others.
+func (r *DeploymentRequestRepository) Requeue(ctx context.Context, req *models.DeploymentRequest, msg *models.OutboxMessage) (bool, error) {
+	requeued := false
+	q := query.Use(r.db.DB)
+	err := q.Transaction(func(tx *query.Query) error {
+		d := tx.DeploymentRequest
+		info, err := d.WithContext(ctx).
+			Where(d.ID.Eq(req.ID)).
+			Where(d.Status.Eq(string(models.DeploymentRequestStatusCreated))).
+			Where(d.ReapCount.Eq(req.ReapCount)).
+			UpdateSimple(
+				d.ReapCount.Add(1),
+				d.UpdatedOn.Value(time.Now()),
+			)
+		if err != nil {
+			return fmt.Errorf("failed to update reap count: %w", err)
+		}
+		if info.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.OutboxMessage.WithContext(ctx).Create(msg); err != nil {
+			return fmt.Errorf("failed to create outbox message: %w", err)
+		}
+		requeued = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return requeued, nil
+}
+
+// ListSuccessfulByIdentifier retrieves the SUCCESS deployment requests of a deployment, oldest first
+func (r *DeploymentRequestRepository) ListSuccessfulByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentRequest, error) {
+	q := query.Use(r.db.DB).DeploymentRequest
+	requests, err := q.WithContext(ctx).
+		Where(q.Identifier.Eq(identifier)).
+		Where(q.Status.Eq(string(models.DeploymentRequestStatusSuccess))).
+		Order(q.CreatedOn).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list successful deployment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ListPendingResizesByOwner retrieves the CREATE, UPDATE and ROLLBACK requests not yet processed (status
+// CREATED) of a team, or the user's personal ones when teamID is nil, oldest first
+func (r *DeploymentRequestRepository) ListPendingResizesByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.DeploymentRequest, error) {
+	q := query.Use(r.db.DB).DeploymentRequest
+	do := q.WithContext(ctx).
+		Where(q.RequestType.In(
+			string(models.DeploymentRequestTypeCreate),
+			string(models.DeploymentRequestTypeUpdate),
+			string(models.DeploymentRequestTypeRollback),
+		)).
+		Where(q.Status.Eq(string(models.DeploymentRequestStatusCreated))).
+		Order(q.CreatedOn)
+	if teamID != nil {
+		do = do.Where(q.TeamID.Eq(*teamID))
+	} else {
+		do = do.Where(q.TeamID.IsNull(), q.UserID.Eq(userID))
+	}
+	requests, err := do.Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending resize requests: %w", err)
+	}
+	return requests, nil
+}
+
+// CountPendingCreatesInNamespace counts the CREATE requests not yet processed (status CREATED) that target a namespace
+func (r *DeploymentRequestRepository) CountPendingCreatesInNamespace(ctx context.Context, namespace string) (int64, error) {
+	q := query.Use(r.db.DB).DeploymentRequest
+	count, err := q.WithContext(ctx).
+		Where(q.RequestType.Eq(string(models.DeploymentRequestTypeCreate))).
+		Where(q.Status.Eq(string(models.DeploymentRequestStatusCreated))).
+		Where(q.Namespace.Eq(namespace)).
+		Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pending create requests: %w", err)
+	}
+	return count, nil
+}
+
+// SaveSnapshot stores the pre-update snapshot of a deployment request
+func (r *DeploymentRequestRepository) SaveSnapshot(ctx context.Context, id uuid.UUID, snapshot models.JSONB) error {
+	q := query.Use(r.db.DB).DeploymentRequest
+	if _, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(q.Snapshot.Value(snapshot)); err != nil {
+		return fmt.Errorf("failed to save deployment request snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"gorm.io/gorm/clause"
+)
+
+// DeploymentRevisionRepository implements the deployment revision repository interface
+type DeploymentRevisionRepository struct {
+	db *common.DB
+}
+
+// NewDeploymentRevisionRepository creates a new deployment revision repository
+func NewDeploymentRevisionRepository(db *common.DB) portsdb.DeploymentRevision {
+	return &DeploymentRevisionRepository{
+		db: db,
+	}
+}
+
+// Create inserts the revision unless the same revision of the deployment is already recorded
+// (the watcher reports every status change, not only spec changes)
+func (r *DeploymentRevisionRepository) Create(ctx context.Context, revision *models.DeploymentRevision) (bool, error) {
+	q := query.Use(r.db.DB).DeploymentRevision
+	result := q.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(revision)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create deployment revision: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ListByIdentifier retrieves the revisions of a deployment, newest first
+func (r *DeploymentRevisionRepository) ListByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentRevision, error) {
+	q := query.Use(r.db.DB).DeploymentRevision
+	revisions, err := q.WithContext(ctx).
+		Where(q.Identifier.Eq(identifier)).
+		Order(q.Revision.Desc()).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetByRevision retrieves one revision of a deployment
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *DeploymentRevisionRepository) GetByRevision(ctx context.Context, identifier string, revision int64) (*models.DeploymentRevision, bool, error) {
+	q := query.Use(r.db.DB).DeploymentRevision
+	revisions, err := q.WithContext(ctx).
+		Where(q.Identifier.Eq(identifier), q.Revision.Eq(revision)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query deployment revision: %w", err)
+	}
+	if len(revisions) > 0 {
+		return revisions[0], true, nil
+	}
+	return nil, false, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+)
+
+// DeploymentScheduleRepository implements the deployment schedule repository interface
+type DeploymentScheduleRepository struct {
+	db *common.DB
+}
+
+// NewDeploymentScheduleRepository creates a new deployment schedule repository
+func NewDeploymentScheduleRepository(db *common.DB) portsdb.DeploymentSchedule {
+	return &DeploymentScheduleRepository{
+		db: db,
+	}
+}
+
+// Create creates a new deployment schedule in the database
+func (r *DeploymentScheduleRepository) Create(ctx context.Context, schedule *models.DeploymentSchedule) error {
+	q := query.Use(r.db.DB)
+	if err := q.DeploymentSchedule.WithContext(ctx).Create(schedule); err != nil {
+		return fmt.Errorf("failed to create deployment schedule: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a deployment schedule by ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *DeploymentScheduleRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DeploymentSchedule, bool, error) {
+	q := query.Use(r.db.DB).DeploymentSchedule
+	schedules, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query deployment schedule: %w", err)
+	}
+	if len(schedules) > 0 {
+		return schedules[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListByIdentifier retrieves the schedules of a deployment, oldest first
+func (r *DeploymentScheduleRepository) ListByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentSchedule, error) {
+	q := query.Use(r.db.DB).DeploymentSchedule
+	schedules, err := q.WithContext(ctx).
+		Where(q.Identifier.Eq(identifier)).
+		Order(q.CreatedOn).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// Delete removes the deployment schedule; requests it emitted are kept
+func (r *DeploymentScheduleRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB).DeploymentSchedule
+	if _, err := q.WithContext(ctx).Where(q.ID.Eq(id)).Delete(); err != nil {
+		return fmt.Errorf("failed to delete deployment schedule: %w", err)
+	}
+	return nil
+}
+
+// DeleteByIdentifier removes every schedule of a deployment
+func (r *DeploymentScheduleRepository) DeleteByIdentifier(ctx context.Context, identifier string) error {
+	q := query.Use(r.db.DB).DeploymentSchedule
+	if _, err := q.WithContext(ctx).Where(q.Identifier.Eq(identifier)).Delete(); err != nil {
+		return fmt.Errorf("failed to delete deployment schedules: %w", err)
+	}
+	return nil
+}
+
+// ListDue retrieves up to limit schedules whose next run is at or before now, earliest first
+func (r *DeploymentScheduleRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*models.DeploymentSchedule, error) {
+	q := query.Use(r.db.DB).DeploymentSchedule
+	schedules, err := q.WithContext(ctx).
+		Where(q.NextRunAt.Lte(now)).
+		Order(q.NextRunAt).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due deployment schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// Fire moves the schedule from the next run read by the caller to nextRunAt, recording the run and its
+// request, and stores the request with its outbox message, if any, in one transaction. When another scheduler
+// already moved the schedule nothing is stored and false is returned.
+func (r *DeploymentScheduleRepository) Fire(ctx context.Context, schedule *models.DeploymentSchedule, nextRunAt time.Time, req *models.DeploymentRequest, msg *models.OutboxMessage) (bool, error) {
+	fired := false
+	q := query.Use(r.db.DB)
+	err := q.Transaction(func(tx *query.Query) error {
+		s := tx.DeploymentSchedule
+		info, err := s.WithContext(ctx).
+			Where(s.ID.Eq(schedule.ID)).
+			Where(s.NextRunAt.Eq(schedule.NextRunAt)).
+			UpdateSimple(
+				s.NextRunAt.Value(nextRunAt),
+				s.LastRunAt.Value(schedule.NextRunAt),
+				s.LastRequestID.Value(req.RequestID),
+				s.UpdatedOn.Value(time.Now()),
+			)
+		if err != nil {
+			return fmt.Errorf("failed to advance deployment schedule: %w", err)
+		}
+		if info.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.DeploymentRequest.WithContext(ctx).Create(req); err != nil {
+			return fmt.Errorf("failed to create deployment request: %w", err)
+		}
+		if msg != nil {
+			if err := tx.OutboxMessage.WithContext(ctx).Create(msg); err != nil {
+				return fmt.Errorf("failed to create outbox message: %w", err)
+			}
+		}
+		fired = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return fired, nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxRepository implements the outbox repository interface
+type OutboxRepository struct {
+	db *common.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *common.DB) portsdb.Outbox {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// PublishPending locks up to limit pending messages (oldest first) and calls publish for each,
+// marking it PUBLISHED on success. Rows are locked with SKIP LOCKED so several relays can run
+// side by side without publishing the same message concurrently. It stops at the first publish
+// error, which is recorded on the message and returned. Returns the number of messages published.
+func (r *OutboxRepository) PublishPending(ctx context.Context, limit int, publish func(msg *models.OutboxMessage) error) (int, error) {
+	published := 0
+	var publishErr error
+
+	q := query.Use(r.db.DB)
+	err := q.Transaction(func(tx *query.Query) error {
+		o := tx.OutboxMessage
+		msgs, err := o.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(o.Status.Eq(string(models.OutboxStatusPending))).
+			Order(o.CreatedOn).
+			Limit(limit).
+			Find()
+		if err != nil {
+			return fmt.Errorf("failed to query pending outbox messages: %w", err)
+		}
+
+		for _, msg := range msgs {
+			now := time.Now()
+			if publishErr = publish(msg); publishErr != nil {
+				// Record the failed attempt; the message stays PENDING and is retried next run
+				if _, err := o.WithContext(ctx).Where(o.ID.Eq(msg.ID)).UpdateSimple(
+					o.Attempts.Add(1),
+					o.LastError.Value(publishErr.Error()),
+					o.UpdatedOn.Value(now),
+				); err != nil {
+					return fmt.Errorf("failed to record outbox publish failure: %w", err)
+				}
+				return nil
+			}
+
+			if _, err := o.WithContext(ctx).Where(o.ID.Eq(msg.ID)).UpdateSimple(
+				o.Status.Value(string(models.OutboxStatusPublished)),
+				o.Attempts.Add(1),
+				o.PublishedOn.Value(now),
+				o.UpdatedOn.Value(now),
+			); err != nil {
+				return fmt.Errorf("failed to mark outbox message published: %w", err)
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return published, err
+	}
+	if publishErr != nil {
+		return published, fmt.Errorf("failed to publish outbox message: %w", publishErr)
+	}
+	return published, nil
+}
+
+// DeletePublishedBefore removes published messages older than before and returns how many were removed
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	q := query.Use(r.db.DB).OutboxMessage
+	info, err := q.WithContext(ctx).
+		Where(q.Status.Eq(string(models.OutboxStatusPublished))).
+		Where(q.PublishedOn.Lt(before)).
+		Delete()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox messages: %w", err)
+	}
+	return info.RowsAffected, nil
+}
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"gorm.io/gen/field"
+)
+
+// pageColumns are the columns a list can be sorted by, with the ID breaking ties
+type pageColumns struct {
+	id        field.Field
+	createdOn field.Time
+	name      field.String
+}
+
+// order returns the ORDER BY expressions of the sort
+func (c pageColumns) order(sort dto.ListSort) []field.Expr {
+	if sort.Field == dto.SortFieldName {
+		if sort.Desc {
+			return []field.Expr{c.name.Desc(), c.id.Desc()}
+		}
+		return []field.Expr{c.name, c.id}
+	}
+	if sort.Desc {
+		return []field.Expr{c.createdOn.Desc(), c.id.Desc()}
+	}
+	return []field.Expr{c.createdOn, c.id}
+}
+
+// after returns the condition selecting the rows that follow the cursor in the sort order
+func (c pageColumns) after(sort dto.ListSort, cursor *dto.PageCursor) (field.Expr, error) {
+	if sort.Field == dto.SortFieldName {
+		if sort.Desc {
+			return field.Or(c.name.Lt(cursor.Value), field.And(c.name.Eq(cursor.Value), c.id.Lt(cursor.ID))), nil
+		}
+		return field.Or(c.name.Gt(cursor.Value), field.And(c.name.Eq(cursor.Value), c.id.Gt(cursor.ID))), nil
+	}
+
+	createdOn, err := time.Parse(time.RFC3339Nano, cursor.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidCursor, err)
+	}
+	if sort.Desc {
+		return field.Or(c.createdOn.Lt(createdOn), field.And(c.createdOn.Eq(createdOn), c.id.Lt(cursor.ID))), nil
+	}
+	return field.Or(c.createdOn.Gt(createdOn), field.And(c.createdOn.Eq(createdOn), c.id.Gt(cursor.ID))), nil
+}
+
+// likePrefix returns the LIKE pattern matching values that start with prefix
+func likePrefix(prefix string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	return escaped + "%"
+}
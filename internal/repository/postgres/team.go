@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// TeamRepository implements the team repository interface
+type TeamRepository struct {
+	db *common.DB
+}
+
+// NewTeamRepository creates a new team repository
+func NewTeamRepository(db *common.DB) portsdb.Team {
+	return &TeamRepository{
+		db: db,
+	}
+}
+
+// CreateWithAdmin creates the team and its first admin membership in one transaction
+func (r *TeamRepository) CreateWithAdmin(ctx context.Context, team *models.Team, userID uuid.UUID) error {
+	q := query.Use(r.db.DB)
+	return q.Transaction(func(tx *query.Query) error {
+		if err := tx.Team.WithContext(ctx).Create(team); err != nil {
+			return fmt.Errorf("failed to create team: %w", err)
+		}
+		membership := &models.TeamMembership{
+			TeamID: team.ID,
+			UserID: userID,
+			Role:   models.TeamRoleAdmin,
+		}
+		if err := tx.TeamMembership.WithContext(ctx).Create(membership); err != nil {
+			return fmt.Errorf("failed to create team membership: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID retrieves a team by ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *TeamRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Team, bool, error) {
+	q := query.Use(r.db.DB).Team
+	teams, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query team: %w", err)
+	}
+	if len(teams) > 0 {
+		return teams[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// GetByName retrieves a team by name
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *TeamRepository) GetByName(ctx context.Context, name string) (*models.Team, bool, error) {
+	q := query.Use(r.db.DB).Team
+	teams, err := q.WithContext(ctx).
+		Where(q.Name.Eq(name)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query team: %w", err)
+	}
+	if len(teams) > 0 {
+		return teams[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListByIDs retrieves the teams with the given IDs, ordered by name
+func (r *TeamRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Team, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	q := query.Use(r.db.DB).Team
+	teams, err := q.WithContext(ctx).
+		Where(q.ID.In(uuidValues(ids)...)).
+		Order(q.Name).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list teams: %w", err)
+	}
+	return teams, nil
+}
+
+// GetMembership retrieves the user's membership in a team
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *TeamRepository) GetMembership(ctx context.Context, teamID, userID uuid.UUID) (*models.TeamMembership, bool, error) {
+	q := query.Use(r.db.DB).TeamMembership
+	memberships, err := q.WithContext(ctx).
+		Where(q.TeamID.Eq(teamID), q.UserID.Eq(userID)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query team membership: %w", err)
+	}
+	if len(memberships) > 0 {
+		return memberships[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListMembershipsByUserID retrieves the memberships of a user in all its teams
+func (r *TeamRepository) ListMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.TeamMembership, error) {
+	q := query.Use(r.db.DB).TeamMembership
+	memberships, err := q.WithContext(ctx).
+		Where(q.UserID.Eq(userID)).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team memberships: %w", err)
+	}
+	return memberships, nil
+}
+
+// ListMembers retrieves the memberships of a team, oldest first
+func (r *TeamRepository) ListMembers(ctx context.Context, teamID uuid.UUID) ([]*models.TeamMembership, error) {
+	q := query.Use(r.db.DB).TeamMembership
+	memberships, err := q.WithContext(ctx).
+		Where(q.TeamID.Eq(teamID)).
+		Order(q.CreatedOn).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list team members: %w", err)
+	}
+	return memberships, nil
+}
+
+// UpsertMember adds the user to the team, or changes its role if it is already a member
+func (r *TeamRepository) UpsertMember(ctx context.Context, membership *models.TeamMembership) error {
+	q := query.Use(r.db.DB).TeamMembership
+	err := q.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: q.TeamID.ColumnName().String()}, {Name: q.UserID.ColumnName().String()}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				q.Role.ColumnName().String():      membership.Role,
+				q.UpdatedOn.ColumnName().String(): time.Now(),
+			}),
+		}).
+		Create(membership).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert team member: %w", err)
+	}
+	return nil
+}
+
+// RemoveMember deletes the user's membership in the team
+func (r *TeamRepository) RemoveMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error) {
+	q := query.Use(r.db.DB).TeamMembership
+	info, err := q.WithContext(ctx).
+		Where(q.TeamID.Eq(teamID), q.UserID.Eq(userID)).
+		Delete()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove team member: %w", err)
+	}
+	return info.RowsAffected > 0, nil
+}
+
+// CountAdmins counts the admins of a team
+func (r *TeamRepository) CountAdmins(ctx context.Context, teamID uuid.UUID) (int64, error) {
+	q := query.Use(r.db.DB).TeamMembership
+	count, err := q.WithContext(ctx).
+		Where(q.TeamID.Eq(teamID), q.Role.Eq(string(models.TeamRoleAdmin))).
+		Count()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count team admins: %w", err)
+	}
+	return count, nil
+}
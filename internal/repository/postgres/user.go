@@ -2,12 +2,15 @@ package postgres
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 
 	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
 	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository implements the user repository interface
@@ -34,8 +37,57 @@ func (r *UserRepository) GetByExternalID(ctx context.Context, externalID string)
 	return user, nil
 }
 
+// GetByID retrieves a user by ID
+func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	q := query.Use(r.db.DB)
+	user, err := q.User.WithContext(ctx).
+		Where(q.User.ID.Eq(id)).
+		First()
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	return user, nil
+}
+
+// ListByIDs retrieves the users with the given IDs
+func (r *UserRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	q := query.Use(r.db.DB)
+	users, err := q.User.WithContext(ctx).
+		Where(q.User.ID.In(uuidValues(ids)...)).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
 // Create creates a new user in the database
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 	q := query.Use(r.db.DB)
 	return q.User.WithContext(ctx).Create(user)
 }
+
+// GetOrCreate retrieves a user by external ID, creating it if it does not exist.
+// Concurrent first requests of the same user are resolved by the unique external ID.
+func (r *UserRepository) GetOrCreate(ctx context.Context, externalID string) (*models.User, error) {
+	q := query.Use(r.db.DB)
+	user := &models.User{UserExternalID: externalID}
+	if err := q.User.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return r.GetByExternalID(ctx, externalID)
+}
+
+// uuidValues converts IDs to driver values for IN conditions on uuid columns
+func uuidValues(ids []uuid.UUID) []driver.Valuer {
+	values := make([]driver.Valuer, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id)
+	}
+	return values
+}
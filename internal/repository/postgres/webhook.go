@@ -0,0 +1,278 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/internal/database/query"
+	"github.com/code-xd/k8s-deployment-manager/internal/repository/postgres/common"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	"github.com/google/uuid"
+	"gorm.io/gen/field"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository implements the webhook repository interface
+type WebhookRepository struct {
+	db *common.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *common.DB) portsdb.Webhook {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+// Create creates a new webhook subscription in the database
+func (r *WebhookRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	q := query.Use(r.db.DB)
+	if err := q.WebhookSubscription.WithContext(ctx).Create(subscription); err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a webhook subscription by ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, bool, error) {
+	q := query.Use(r.db.DB).WebhookSubscription
+	subscriptions, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query webhook subscription: %w", err)
+	}
+	if len(subscriptions) > 0 {
+		return subscriptions[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListByUserID retrieves the webhook subscriptions created by the user, newest first
+func (r *WebhookRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.WebhookSubscription, error) {
+	q := query.Use(r.db.DB).WebhookSubscription
+	subscriptions, err := q.WithContext(ctx).
+		Where(q.UserID.Eq(userID)).
+		Order(q.CreatedOn.Desc()).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// ListByIDs retrieves the webhook subscriptions with the given IDs
+func (r *WebhookRepository) ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.WebhookSubscription, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	q := query.Use(r.db.DB).WebhookSubscription
+	subscriptions, err := q.WithContext(ctx).
+		Where(q.ID.In(uuidValues(ids)...)).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// ListByOwner retrieves the webhook subscriptions of a team, or the user's personal ones when teamID is nil
+func (r *WebhookRepository) ListByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.WebhookSubscription, error) {
+	q := query.Use(r.db.DB).WebhookSubscription
+	do := q.WithContext(ctx)
+	if teamID != nil {
+		do = do.Where(q.TeamID.Eq(*teamID))
+	} else {
+		do = do.Where(q.TeamID.IsNull(), q.UserID.Eq(userID))
+	}
+	subscriptions, err := do.Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owner webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// Delete removes the webhook subscription with its deliveries and their attempts in one transaction
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB)
+	return q.Transaction(func(tx *query.Query) error {
+		d := tx.WebhookDelivery
+		deliveries := d.WithContext(ctx).Select(d.ID).Where(d.SubscriptionID.Eq(id))
+		a := tx.WebhookDeliveryAttempt
+		if _, err := a.WithContext(ctx).Where(field.ContainsSubQuery([]field.Expr{a.DeliveryID}, deliveries.UnderlyingDB())).Delete(); err != nil {
+			return fmt.Errorf("failed to delete webhook delivery attempts: %w", err)
+		}
+		if _, err := d.WithContext(ctx).Where(d.SubscriptionID.Eq(id)).Delete(); err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		if _, err := tx.WebhookSubscription.WithContext(ctx).Where(tx.WebhookSubscription.ID.Eq(id)).Delete(); err != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries stores the deliveries, skipping those already stored for the same subscription and
+// event (e.g. when the event message is redelivered)
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	q := query.Use(r.db.DB).WebhookDelivery
+	err := q.WithContext(ctx).UnderlyingDB().
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(deliveries).Error
+	if err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// ClaimDueDeliveries locks up to limit due PENDING deliveries (oldest first) with SKIP LOCKED and moves
+// their next attempt lease ahead, so dispatchers running side by side do not send the same delivery.
+// A dispatcher that dies mid-send leaves the delivery to be retried once the lease expires.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error) {
+	var claimed []*models.WebhookDelivery
+
+	q := query.Use(r.db.DB)
+	err := q.Transaction(func(tx *query.Query) error {
+		d := tx.WebhookDelivery
+		now := time.Now()
+		deliveries, err := d.WithContext(ctx).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where(d.Status.Eq(string(models.WebhookDeliveryStatusPending))).
+			Where(d.NextAttemptAt.Lte(now)).
+			Order(d.NextAttemptAt).
+			Limit(limit).
+			Find()
+		if err != nil {
+			return fmt.Errorf("failed to query due webhook deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+		if _, err := d.WithContext(ctx).Where(d.ID.In(uuidValues(ids)...)).UpdateSimple(
+			d.NextAttemptAt.Value(now.Add(lease)),
+		); err != nil {
+			return fmt.Errorf("failed to lease webhook deliveries: %w", err)
+		}
+		claimed = deliveries
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// RecordAttempt stores the attempt and writes the delivery's status, attempts, next attempt, last result
+// and delivery time in one transaction
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error {
+	q := query.Use(r.db.DB)
+	return q.Transaction(func(tx *query.Query) error {
+		if err := tx.WebhookDeliveryAttempt.WithContext(ctx).Create(attempt); err != nil {
+			return fmt.Errorf("failed to create webhook delivery attempt: %w", err)
+		}
+
+		d := tx.WebhookDelivery
+		assignments := []field.AssignExpr{
+			d.Status.Value(string(delivery.Status)),
+			d.Attempts.Value(delivery.Attempts),
+			d.UpdatedOn.Value(time.Now()),
+		}
+		if delivery.NextAttemptAt != nil {
+			assignments = append(assignments, d.NextAttemptAt.Value(*delivery.NextAttemptAt))
+		} else {
+			assignments = append(assignments, d.NextAttemptAt.Null())
+		}
+		if delivery.LastStatusCode != nil {
+			assignments = append(assignments, d.LastStatusCode.Value(*delivery.LastStatusCode))
+		} else {
+			assignments = append(assignments, d.LastStatusCode.Null())
+		}
+		if delivery.LastError != nil {
+			assignments = append(assignments, d.LastError.Value(*delivery.LastError))
+		} else {
+			assignments = append(assignments, d.LastError.Null())
+		}
+		if delivery.DeliveredOn != nil {
+			assignments = append(assignments, d.DeliveredOn.Value(*delivery.DeliveredOn))
+		}
+		if _, err := d.WithContext(ctx).Where(d.ID.Eq(delivery.ID)).UpdateSimple(assignments...); err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetDelivery retrieves a webhook delivery by ID
+// Returns single object (at most one), boolean indicating if found, and error
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, bool, error) {
+	q := query.Use(r.db.DB).WebhookDelivery
+	deliveries, err := q.WithContext(ctx).
+		Where(q.ID.Eq(id)).
+		Find()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query webhook delivery: %w", err)
+	}
+	if len(deliveries) > 0 {
+		return deliveries[0], true, nil
+	}
+	return nil, false, nil
+}
+
+// ListDeliveries retrieves up to limit deliveries of the subscription, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	q := query.Use(r.db.DB).WebhookDelivery
+	deliveries, err := q.WithContext(ctx).
+		Where(q.SubscriptionID.Eq(subscriptionID)).
+		Order(q.CreatedOn.Desc()).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// ListAttempts retrieves the attempts of the deliveries, oldest first
+func (r *WebhookRepository) ListAttempts(ctx context.Context, deliveryIDs []uuid.UUID) ([]*models.WebhookDeliveryAttempt, error) {
+	if len(deliveryIDs) == 0 {
+		return nil, nil
+	}
+	q := query.Use(r.db.DB).WebhookDeliveryAttempt
+	attempts, err := q.WithContext(ctx).
+		Where(q.DeliveryID.In(uuidValues(deliveryIDs)...)).
+		Order(q.CreatedOn).
+		Find()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook delivery attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+// Redeliver makes the delivery PENDING and due now, with its attempt count reset; earlier attempts are kept
+func (r *WebhookRepository) Redeliver(ctx context.Context, id uuid.UUID) error {
+	q := query.Use(r.db.DB).WebhookDelivery
+	now := time.Now()
+	if _, err := q.WithContext(ctx).Where(q.ID.Eq(id)).UpdateSimple(
+		q.Status.Value(string(models.WebhookDeliveryStatusPending)),
+		q.Attempts.Value(0),
+		q.NextAttemptAt.Value(now),
+		q.UpdatedOn.Value(now),
+	); err != nil {
+		return fmt.Errorf("failed to redeliver webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portstemplate "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/template"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Registry holds the deployment templates found under <basePath>/templates/*/.
+// Each template folder has a manifest.yaml describing the template and a deployment.yaml
+// rendered by the worker, so new templates can be added without code changes.
+type Registry struct {
+	templates []*dto.TemplateManifest
+	byName    map[string]*dto.TemplateManifest
+}
+
+// NewRegistry loads and validates every template under basePath/templates.
+// basePath is the directory containing the templates folder (e.g. project root or ".").
+func NewRegistry(basePath string) (portstemplate.Registry, error) {
+	root, err := filepath.Abs(filepath.Join(basePath, dto.TemplatesDir))
+	if err != nil {
+		return nil, fmt.Errorf("resolve templates path: %w", err)
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("read templates directory %q: %w", root, err)
+	}
+
+	r := &Registry{byName: make(map[string]*dto.TemplateManifest)}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := loadManifest(filepath.Join(root, entry.Name()), entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := r.byName[manifest.Name]; exists {
+			return nil, fmt.Errorf("template %q is defined more than once", manifest.Name)
+		}
+		r.byName[manifest.Name] = manifest
+		r.templates = append(r.templates, manifest)
+	}
+	if len(r.templates) == 0 {
+		return nil, fmt.Errorf("no templates found in %q", root)
+	}
+
+	sort.Slice(r.templates, func(i, j int) bool {
+		return r.templates[i].Name < r.templates[j].Name
+	})
+	return r, nil
+}
+
+// loadManifest reads and validates the manifest of the template in dir.
+func loadManifest(dir, folder string) (*dto.TemplateManifest, error) {
+	manifestPath := filepath.Join(dir, dto.TemplateManifestFile)
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("read template manifest %q: %w", manifestPath, err)
+	}
+
+	var manifest dto.TemplateManifest
+	if err := yaml.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("parse template manifest %q: %w", manifestPath, err)
+	}
+	if manifest.Name == "" {
+		manifest.Name = folder
+	}
+	if manifest.Name != folder {
+		return nil, fmt.Errorf("template manifest %q: name %q must match its folder", manifestPath, manifest.Name)
+	}
+	if len(manifest.ImagePatterns) == 0 {
+		return nil, fmt.Errorf("template manifest %q: at least one image pattern is required", manifestPath)
+	}
+	for _, pattern := range manifest.ImagePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("template manifest %q: invalid image pattern %q: %w", manifestPath, pattern, err)
+		}
+	}
+	if manifest.ContainerPort < 1 || manifest.ContainerPort > 65535 {
+		return nil, fmt.Errorf("template manifest %q: container_port must be between 1 and 65535", manifestPath)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, dto.TemplateDeploymentFile)); err != nil {
+		return nil, fmt.Errorf("template %q: %w", manifest.Name, err)
+	}
+	return &manifest, nil
+}
+
+// List returns all templates ordered by name
+func (r *Registry) List() []*dto.TemplateManifest {
+	out := make([]*dto.TemplateManifest, len(r.templates))
+	copy(out, r.templates)
+	return out
+}
+
+// Get returns the template with the given name
+func (r *Registry) Get(name string) (*dto.TemplateManifest, bool) {
+	manifest, ok := r.byName[name]
+	return manifest, ok
+}
+
+// Match returns the first template (by name) with an image pattern matching image.
+// The tag and digest are ignored, e.g. "docker.io/library/nginx:1.27" is matched as "docker.io/library/nginx".
+func (r *Registry) Match(image string) (*dto.TemplateManifest, bool) {
+	repository := imageRepository(image)
+	for _, manifest := range r.templates {
+		for _, pattern := range manifest.ImagePatterns {
+			if ok, _ := path.Match(pattern, repository); ok {
+				return manifest, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// imageRepository strips the digest and tag from image.
+// e.g. "nginx:latest" -> "nginx", "registry:5000/team/app@sha256:..." -> "registry:5000/team/app"
+func imageRepository(image string) string {
+	if idx := strings.Index(image, "@"); idx >= 0 {
+		image = image[:idx]
+	}
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+	return image
+}
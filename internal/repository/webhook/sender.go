@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portswebhook "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/webhook"
+)
+
+// maxDrainBody caps the response body read (and discarded) so the connection can be reused
+const maxDrainBody = 4096
+
+// errAddressNotAllowed is returned when a webhook URL resolves to a loopback, private or link-local address
+var errAddressNotAllowed = errors.New("webhook address is not allowed")
+
+// HTTPSender POSTs webhook payloads over HTTP
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender creates a sender whose requests time out after timeout. Unless allowPrivateNetworks is set,
+// connections to loopback, private, link-local and other non-public addresses are refused after the host
+// is resolved, so a webhook cannot reach the cluster network or the cloud metadata endpoint. Redirects are
+// not followed and environment proxies are not used.
+func NewHTTPSender(timeout time.Duration, allowPrivateNetworks bool) portswebhook.Sender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = refusePrivateAddress
+	}
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send POSTs the JSON payload with the event, delivery, timestamp and signature headers. Any status outside
+// 2xx, redirects included, is an error. The response body is never quoted: the error is shown to the
+// webhook's owner.
+func (s *HTTPSender) Send(ctx context.Context, url, secret, eventType, deliveryID string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(dto.WebhookHeaderEvent, eventType)
+	req.Header.Set(dto.WebhookHeaderDelivery, deliveryID)
+	req.Header.Set(dto.WebhookHeaderTimestamp, timestamp)
+	req.Header.Set(dto.WebhookHeaderSignature, Sign(secret, timestamp, payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// refusePrivateAddress is a net.Dialer Control func that refuses to connect to addresses that are not
+// public. It runs on the resolved address, so a host name cannot be pointed at the cluster after creation.
+func refusePrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return fmt.Errorf("%w: %s", errAddressNotAllowed, host)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), used by some clusters for pods or services
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// publicIP reports whether ip is a globally routable unicast address
+func publicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !sharedAddressSpace.Contains(ip)
+}
+
+// Sign returns the signature header value of a payload: "sha256=" and the hex HMAC-SHA256 of
+// "<timestamp>.<payload>" keyed with secret
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return dto.WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
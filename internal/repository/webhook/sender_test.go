@@ -0,0 +1,89 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "203.0.113.10", want: true},
+		{ip: "2001:db8::1", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.96.0.1", want: false},
+		{ip: "172.16.5.4", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "100.64.0.1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+		{ip: "224.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPSenderSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusNoContent)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("internal secret"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name                 string
+		path                 string
+		allowPrivateNetworks bool
+		wantStatus           int
+		wantErr              bool
+	}{
+		{name: "loopback refused", path: "/ok", wantErr: true},
+		{name: "delivered when private networks are allowed", path: "/ok", allowPrivateNetworks: true, wantStatus: http.StatusNoContent},
+		{name: "redirect not followed", path: "/redirect", allowPrivateNetworks: true, wantStatus: http.StatusFound, wantErr: true},
+		{name: "error status without the body", path: "/fail", allowPrivateNetworks: true, wantStatus: http.StatusInternalServerError, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender := NewHTTPSender(5*time.Second, tt.allowPrivateNetworks)
+			status, err := sender.Send(context.Background(), server.URL+tt.path, "secret", "deployment.deleted", "delivery", []byte(`{}`))
+			if status != tt.wantStatus {
+				t.Errorf("Send() status = %d, want %d", status, tt.wantStatus)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "internal secret") {
+				t.Errorf("Send() error = %v, quotes the response body", err)
+			}
+			if tt.wantStatus == 0 && !errors.Is(err, errAddressNotAllowed) {
+				t.Errorf("Send() error = %v, want %v", err, errAddressNotAllowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,551 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AdmissionService enforces the namespace allowlists and quotas of the admission config.
+// Usage counts the owner's deployments that are not DELETED plus its CREATE, UPDATE and ROLLBACK requests not
+// processed yet. The service must run under the owner's lock taken by DeploymentRequest.CreateWithOutboxAdmitted,
+// otherwise concurrent requests read the same usage.
+type AdmissionService struct {
+	reserved              []string
+	defaultPolicy         *admissionPolicy
+	teamPolicies          map[string]*admissionPolicy
+	userPolicies          map[string]*admissionPolicy
+	deploymentRepo        portsdb.Deployment
+	deploymentRequestRepo portsdb.DeploymentRequest
+	teamRepo              portsdb.Team
+	userRepo              portsdb.User
+	logger                *zap.Logger
+}
+
+// admissionPolicy is a parsed dto.AdmissionPolicy; zero limits and nil quantities are unlimited
+type admissionPolicy struct {
+	namespaces                 []string
+	maxDeploymentsPerNamespace int
+	maxReplicas                int
+	maxCPU                     *resource.Quantity
+	maxMemory                  *resource.Quantity
+}
+
+// deploymentSize is what one deployment counts against the replica, CPU and memory quotas
+type deploymentSize struct {
+	replicas       int
+	podCPUMillis   int64
+	podMemoryBytes int64
+}
+
+func (d deploymentSize) cpuMillis() int64   { return int64(d.replicas) * d.podCPUMillis }
+func (d deploymentSize) memoryBytes() int64 { return int64(d.replicas) * d.podMemoryBytes }
+
+// ownerUsage is the current usage of a user's personal deployments or of a team
+type ownerUsage struct {
+	// sizes holds the counted size of each deployment by identifier
+	sizes        map[string]deploymentSize
+	perNamespace map[string]int
+	replicas     int
+	cpuMillis    int64
+	memoryBytes  int64
+}
+
+// NewAdmissionService creates a new AdmissionService; it fails on invalid patterns or quantities in cfg
+func NewAdmissionService(
+	cfg dto.AdmissionConfig,
+	deploymentRepo portsdb.Deployment,
+	deploymentRequestRepo portsdb.DeploymentRequest,
+	teamRepo portsdb.Team,
+	userRepo portsdb.User,
+	logger *zap.Logger,
+) (portsapi.Admission, error) {
+	if err := validateNamespacePatterns(cfg.ReservedNamespaces); err != nil {
+		return nil, fmt.Errorf("admission reserved_namespaces: %w", err)
+	}
+	defaultPolicy, err := parseAdmissionPolicy(cfg.Default)
+	if err != nil {
+		return nil, fmt.Errorf("admission default policy: %w", err)
+	}
+
+	s := &AdmissionService{
+		reserved:              cfg.ReservedNamespaces,
+		defaultPolicy:         defaultPolicy,
+		teamPolicies:          make(map[string]*admissionPolicy),
+		userPolicies:          make(map[string]*admissionPolicy),
+		deploymentRepo:        deploymentRepo,
+		deploymentRequestRepo: deploymentRequestRepo,
+		teamRepo:              teamRepo,
+		userRepo:              userRepo,
+		logger:                logger,
+	}
+	for i, p := range cfg.Policies {
+		if (p.Team == "") == (p.User == "") {
+			return nil, fmt.Errorf("admission policy %d: set exactly one of team and user", i)
+		}
+		policy, err := parseAdmissionPolicy(p)
+		if err != nil {
+			return nil, fmt.Errorf("admission policy %d: %w", i, err)
+		}
+		if p.Team != "" {
+			s.teamPolicies[p.Team] = policy
+		} else {
+			s.userPolicies[p.User] = policy
+		}
+	}
+	return s, nil
+}
+
+// AdmitCreate checks the namespace against the reserved namespaces and the owner's allowlist, then the
+// owner's per-namespace deployment count and replica, CPU and memory quotas with the new deployment added
+func (s *AdmissionService) AdmitCreate(ctx context.Context, owner dto.Ownership, namespace string, metadata *dto.DeploymentMetadata) error {
+	if matchesNamespace(s.reserved, namespace) {
+		return namespaceViolation(namespace)
+	}
+	policy, err := s.ownerPolicy(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if len(policy.namespaces) > 0 && !matchesNamespace(policy.namespaces, namespace) {
+		return namespaceViolation(namespace)
+	}
+
+	size := deploymentSize{replicas: createReplicas(metadata)}
+	size.podCPUMillis, size.podMemoryBytes, err = parseLimits(&metadata.ResourceLimit.Limit)
+	if err != nil {
+		return err
+	}
+
+	usage, err := s.usage(ctx, owner)
+	if err != nil {
+		return err
+	}
+	if limit := policy.maxDeploymentsPerNamespace; limit > 0 && usage.perNamespace[namespace]+1 > limit {
+		return &dto.AdmissionViolation{
+			Err:       dto.ErrQuotaExceeded,
+			Rule:      dto.RuleDeploymentsPerNamespace,
+			Namespace: namespace,
+			Limit:     strconv.Itoa(limit),
+			Used:      strconv.Itoa(usage.perNamespace[namespace]),
+			Requested: "1",
+		}
+	}
+	return checkQuotas(policy, usage, deploymentSize{}, size)
+}
+
+// AdmitUpdate checks the owner's replica, CPU and memory quotas with the deployment resized by metadata;
+// autoscaling counts its max_replicas. The deployment counts as its largest pending size, if any.
+// Requests that do not grow the deployment are always admitted, so an owner over a lowered quota can shrink.
+func (s *AdmissionService) AdmitUpdate(ctx context.Context, deployment *models.Deployment, metadata *dto.UpdateDeploymentRequestMetadata) error {
+	stored := storedSize(deployment)
+	next, err := resize(stored, metadata)
+	if err != nil {
+		return err
+	}
+	if next == stored {
+		return nil
+	}
+
+	owner := dto.Ownership{UserID: deployment.UserID, TeamID: deployment.TeamID}
+	policy, err := s.ownerPolicy(ctx, owner)
+	if err != nil {
+		return err
+	}
+	usage, err := s.usage(ctx, owner)
+	if err != nil {
+		return err
+	}
+	current, ok := usage.sizes[deployment.Identifier]
+	if !ok {
+		current = stored
+	}
+	return checkQuotas(policy, usage, current, next)
+}
+
+// GetQuotas returns the policy and usage of the user's personal deployments followed by those of its teams
+func (s *AdmissionService) GetQuotas(ctx context.Context, userID string) ([]*dto.QuotaResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	personal := dto.Ownership{UserID: userUUID}
+	usage, err := s.usage(ctx, personal)
+	if err != nil {
+		return nil, err
+	}
+	quotas := []*dto.QuotaResponse{s.toQuotaResponse(s.userPolicy(user.UserExternalID), usage)}
+
+	memberships, err := s.teamRepo.ListMembershipsByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs := make([]uuid.UUID, 0, len(memberships))
+	for _, m := range memberships {
+		teamIDs = append(teamIDs, m.TeamID)
+	}
+	teams, err := s.teamRepo.ListByIDs(ctx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		usage, err := s.usage(ctx, dto.Ownership{UserID: userUUID, TeamID: &team.ID})
+		if err != nil {
+			return nil, err
+		}
+		quota := s.toQuotaResponse(s.teamPolicy(team.Name), usage)
+		quota.TeamID = &team.ID
+		quota.TeamName = team.Name
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// ownerPolicy returns the policy of the owning team, or of the user for personal deployments
+func (s *AdmissionService) ownerPolicy(ctx context.Context, owner dto.Ownership) (*admissionPolicy, error) {
+	if owner.TeamID != nil {
+		if len(s.teamPolicies) == 0 {
+			return s.defaultPolicy, nil
+		}
+		team, found, err := s.teamRepo.GetByID(ctx, *owner.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, dto.ErrTeamNotFound
+		}
+		return s.teamPolicy(team.Name), nil
+	}
+
+	if len(s.userPolicies) == 0 {
+		return s.defaultPolicy, nil
+	}
+	user, err := s.userRepo.GetByID(ctx, owner.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.userPolicy(user.UserExternalID), nil
+}
+
+// teamPolicy returns the policy configured for the team name, or the default
+func (s *AdmissionService) teamPolicy(name string) *admissionPolicy {
+	if policy, ok := s.teamPolicies[name]; ok {
+		return policy
+	}
+	return s.defaultPolicy
+}
+
+// userPolicy returns the policy configured for the user external ID, or the default
+func (s *AdmissionService) userPolicy(externalID string) *admissionPolicy {
+	if policy, ok := s.userPolicies[externalID]; ok {
+		return policy
+	}
+	return s.defaultPolicy
+}
+
+// usage sums the owner's deployments that are not DELETED and its pending CREATE, UPDATE and ROLLBACK
+// requests. A deployment counts once, at the largest of its stored size and the sizes its pending requests
+// resize it to, since they may be applied in any order before the next request is admitted.
+func (s *AdmissionService) usage(ctx context.Context, owner dto.Ownership) (*ownerUsage, error) {
+	deployments, err := s.deploymentRepo.ListActiveByOwner(ctx, owner.UserID, owner.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := s.deploymentRequestRepo.ListPendingResizesByOwner(ctx, owner.UserID, owner.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &ownerUsage{
+		sizes:        make(map[string]deploymentSize, len(deployments)+len(pending)),
+		perNamespace: make(map[string]int),
+	}
+	// base is the size pending resizes start from: the stored size, or the pending CREATE's
+	base := make(map[string]deploymentSize, len(deployments))
+	for _, d := range deployments {
+		base[d.Identifier] = storedSize(d)
+		usage.sizes[d.Identifier] = base[d.Identifier]
+		usage.perNamespace[d.Namespace]++
+	}
+	for _, r := range pending {
+		if r.RequestType == models.DeploymentRequestTypeCreate {
+			// A pending request whose deployment was already synced (e.g. while waiting for the rollout) is counted once
+			if _, ok := base[r.Identifier]; ok {
+				continue
+			}
+			size, err := requestSize(r)
+			if err != nil {
+				// The worker will fail the request; it still takes a deployment slot until then
+				s.logger.Warn("Failed to size pending deployment request for quota",
+					zap.String("request_id", r.RequestID),
+					zap.Error(err),
+				)
+			}
+			base[r.Identifier] = size
+			usage.sizes[r.Identifier] = size
+			usage.perNamespace[r.Namespace]++
+			continue
+		}
+
+		from, ok := base[r.Identifier]
+		if !ok {
+			// The deployment is gone; the worker will fail the request
+			continue
+		}
+		var metadata dto.UpdateDeploymentRequestMetadata
+		err := decodeMetadata(r.Metadata, &metadata)
+		var size deploymentSize
+		if err == nil {
+			size, err = resize(from, &metadata)
+		}
+		if err != nil {
+			s.logger.Warn("Failed to size pending deployment request for quota",
+				zap.String("request_id", r.RequestID),
+				zap.Error(err),
+			)
+			continue
+		}
+		usage.sizes[r.Identifier] = largest(usage.sizes[r.Identifier], size)
+	}
+
+	for _, size := range usage.sizes {
+		usage.replicas += size.replicas
+		usage.cpuMillis += size.cpuMillis()
+		usage.memoryBytes += size.memoryBytes()
+	}
+	return usage, nil
+}
+
+// toQuotaResponse maps a policy and usage to the quota response
+func (s *AdmissionService) toQuotaResponse(policy *admissionPolicy, usage *ownerUsage) *dto.QuotaResponse {
+	quota := &dto.QuotaResponse{
+		Namespaces:                 policy.namespaces,
+		ReservedNamespaces:         s.reserved,
+		DeploymentsPerNamespace:    usage.perNamespace,
+		MaxDeploymentsPerNamespace: policy.maxDeploymentsPerNamespace,
+		Replicas:                   dto.QuotaUsage{Used: strconv.Itoa(usage.replicas)},
+		CPU:                        dto.QuotaUsage{Used: formatCPU(usage.cpuMillis)},
+		Memory:                     dto.QuotaUsage{Used: formatMemory(usage.memoryBytes)},
+	}
+	if quota.Namespaces == nil {
+		quota.Namespaces = []string{}
+	}
+	if quota.ReservedNamespaces == nil {
+		quota.ReservedNamespaces = []string{}
+	}
+	if policy.maxReplicas > 0 {
+		quota.Replicas.Limit = strconv.Itoa(policy.maxReplicas)
+	}
+	if policy.maxCPU != nil {
+		quota.CPU.Limit = policy.maxCPU.String()
+	}
+	if policy.maxMemory != nil {
+		quota.Memory.Limit = policy.maxMemory.String()
+	}
+	return quota
+}
+
+// checkQuotas checks the replica, CPU and memory quotas with the deployment resized from current to next
+// (current is zero for a new deployment). Only quotas the change grows are checked.
+func checkQuotas(policy *admissionPolicy, usage *ownerUsage, current, next deploymentSize) error {
+	if policy.maxReplicas > 0 && next.replicas > current.replicas {
+		used := usage.replicas - current.replicas
+		if used+next.replicas > policy.maxReplicas {
+			return quotaViolation(dto.RuleReplicas, strconv.Itoa(policy.maxReplicas), strconv.Itoa(used), strconv.Itoa(next.replicas))
+		}
+	}
+	if policy.maxCPU != nil && next.cpuMillis() > current.cpuMillis() {
+		used := usage.cpuMillis - current.cpuMillis()
+		if used+next.cpuMillis() > policy.maxCPU.MilliValue() {
+			return quotaViolation(dto.RuleCPU, policy.maxCPU.String(), formatCPU(used), formatCPU(next.cpuMillis()))
+		}
+	}
+	if policy.maxMemory != nil && next.memoryBytes() > current.memoryBytes() {
+		used := usage.memoryBytes - current.memoryBytes()
+		if used+next.memoryBytes() > policy.maxMemory.Value() {
+			return quotaViolation(dto.RuleMemory, policy.maxMemory.String(), formatMemory(used), formatMemory(next.memoryBytes()))
+		}
+	}
+	return nil
+}
+
+// namespaceViolation reports a namespace that is reserved or not on the owner's allowlist
+func namespaceViolation(namespace string) error {
+	return &dto.AdmissionViolation{
+		Err:       dto.ErrNamespaceNotAllowed,
+		Rule:      dto.RuleNamespace,
+		Namespace: namespace,
+	}
+}
+
+// quotaViolation reports a replica, CPU or memory quota the request would exceed
+func quotaViolation(rule, limit, used, requested string) error {
+	return &dto.AdmissionViolation{
+		Err:       dto.ErrQuotaExceeded,
+		Rule:      rule,
+		Limit:     limit,
+		Used:      used,
+		Requested: requested,
+	}
+}
+
+// storedSize is the size of a deployment as last synced
+func storedSize(d *models.Deployment) deploymentSize {
+	return deploymentSize{
+		replicas:       d.Replicas,
+		podCPUMillis:   d.PodCPUMillis,
+		podMemoryBytes: d.PodMemoryBytes,
+	}
+}
+
+// resize returns current resized by the metadata of an UPDATE or ROLLBACK request; autoscaling counts its max_replicas
+func resize(current deploymentSize, metadata *dto.UpdateDeploymentRequestMetadata) (deploymentSize, error) {
+	next := current
+	if metadata.ReplicaCount != nil {
+		next.replicas = *metadata.ReplicaCount
+	}
+	// An autoscaled deployment may grow to its max_replicas
+	if metadata.Autoscaling != nil && !metadata.Autoscaling.Disabled {
+		next.replicas = metadata.Autoscaling.MaxReplicas
+	}
+	if metadata.ResourceLimit != nil {
+		var err error
+		next.podCPUMillis, next.podMemoryBytes, err = parseLimits(&metadata.ResourceLimit.Limit)
+		if err != nil {
+			return deploymentSize{}, err
+		}
+	}
+	return next, nil
+}
+
+// largest returns the larger of each replica count and pod limit of a and b
+func largest(a, b deploymentSize) deploymentSize {
+	return deploymentSize{
+		replicas:       max(a.replicas, b.replicas),
+		podCPUMillis:   max(a.podCPUMillis, b.podCPUMillis),
+		podMemoryBytes: max(a.podMemoryBytes, b.podMemoryBytes),
+	}
+}
+
+// requestSize sizes a pending CREATE request from its metadata
+func requestSize(r *models.DeploymentRequest) (deploymentSize, error) {
+	var metadata dto.DeploymentMetadata
+	if err := decodeMetadata(r.Metadata, &metadata); err != nil {
+		return deploymentSize{}, err
+	}
+	size := deploymentSize{replicas: createReplicas(&metadata)}
+	var err error
+	size.podCPUMillis, size.podMemoryBytes, err = parseLimits(&metadata.ResourceLimit.Limit)
+	return size, err
+}
+
+// decodeMetadata decodes request metadata stored as JSONB into a metadata DTO by its json tags
+func decodeMetadata(metadata models.JSONB, out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  out,
+		TagName: dto.MapstructureTagJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if err := decoder.Decode(map[string]interface{}(metadata)); err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+	return nil
+}
+
+// createReplicas returns the replica count of a CREATE request: the max_replicas of an autoscaled deployment,
+// 0 when it has none
+func createReplicas(metadata *dto.DeploymentMetadata) int {
+	if metadata.Autoscaling != nil && !metadata.Autoscaling.Disabled {
+		return metadata.Autoscaling.MaxReplicas
+	}
+	if metadata.ReplicaCount == nil {
+		return 0
+	}
+	return *metadata.ReplicaCount
+}
+
+// parseLimits parses the CPU (in millicores) and memory (in bytes) limits of one pod
+func parseLimits(limit *dto.ResourceLimitInfo) (int64, int64, error) {
+	cpu, err := resource.ParseQuantity(limit.CPU)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: cpu %q: %v", dto.ErrInvalidResourceQuantity, limit.CPU, err)
+	}
+	memory, err := resource.ParseQuantity(limit.Memory)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: memory %q: %v", dto.ErrInvalidResourceQuantity, limit.Memory, err)
+	}
+	return cpu.MilliValue(), memory.Value(), nil
+}
+
+// parseAdmissionPolicy validates the namespace patterns and parses the CPU and memory quotas of a policy
+func parseAdmissionPolicy(cfg dto.AdmissionPolicy) (*admissionPolicy, error) {
+	if err := validateNamespacePatterns(cfg.Namespaces); err != nil {
+		return nil, err
+	}
+	policy := &admissionPolicy{
+		namespaces:                 cfg.Namespaces,
+		maxDeploymentsPerNamespace: cfg.MaxDeploymentsPerNamespace,
+		maxReplicas:                cfg.MaxReplicas,
+	}
+	if cfg.MaxCPU != "" {
+		q, err := resource.ParseQuantity(cfg.MaxCPU)
+		if err != nil {
+			return nil, fmt.Errorf("max_cpu: %w", err)
+		}
+		policy.maxCPU = &q
+	}
+	if cfg.MaxMemory != "" {
+		q, err := resource.ParseQuantity(cfg.MaxMemory)
+		if err != nil {
+			return nil, fmt.Errorf("max_memory: %w", err)
+		}
+		policy.maxMemory = &q
+	}
+	return policy, nil
+}
+
+// validateNamespacePatterns rejects malformed path.Match patterns
+func validateNamespacePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("namespace pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchesNamespace reports whether namespace matches any of the patterns
+func matchesNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCPU formats millicores as a Kubernetes quantity (e.g. "1500m")
+func formatCPU(millis int64) string {
+	return resource.NewMilliQuantity(millis, resource.DecimalSI).String()
+}
+
+// formatMemory formats bytes as a Kubernetes quantity (e.g. "512Mi")
+func formatMemory(bytes int64) string {
+	return resource.NewQuantity(bytes, resource.BinarySI).String()
+}
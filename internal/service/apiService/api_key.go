@@ -0,0 +1,147 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// APIKeyService implements creating, listing and revoking API keys for the API
+type APIKeyService struct {
+	repo   portsdb.APIKey
+	logger *zap.Logger
+}
+
+// NewAPIKeyService creates a new APIKeyService with injected dependencies
+func NewAPIKeyService(
+	repo portsdb.APIKey,
+	logger *zap.Logger,
+) portsapi.APIKey {
+	return &APIKeyService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// CreateAPIKey generates a key for the principal's user. The requested scopes must all be granted to the
+// principal, so a restricted key cannot mint a broader one. The full key is only returned here.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, principal *dto.Principal, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	for _, scope := range req.Scopes {
+		if !principal.HasScope(scope) {
+			return nil, fmt.Errorf("%w: %s", dto.ErrScopeNotGranted, scope)
+		}
+	}
+
+	key, keyID, secretHash, err := utils.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &models.APIKey{
+		UserID:     principal.User.ID,
+		Name:       req.Name,
+		KeyID:      keyID,
+		SecretHash: secretHash,
+		Scopes:     utils.JoinScopes(req.Scopes),
+	}
+	if req.ExpiresInSeconds > 0 {
+		expiresOn := time.Now().Add(time.Duration(req.ExpiresInSeconds) * time.Second)
+		apiKey.ExpiresOn = &expiresOn
+	}
+
+	if err := s.repo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API key created",
+		zap.String("user_id", principal.User.ID.String()),
+		zap.String("key_id", keyID),
+		zap.Strings("scopes", req.Scopes),
+	)
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: *toAPIKeyResponse(apiKey),
+		Key:            key,
+	}, nil
+}
+
+// ListAPIKeys returns the API keys of the given user, including revoked and expired ones
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, userID string) ([]*dto.APIKeyResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	keys, err := s.repo.ListByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.APIKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, toAPIKeyResponse(k))
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes one of the user's API keys; requests made with it are rejected from then on
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id string, userID string) (*dto.APIKeyResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	keyUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, dto.ErrAPIKeyNotFound
+	}
+
+	apiKey, found, err := s.repo.GetByID(ctx, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !found || apiKey.UserID != userUUID {
+		return nil, dto.ErrAPIKeyNotFound
+	}
+
+	if err := s.repo.Revoke(ctx, keyUUID); err != nil {
+		return nil, err
+	}
+
+	apiKey, _, err = s.repo.GetByID(ctx, keyUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API key revoked", zap.String("user_id", userID), zap.String("key_id", apiKey.KeyID))
+	return toAPIKeyResponse(apiKey), nil
+}
+
+// toAPIKeyResponse maps an API key to its response; timestamps are RFC3339, empty when unset
+func toAPIKeyResponse(k *models.APIKey) *dto.APIKeyResponse {
+	resp := &dto.APIKeyResponse{
+		ID:        k.ID,
+		Name:      k.Name,
+		KeyID:     k.KeyID,
+		Scopes:    utils.ParseScopes(k.Scopes),
+		CreatedAt: k.CreatedOn.Format(time.RFC3339),
+	}
+	if k.ExpiresOn != nil {
+		resp.ExpiresAt = k.ExpiresOn.Format(time.RFC3339)
+	}
+	if k.LastUsedOn != nil {
+		resp.LastUsedAt = k.LastUsedOn.Format(time.RFC3339)
+	}
+	if k.RevokedOn != nil {
+		resp.RevokedAt = k.RevokedOn.Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,79 @@
+package apiService
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"go.uber.org/zap"
+)
+
+// APIKeyAuthenticator authenticates requests by the static API key in the X-API-Key header
+type APIKeyAuthenticator struct {
+	apiKeyRepo portsdb.APIKey
+	userRepo   portsdb.User
+	logger     *zap.Logger
+}
+
+// NewAPIKeyAuthenticator creates a new APIKeyAuthenticator with injected dependencies
+func NewAPIKeyAuthenticator(
+	apiKeyRepo portsdb.APIKey,
+	userRepo portsdb.User,
+	logger *zap.Logger,
+) portsapi.Authenticator {
+	return &APIKeyAuthenticator{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		logger:     logger,
+	}
+}
+
+// Authenticate looks the key up by its key ID and compares the hash of its secret with the stored one.
+// Expired and revoked keys are rejected; the principal is limited to the key's scopes.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*dto.Principal, error) {
+	key := r.Header.Get(dto.APIKeyHeader)
+	if key == "" {
+		return nil, dto.ErrNoCredentials
+	}
+
+	keyID, secret, ok := utils.ParseAPIKey(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed api key", dto.ErrInvalidCredentials)
+	}
+
+	apiKey, found, err := a.apiKeyRepo.GetByKeyID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !found || subtle.ConstantTimeCompare([]byte(utils.HashAPIKeySecret(secret)), []byte(apiKey.SecretHash)) != 1 {
+		return nil, fmt.Errorf("%w: unknown api key", dto.ErrInvalidCredentials)
+	}
+
+	now := time.Now()
+	if apiKey.RevokedOn != nil {
+		return nil, fmt.Errorf("%w: api key %s is revoked", dto.ErrInvalidCredentials, keyID)
+	}
+	if apiKey.ExpiresOn != nil && now.After(*apiKey.ExpiresOn) {
+		return nil, fmt.Errorf("%w: api key %s expired", dto.ErrInvalidCredentials, keyID)
+	}
+
+	user, err := a.userRepo.GetByID(ctx, apiKey.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		a.logger.Warn("Failed to update api key last used", zap.String("key_id", keyID), zap.Error(err))
+	}
+	scopes := utils.ParseScopes(apiKey.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &dto.Principal{User: user, Scopes: scopes, APIKeyID: &apiKey.ID}, nil
+}
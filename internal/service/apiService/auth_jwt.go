@@ -0,0 +1,192 @@
+package apiService
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"go.uber.org/zap"
+)
+
+// jwtAlgorithms are the accepted HMAC signing algorithms by JWT alg header
+var jwtAlgorithms = map[string]func() hash.Hash{
+	"HS256": sha256.New,
+	"HS384": sha512.New384,
+	"HS512": sha512.New,
+}
+
+// jwtHeader is the JOSE header of a token
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+// JWTAuthenticator authenticates requests by an HMAC-signed JWT in the Authorization bearer header
+type JWTAuthenticator struct {
+	cfg      dto.JWTConfig
+	userRepo portsdb.User
+	logger   *zap.Logger
+}
+
+// NewJWTAuthenticator creates a new JWTAuthenticator; the config must have a secret of at least
+// dto.MinJWTSecretLength bytes, since anyone who guesses it can sign tokens for any user
+func NewJWTAuthenticator(
+	cfg dto.JWTConfig,
+	userRepo portsdb.User,
+	logger *zap.Logger,
+) (portsapi.Authenticator, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("auth.jwt.secret is required for the jwt authenticator")
+	}
+	if len(cfg.Secret) < dto.MinJWTSecretLength {
+		return nil, fmt.Errorf("auth.jwt.secret must be at least %d bytes", dto.MinJWTSecretLength)
+	}
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = dto.DefaultJWTUserClaim
+	}
+	return &JWTAuthenticator{
+		cfg:      cfg,
+		userRepo: userRepo,
+		logger:   logger,
+	}, nil
+}
+
+// Authenticate verifies the token's signature, exp, nbf, iss and aud and returns the user named by the
+// user claim, creating it on its first request. A scope claim limits the principal to the listed scopes.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*dto.Principal, error) {
+	authorization := r.Header.Get(dto.AuthorizationHeader)
+	if !strings.HasPrefix(authorization, dto.BearerPrefix) {
+		return nil, dto.ErrNoCredentials
+	}
+
+	claims, err := a.verify(strings.TrimSpace(strings.TrimPrefix(authorization, dto.BearerPrefix)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidCredentials, err)
+	}
+
+	userExternalID, _ := claims[a.cfg.UserClaim].(string)
+	if userExternalID == "" {
+		return nil, fmt.Errorf("%w: token has no %s claim", dto.ErrInvalidCredentials, a.cfg.UserClaim)
+	}
+	scopes, err := jwtScopes(claims)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidCredentials, err)
+	}
+	user, err := a.userRepo.GetOrCreate(ctx, userExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Principal{User: user, Scopes: scopes}, nil
+}
+
+// jwtScopes returns the scopes of the scope claim, given as a space-delimited string or an array of strings.
+// Without the claim the token is unrestricted (nil); a claim of any other form is an error, never unrestricted.
+func jwtScopes(claims map[string]interface{}) ([]string, error) {
+	claim, ok := claims[dto.JWTScopeClaim]
+	if !ok {
+		return nil, nil
+	}
+
+	scopes := []string{}
+	switch v := claim.(type) {
+	case string:
+		scopes = append(scopes, strings.Fields(v)...)
+	case []interface{}:
+		for _, s := range v {
+			scope, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s claim has a non-string scope", dto.JWTScopeClaim)
+			}
+			scopes = append(scopes, strings.Fields(scope)...)
+		}
+	default:
+		return nil, fmt.Errorf("%s claim must be a string or an array of strings", dto.JWTScopeClaim)
+	}
+	return scopes, nil
+}
+
+// verify checks the token's signature and registered claims and returns its claims
+func (a *JWTAuthenticator) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	newHash, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported alg %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	mac := hmac.New(newHash, []byte(a.cfg.Secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid signature")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid claims: %w", err)
+	}
+
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("token has no exp claim")
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(a.cfg.Leeway)) {
+		return nil, errors.New("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(a.cfg.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return nil, errors.New("token not valid yet")
+	}
+	if a.cfg.Issuer != "" && claims["iss"] != a.cfg.Issuer {
+		return nil, fmt.Errorf("issuer is not %q", a.cfg.Issuer)
+	}
+	if a.cfg.Audience != "" && !hasAudience(claims["aud"], a.cfg.Audience) {
+		return nil, fmt.Errorf("audience is not %q", a.cfg.Audience)
+	}
+	return claims, nil
+}
+
+// decodeJWTSegment decodes a base64url JSON segment of a token
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// hasAudience reports whether the aud claim (a string or an array of strings) contains audience
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if a == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
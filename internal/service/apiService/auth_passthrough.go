@@ -0,0 +1,43 @@
+package apiService
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"go.uber.org/zap"
+)
+
+// PassthroughAuthenticator trusts the X-User-ID header and creates unknown users.
+// It performs no verification and is meant for local development only.
+type PassthroughAuthenticator struct {
+	userRepo portsdb.User
+	logger   *zap.Logger
+}
+
+// NewPassthroughAuthenticator creates a new PassthroughAuthenticator with injected dependencies
+func NewPassthroughAuthenticator(
+	userRepo portsdb.User,
+	logger *zap.Logger,
+) portsapi.Authenticator {
+	return &PassthroughAuthenticator{
+		userRepo: userRepo,
+		logger:   logger,
+	}
+}
+
+// Authenticate returns the user named by the X-User-ID header, creating it if it does not exist.
+// The principal is unrestricted.
+func (a *PassthroughAuthenticator) Authenticate(ctx context.Context, r *http.Request) (*dto.Principal, error) {
+	userExternalID := r.Header.Get(dto.UserIDHeader)
+	if userExternalID == "" {
+		return nil, dto.ErrNoCredentials
+	}
+	user, err := a.userRepo.GetOrCreate(ctx, userExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.Principal{User: user}, nil
+}
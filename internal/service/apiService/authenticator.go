@@ -0,0 +1,64 @@
+package apiService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"go.uber.org/zap"
+)
+
+// AuthenticatorChain tries the configured authenticators in order; the first one that finds its
+// credentials in the request decides
+type AuthenticatorChain struct {
+	authenticators []portsapi.Authenticator
+}
+
+// NewAuthenticator builds the authenticator chain selected by the auth config
+func NewAuthenticator(
+	cfg dto.AuthConfig,
+	userRepo portsdb.User,
+	apiKeyRepo portsdb.APIKey,
+	logger *zap.Logger,
+) (portsapi.Authenticator, error) {
+	if len(cfg.Authenticators) == 0 {
+		return nil, errors.New("auth.authenticators must list at least one authenticator")
+	}
+
+	chain := &AuthenticatorChain{}
+	for _, name := range cfg.Authenticators {
+		switch name {
+		case dto.AuthenticatorAPIKey:
+			chain.authenticators = append(chain.authenticators, NewAPIKeyAuthenticator(apiKeyRepo, userRepo, logger))
+		case dto.AuthenticatorJWT:
+			jwt, err := NewJWTAuthenticator(cfg.JWT, userRepo, logger)
+			if err != nil {
+				return nil, err
+			}
+			chain.authenticators = append(chain.authenticators, jwt)
+		case dto.AuthenticatorPassthrough:
+			logger.Warn("Passthrough authenticator enabled: X-User-ID is trusted without verification, use only in development")
+			chain.authenticators = append(chain.authenticators, NewPassthroughAuthenticator(userRepo, logger))
+		default:
+			return nil, fmt.Errorf("unknown authenticator %q", name)
+		}
+	}
+	return chain, nil
+}
+
+// Authenticate returns the principal of the first authenticator that finds its credentials,
+// or dto.ErrNoCredentials if none does
+func (a *AuthenticatorChain) Authenticate(ctx context.Context, r *http.Request) (*dto.Principal, error) {
+	for _, authenticator := range a.authenticators {
+		principal, err := authenticator.Authenticate(ctx, r)
+		if errors.Is(err, dto.ErrNoCredentials) {
+			continue
+		}
+		return principal, err
+	}
+	return nil, dto.ErrNoCredentials
+}
@@ -0,0 +1,181 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DeadLetterService implements listing and replaying dead-lettered messages for the API
+type DeadLetterService struct {
+	repo                  portsdb.DeadLetter
+	deploymentRequestRepo portsdb.DeploymentRequest
+	publisher             portsqueue.DeadLetter
+	logger                *zap.Logger
+}
+
+// NewDeadLetterService creates a new DeadLetterService with injected dependencies
+func NewDeadLetterService(
+	repo portsdb.DeadLetter,
+	deploymentRequestRepo portsdb.DeploymentRequest,
+	publisher portsqueue.DeadLetter,
+	logger *zap.Logger,
+) portsapi.DeadLetter {
+	return &DeadLetterService{
+		repo:                  repo,
+		deploymentRequestRepo: deploymentRequestRepo,
+		publisher:             publisher,
+		logger:                logger,
+	}
+}
+
+// ListDeadLetters returns all dead-lettered messages owned by the given user
+func (s *DeadLetterService) ListDeadLetters(ctx context.Context, userID string) ([]*dto.DeadLetterResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	deadLetters, err := s.repo.ListByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead-lettered messages: %w", err)
+	}
+
+	result := make([]*dto.DeadLetterResponse, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		result = append(result, toDeadLetterResponse(d))
+	}
+	return result, nil
+}
+
+// ListAllDeadLetters returns every dead-lettered message, including those recorded without a
+// user_id header (e.g. watcher events), for callers holding the dead-letter admin scope
+func (s *DeadLetterService) ListAllDeadLetters(ctx context.Context) ([]*dto.DeadLetterResponse, error) {
+	deadLetters, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead-lettered messages: %w", err)
+	}
+
+	result := make([]*dto.DeadLetterResponse, 0, len(deadLetters))
+	for _, d := range deadLetters {
+		result = append(result, toDeadLetterResponse(d))
+	}
+	return result, nil
+}
+
+// ReplayDeadLetter republishes a dead-lettered message onto its original subject.
+// If the message belongs to a deployment request that was marked FAILURE, the request is
+// reset to CREATED first so the worker processes it again.
+func (s *DeadLetterService) ReplayDeadLetter(ctx context.Context, id string, userID string) (*dto.DeadLetterResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	d, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d.UserID == nil || *d.UserID != userUUID {
+		return nil, dto.ErrDeadLetterNotFound
+	}
+	return s.replay(ctx, d, userID)
+}
+
+// ReplayAnyDeadLetter replays a dead-lettered message regardless of its owner (dead-letter admin scope)
+func (s *DeadLetterService) ReplayAnyDeadLetter(ctx context.Context, id string, userID string) (*dto.DeadLetterResponse, error) {
+	d, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.replay(ctx, d, userID)
+}
+
+// getDeadLetter looks up a dead-lettered message by its ID string
+func (s *DeadLetterService) getDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	deadLetterID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, dto.ErrDeadLetterNotFound
+	}
+
+	d, found, err := s.repo.GetByID(ctx, deadLetterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead-lettered message: %w", err)
+	}
+	if !found {
+		return nil, dto.ErrDeadLetterNotFound
+	}
+	return d, nil
+}
+
+// replay resets the owning deployment request, republishes the message and marks it replayed
+func (s *DeadLetterService) replay(ctx context.Context, d *models.DeadLetter, userID string) (*dto.DeadLetterResponse, error) {
+	if d.RequestID != nil {
+		if err := s.resetDeploymentRequest(ctx, *d.RequestID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.publisher.Replay(d.OriginalSubject, d.Payload, utils.HeadersFromJSONB(d.Headers)); err != nil {
+		return nil, fmt.Errorf("failed to replay dead-lettered message: %w", err)
+	}
+
+	if err := s.repo.MarkReplayed(ctx, d.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark dead-lettered message as replayed: %w", err)
+	}
+
+	s.logger.Info("Dead-lettered message replayed",
+		zap.String("id", d.ID.String()),
+		zap.String("original_subject", d.OriginalSubject),
+		zap.String("user_id", userID),
+	)
+
+	d.Status = models.DeadLetterStatusReplayed
+	d.ReplayCount++
+	return toDeadLetterResponse(d), nil
+}
+
+// resetDeploymentRequest moves a FAILURE deployment request back to CREATED so the worker accepts the replay.
+func (s *DeadLetterService) resetDeploymentRequest(ctx context.Context, requestID string) error {
+	req, found, err := s.deploymentRequestRepo.GetByRequestID(ctx, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment request: %w", err)
+	}
+	if !found || req.Status != models.DeploymentRequestStatusFailure {
+		return nil
+	}
+	if err := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusCreated, nil); err != nil {
+		return fmt.Errorf("failed to reset deployment request status: %w", err)
+	}
+	return nil
+}
+
+func toDeadLetterResponse(d *models.DeadLetter) *dto.DeadLetterResponse {
+	updatedAt := ""
+	if d.UpdatedOn != nil {
+		updatedAt = d.UpdatedOn.Format(time.RFC3339)
+	}
+
+	return &dto.DeadLetterResponse{
+		ID:              d.ID,
+		OriginalSubject: d.OriginalSubject,
+		RequestID:       d.RequestID,
+		UserID:          d.UserID,
+		Status:          string(d.Status),
+		Attempts:        d.Attempts,
+		LastError:       d.LastError,
+		ReplayCount:     d.ReplayCount,
+		Payload:         string(d.Payload),
+		CreatedAt:       d.CreatedOn.Format(time.RFC3339),
+		UpdatedAt:       updatedAt,
+	}
+}
@@ -2,12 +2,15 @@ package apiService
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -15,30 +18,66 @@ import (
 // DeploymentService implements the deployment business logic for the API
 type DeploymentService struct {
 	deploymentRepo portsdb.Deployment
+	revisionRepo   portsdb.DeploymentRevision
+	policy         portsapi.Policy
 	logger         *zap.Logger
 }
 
 // NewDeploymentService creates a new DeploymentService with injected dependencies
 func NewDeploymentService(
 	deploymentRepo portsdb.Deployment,
+	revisionRepo portsdb.DeploymentRevision,
+	policy portsapi.Policy,
 	logger *zap.Logger,
 ) portsapi.Deployment {
 	return &DeploymentService{
 		deploymentRepo: deploymentRepo,
+		revisionRepo:   revisionRepo,
+		policy:         policy,
 		logger:         logger,
 	}
 }
 
-// ListDeployments returns all deployments for the given user
-func (s *DeploymentService) ListDeployments(ctx context.Context, userID string) ([]*dto.DeploymentListResponse, error) {
+// ListDeployments returns a page of the user's personal deployments and those of its teams matching the
+// query, with the cursor of the next page. A malformed cursor is dto.ErrInvalidCursor.
+func (s *DeploymentService) ListDeployments(ctx context.Context, userID string, query *dto.ListDeploymentsQuery) ([]*dto.DeploymentListResponse, string, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+		return nil, "", fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	filter := &dto.DeploymentFilter{
+		Status:        query.Status,
+		Namespace:     query.Namespace,
+		NamePrefix:    query.NamePrefix,
+		Image:         query.Image,
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+		Sort:          dto.ParseListSort(query.Sort),
+		Limit:         pageLimit(query.Limit) + 1,
+	}
+	if query.Cursor != "" {
+		if filter.After, err = utils.DecodeCursor(query.Cursor, filter.Sort); err != nil {
+			return nil, "", err
+		}
+	}
+
+	teamIDs, err := s.policy.VisibleTeamIDs(ctx, userUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list teams: %w", err)
 	}
 
-	deployments, err := s.deploymentRepo.ListByUserID(ctx, userUUID)
+	deployments, err := s.deploymentRepo.ListVisible(ctx, userUUID, teamIDs, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list deployments: %w", err)
+		return nil, "", fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	// One row past the page tells whether another page follows
+	nextCursor := ""
+	if len(deployments) == filter.Limit {
+		deployments = deployments[:len(deployments)-1]
+		last := deployments[len(deployments)-1]
+		nextCursor = utils.EncodeCursor(dto.NewPageCursor(filter.Sort, last.ID, last.CreatedOn, last.Name))
 	}
 
 	result := make([]*dto.DeploymentListResponse, 0, len(deployments))
@@ -55,13 +94,108 @@ func (s *DeploymentService) ListDeployments(ctx context.Context, userID string)
 			Status:     string(d.Status),
 			Name:       d.Name,
 			Namespace:  d.Namespace,
+			TeamID:     d.TeamID,
 		})
 	}
-	return result, nil
+	return result, nextCursor, nil
 }
 
-// GetDeployment returns the full deployment by identifier if it belongs to the user
+// GetDeployment returns the full deployment by identifier if the user can view it
 func (s *DeploymentService) GetDeployment(ctx context.Context, identifier string, userID string) (*dto.DeploymentResponse, error) {
+	d, err := s.getViewable(ctx, identifier, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := ""
+	if d.UpdatedOn != nil {
+		updatedAt = d.UpdatedOn.Format(time.RFC3339)
+	}
+
+	return &dto.DeploymentResponse{
+		ID:          d.ID,
+		Identifier:  d.Identifier,
+		Name:        d.Name,
+		Namespace:   d.Namespace,
+		Image:       d.Image,
+		Status:      string(d.Status),
+		CreatedAt:   d.CreatedOn.Format(time.RFC3339),
+		UpdatedAt:   updatedAt,
+		TeamID:      d.TeamID,
+		Autoscaling: toDeploymentAutoscalingResponse(d),
+		Metadata:    map[string]interface{}(d.Metadata),
+	}, nil
+}
+
+// toDeploymentAutoscalingResponse reports the deployment's autoscaler; nil when it is not autoscaled
+func toDeploymentAutoscalingResponse(d *models.Deployment) *dto.DeploymentAutoscalingResponse {
+	if d.AutoscalingMinReplicas == nil || d.AutoscalingMaxReplicas == nil {
+		return nil
+	}
+	resp := &dto.DeploymentAutoscalingResponse{
+		MinReplicas: *d.AutoscalingMinReplicas,
+		MaxReplicas: *d.AutoscalingMaxReplicas,
+	}
+	if d.AutoscalingDesiredReplicas != nil {
+		resp.DesiredReplicas = *d.AutoscalingDesiredReplicas
+	}
+	if d.AutoscalingCurrentReplicas != nil {
+		resp.CurrentReplicas = *d.AutoscalingCurrentReplicas
+	}
+	return resp
+}
+
+// ListDeploymentRevisions returns the recorded revisions of the deployment, newest first, if the user can view it
+func (s *DeploymentService) ListDeploymentRevisions(ctx context.Context, identifier string, userID string) ([]*dto.DeploymentRevisionResponse, error) {
+	if _, err := s.getViewable(ctx, identifier, userID); err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.revisionRepo.ListByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment revisions: %w", err)
+	}
+
+	result := make([]*dto.DeploymentRevisionResponse, 0, len(revisions))
+	for _, r := range revisions {
+		result = append(result, &dto.DeploymentRevisionResponse{
+			Revision:  r.Revision,
+			Image:     r.Image,
+			Replicas:  r.Replicas,
+			Resources: map[string]interface{}(r.Resources),
+			Spec:      map[string]interface{}(r.Spec),
+			RequestID: r.RequestID,
+			CreatedAt: r.CreatedOn.Format(time.RFC3339),
+		})
+	}
+	return result, nil
+}
+
+// DiffDeploymentRevisions returns the spec fields that changed from one revision of the deployment to another
+func (s *DeploymentService) DiffDeploymentRevisions(ctx context.Context, identifier string, from, to int64, userID string) (*dto.DeploymentRevisionDiffResponse, error) {
+	if _, err := s.getViewable(ctx, identifier, userID); err != nil {
+		return nil, err
+	}
+
+	fromRevision, err := s.getRevision(ctx, identifier, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.getRevision(ctx, identifier, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.DeploymentRevisionDiffResponse{
+		Identifier: identifier,
+		From:       from,
+		To:         to,
+		Changes:    utils.DiffJSON(fromRevision.Spec, toRevision.Spec),
+	}, nil
+}
+
+// getViewable returns the deployment, or dto.ErrDeploymentNotFound unless it exists and the user can view it
+func (s *DeploymentService) getViewable(ctx context.Context, identifier string, userID string) (*models.Deployment, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
@@ -71,24 +205,33 @@ func (s *DeploymentService) GetDeployment(ctx context.Context, identifier string
 	if err != nil {
 		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
-	if !found || d.UserID != userUUID {
+	if !found {
 		return nil, dto.ErrDeploymentNotFound
 	}
+	if err := authorizeDeployment(ctx, s.policy, userUUID, d, dto.ActionView); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
 
-	updatedAt := ""
-	if d.UpdatedOn != nil {
-		updatedAt = d.UpdatedOn.Format(time.RFC3339)
+// authorizeDeployment checks that the user may perform action on the deployment;
+// deployments the user cannot see are reported as dto.ErrDeploymentNotFound
+func authorizeDeployment(ctx context.Context, policy portsapi.Policy, userID uuid.UUID, d *models.Deployment, action dto.PolicyAction) error {
+	err := policy.Authorize(ctx, userID, dto.Ownership{UserID: d.UserID, TeamID: d.TeamID}, action)
+	if errors.Is(err, dto.ErrResourceNotVisible) {
+		return dto.ErrDeploymentNotFound
 	}
+	return err
+}
 
-	return &dto.DeploymentResponse{
-		ID:         d.ID,
-		Identifier: d.Identifier,
-		Name:       d.Name,
-		Namespace:  d.Namespace,
-		Image:      d.Image,
-		Status:     string(d.Status),
-		CreatedAt:  d.CreatedOn.Format(time.RFC3339),
-		UpdatedAt:  updatedAt,
-		Metadata:   map[string]interface{}(d.Metadata),
-	}, nil
+// getRevision returns one revision of the deployment or dto.ErrDeploymentRevisionNotFound
+func (s *DeploymentService) getRevision(ctx context.Context, identifier string, revision int64) (*models.DeploymentRevision, error) {
+	r, found, err := s.revisionRepo.GetByRevision(ctx, identifier, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment revision: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: revision %d", dto.ErrDeploymentRevisionNotFound, revision)
+	}
+	return r, nil
 }
@@ -2,16 +2,19 @@ package apiService
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	portstemplate "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/template"
 	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
 	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeploymentRequestService implements the deployment request business logic for the API
@@ -19,6 +22,10 @@ type DeploymentRequestService struct {
 	repo           portsdb.DeploymentRequest
 	deploymentRepo portsdb.Deployment
 	publisher      portsqueue.DeploymentRequest
+	statuses       portsqueue.DeploymentStatusSubscriber
+	templates      portstemplate.Registry
+	policy         portsapi.Policy
+	admission      portsapi.Admission
 	logger         *zap.Logger
 }
 
@@ -27,12 +34,20 @@ func NewDeploymentRequestService(
 	repo portsdb.DeploymentRequest,
 	deploymentRepo portsdb.Deployment,
 	publisher portsqueue.DeploymentRequest,
+	statuses portsqueue.DeploymentStatusSubscriber,
+	templates portstemplate.Registry,
+	policy portsapi.Policy,
+	admission portsapi.Admission,
 	logger *zap.Logger,
 ) portsapi.DeploymentRequest {
 	return &DeploymentRequestService{
 		repo:           repo,
 		deploymentRepo: deploymentRepo,
 		publisher:      publisher,
+		statuses:       statuses,
+		templates:      templates,
+		policy:         policy,
+		admission:      admission,
 		logger:         logger,
 	}
 }
@@ -71,6 +86,12 @@ func (s *DeploymentRequestService) CreateDeploymentRequest(
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
+	// A team-owned deployment needs a deployer or admin of the team
+	teamID, err := s.authorizeTeam(ctx, userUUID, req.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate identifier (collision chance is very low)
 	identifier, err := utils.GenerateDeploymentIdentifier(req.Name, req.Namespace)
 	if err != nil {
@@ -87,28 +108,59 @@ func (s *DeploymentRequestService) CreateDeploymentRequest(
 		Status:      models.DeploymentRequestStatusCreated,
 		Image:       req.Image,
 		UserID:      userUUID,
+		TeamID:      teamID,
 		Metadata: models.JSONB{
-			"replica_count":  req.Metadata.ReplicaCount,
+			"replica_count":  *req.Metadata.ReplicaCount,
 			"resource_limit": req.Metadata.ResourceLimit,
-			"doc_html":       req.Metadata.DocHTML,
 		},
-	}
-
-	// Save to database via repository
-	if err := s.repo.Create(ctx, deploymentRequest); err != nil {
-		return nil, fmt.Errorf("failed to create deployment request in database: %w", err)
-	}
-
-	// Publish to NATS for worker processing
-	if err := s.publisher.Publish(requestID, userID); err != nil {
-		s.logger.Error("Failed to publish deployment request to NATS",
-			zap.String("request_id", requestID),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to publish deployment request: %w", err)
-	}
-
-	s.logger.Info("Deployment request created and published",
+		ForceOwnership:        req.ForceOwnership,
+		WaitForRollout:        req.WaitForRollout,
+		RolloutTimeoutSeconds: rolloutTimeout(req.WaitForRollout, req.RolloutTimeoutSeconds),
+	}
+	if req.Metadata.DocHTML != "" {
+		deploymentRequest.Metadata["doc_html"] = req.Metadata.DocHTML
+	}
+	if req.Metadata.Strategy != nil {
+		if err := validateStrategy(req.Metadata.Strategy); err != nil {
+			return nil, err
+		}
+		// A new deployment has no pods to compare a canary with
+		if req.Metadata.Strategy.Type == dto.StrategyCanary {
+			return nil, fmt.Errorf("%w: %s is only supported for updates", dto.ErrInvalidStrategy, dto.StrategyCanary)
+		}
+		deploymentRequest.Metadata["strategy"] = req.Metadata.Strategy
+	}
+	if req.Metadata.Autoscaling != nil {
+		if err := validateAutoscaling(req.Metadata.Autoscaling); err != nil {
+			return nil, err
+		}
+		// A new deployment has no autoscaler to remove, and starts with replica_count pods
+		if req.Metadata.Autoscaling.Disabled {
+			return nil, fmt.Errorf("%w: disabled is only supported for updates", dto.ErrInvalidAutoscaling)
+		}
+		replicas := *req.Metadata.ReplicaCount
+		if replicas < req.Metadata.Autoscaling.MinReplicas || replicas > req.Metadata.Autoscaling.MaxReplicas {
+			return nil, fmt.Errorf("%w: replica_count must lie between min_replicas and max_replicas", dto.ErrInvalidAutoscaling)
+		}
+		deploymentRequest.Metadata["autoscaling"] = req.Metadata.Autoscaling
+	}
+
+	// The image must map to a template that accepts every metadata key set on the request
+	if err := s.validateTemplate(req.Image, deploymentRequest.Metadata); err != nil {
+		return nil, err
+	}
+
+	// Save to database together with the outbox message for worker processing, once the namespace is allowed
+	// for the owner and the deployment fits its quotas
+	owner := dto.Ownership{UserID: userUUID, TeamID: teamID}
+	admit := func(ctx context.Context) error {
+		return s.admission.AdmitCreate(ctx, owner, req.Namespace, &req.Metadata)
+	}
+	if err := s.enqueueAdmitted(ctx, owner, deploymentRequest, userID, admit); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Deployment request created and queued for publishing",
 		zap.String("request_id", requestID),
 		zap.String("identifier", identifier),
 		zap.String("name", req.Name),
@@ -124,20 +176,52 @@ func (s *DeploymentRequestService) CreateDeploymentRequest(
 		Image:       deploymentRequest.Image,
 		Status:      string(deploymentRequest.Status),
 		RequestType: string(deploymentRequest.RequestType),
+		TeamID:      deploymentRequest.TeamID,
 		Metadata:    map[string]interface{}(deploymentRequest.Metadata),
 	}, nil
 }
 
-// ListDeploymentRequests returns all deployment requests for the given user
-func (s *DeploymentRequestService) ListDeploymentRequests(ctx context.Context, userID string) ([]*dto.DeploymentRequestListResponse, error) {
+// ListDeploymentRequests returns a page of the requests of the user's personal deployments and those of its
+// teams matching the query, with the cursor of the next page. A malformed cursor is dto.ErrInvalidCursor.
+func (s *DeploymentRequestService) ListDeploymentRequests(ctx context.Context, userID string, query *dto.ListDeploymentRequestsQuery) ([]*dto.DeploymentRequestListResponse, string, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid user ID: %w", err)
+		return nil, "", fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	filter := &dto.DeploymentRequestFilter{
+		Status:        query.Status,
+		Namespace:     query.Namespace,
+		NamePrefix:    query.NamePrefix,
+		RequestType:   query.RequestType,
+		Image:         query.Image,
+		CreatedAfter:  query.CreatedAfter,
+		CreatedBefore: query.CreatedBefore,
+		Sort:          dto.ParseListSort(query.Sort),
+		Limit:         pageLimit(query.Limit) + 1,
+	}
+	if query.Cursor != "" {
+		if filter.After, err = utils.DecodeCursor(query.Cursor, filter.Sort); err != nil {
+			return nil, "", err
+		}
 	}
 
-	requests, err := s.repo.ListByUserID(ctx, userUUID)
+	teamIDs, err := s.policy.VisibleTeamIDs(ctx, userUUID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list deployment requests: %w", err)
+		return nil, "", fmt.Errorf("failed to list teams: %w", err)
+	}
+
+	requests, err := s.repo.ListVisible(ctx, userUUID, teamIDs, filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list deployment requests: %w", err)
+	}
+
+	// One row past the page tells whether another page follows
+	nextCursor := ""
+	if len(requests) == filter.Limit {
+		requests = requests[:len(requests)-1]
+		last := requests[len(requests)-1]
+		nextCursor = utils.EncodeCursor(dto.NewPageCursor(filter.Sort, last.ID, last.CreatedOn, last.Name))
 	}
 
 	result := make([]*dto.DeploymentRequestListResponse, 0, len(requests))
@@ -149,26 +233,26 @@ func (s *DeploymentRequestService) ListDeploymentRequests(ctx context.Context, u
 			Namespace:     r.Namespace,
 			Image:         r.Image,
 			Status:        string(r.Status),
+			Phase:         string(r.Phase),
 			RequestType:   string(r.RequestType),
+			TeamID:        r.TeamID,
+			ScheduleID:    r.ScheduleID,
 			FailureReason: r.FailureReason,
 		})
 	}
-	return result, nil
+	return result, nextCursor, nil
 }
 
-// GetDeploymentRequest returns the full deployment request by request_id if it belongs to the user
+// GetDeploymentRequest returns the full deployment request by request_id if the user can view it
 func (s *DeploymentRequestService) GetDeploymentRequest(ctx context.Context, requestID string, userID string) (*dto.DeploymentRequestResponse, error) {
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	r, found, err := s.repo.GetByRequestID(ctx, requestID)
+	r, err := s.viewableRequest(ctx, requestID, userUUID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get deployment request: %w", err)
-	}
-	if !found || r.UserID != userUUID {
-		return nil, dto.ErrDeploymentRequestNotFound
+		return nil, err
 	}
 
 	return &dto.DeploymentRequestResponse{
@@ -179,11 +263,34 @@ func (s *DeploymentRequestService) GetDeploymentRequest(ctx context.Context, req
 		Namespace:   r.Namespace,
 		Image:       r.Image,
 		Status:      string(r.Status),
+		Phase:       string(r.Phase),
 		RequestType: string(r.RequestType),
+		TeamID:      r.TeamID,
+		ScheduleID:  r.ScheduleID,
 		Metadata:    map[string]interface{}(r.Metadata),
 	}, nil
 }
 
+// viewableRequest returns the deployment request by request_id, or ErrDeploymentRequestNotFound when it
+// does not exist or the user cannot view it
+func (s *DeploymentRequestService) viewableRequest(ctx context.Context, requestID string, userID uuid.UUID) (*models.DeploymentRequest, error) {
+	r, found, err := s.repo.GetByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment request: %w", err)
+	}
+	if !found {
+		return nil, dto.ErrDeploymentRequestNotFound
+	}
+	err = s.policy.Authorize(ctx, userID, dto.Ownership{UserID: r.UserID, TeamID: r.TeamID}, dto.ActionView)
+	if errors.Is(err, dto.ErrResourceNotVisible) {
+		return nil, dto.ErrDeploymentRequestNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 // UpdateDeploymentRequest handles the business logic for updating a deployment request
 func (s *DeploymentRequestService) UpdateDeploymentRequest(
 	ctx context.Context,
@@ -213,9 +320,9 @@ func (s *DeploymentRequestService) UpdateDeploymentRequest(
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	// Check if deployment belongs to user
-	if deployment.UserID != userUUID {
-		return nil, dto.ErrDeploymentNotFound
+	// Check if the user may deploy to it (owner, or deployer/admin of the owning team)
+	if err := authorizeDeployment(ctx, s.policy, userUUID, deployment, dto.ActionDeploy); err != nil {
+		return nil, err
 	}
 
 	// Check if deployment is deleted
@@ -234,35 +341,80 @@ func (s *DeploymentRequestService) UpdateDeploymentRequest(
 	if req.DocHTML != nil {
 		metadata["doc_html"] = *req.DocHTML
 	}
+	canary := false
+	if req.Strategy != nil {
+		if err := validateStrategy(req.Strategy); err != nil {
+			return nil, err
+		}
+		// The canary runs the new pod template, so there must be a change to the pods to run
+		if canary = req.Strategy.Type == dto.StrategyCanary; canary {
+			if req.ResourceLimit == nil && (req.Image == nil || *req.Image == deployment.Image) {
+				return nil, fmt.Errorf("%w: %s needs an image or resource_limit change", dto.ErrInvalidStrategy, dto.StrategyCanary)
+			}
+			if req.Strategy.Canary.SoakSeconds == 0 {
+				req.Strategy.Canary.SoakSeconds = dto.DefaultCanarySoakSeconds
+			}
+		}
+		metadata["strategy"] = req.Strategy
+	}
+	if req.Autoscaling != nil {
+		if err := validateAutoscaling(req.Autoscaling); err != nil {
+			return nil, err
+		}
+		metadata["autoscaling"] = req.Autoscaling
+	}
+	// The replica count of an autoscaled deployment belongs to its autoscaler
+	autoscaled := deployment.AutoscalingMaxReplicas != nil
+	if req.Autoscaling != nil {
+		autoscaled = !req.Autoscaling.Disabled
+	}
+	if autoscaled && req.ReplicaCount != nil {
+		return nil, fmt.Errorf("%w: replica_count cannot be set while the deployment is autoscaled", dto.ErrInvalidAutoscaling)
+	}
+
+	// The deployment's template must accept every metadata key set on the request
+	if err := s.validateTemplate(deployment.Image, metadata); err != nil {
+		return nil, err
+	}
+
+	// A new image is rolled out by the worker and must belong to the deployment's template
+	image := deployment.Image
+	if req.Image != nil && *req.Image != deployment.Image {
+		if err := s.validateImageChange(deployment.Image, *req.Image); err != nil {
+			return nil, err
+		}
+		image = *req.Image
+		metadata["image"] = image
+	}
 
 	// Convert DTO to model
 	deploymentRequest := &models.DeploymentRequest{
-		RequestID:   requestID,
-		Identifier:  identifier,
-		Name:        deployment.Name,
-		Namespace:   deployment.Namespace,
-		RequestType: models.DeploymentRequestTypeUpdate,
-		Status:      models.DeploymentRequestStatusCreated,
-		Image:       deployment.Image,
-		UserID:      userUUID,
-		Metadata:    metadata,
-	}
-
-	// Save to database via repository
-	if err := s.repo.Create(ctx, deploymentRequest); err != nil {
-		return nil, fmt.Errorf("failed to create deployment request in database: %w", err)
-	}
-
-	// Publish to NATS for worker processing
-	if err := s.publisher.Publish(requestID, userID); err != nil {
-		s.logger.Error("Failed to publish deployment request to NATS",
-			zap.String("request_id", requestID),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to publish deployment request: %w", err)
-	}
-
-	s.logger.Info("Deployment request updated and published",
+		RequestID:             requestID,
+		Identifier:            identifier,
+		Name:                  deployment.Name,
+		Namespace:             deployment.Namespace,
+		RequestType:           models.DeploymentRequestTypeUpdate,
+		Status:                models.DeploymentRequestStatusCreated,
+		Image:                 image,
+		UserID:                userUUID,
+		TeamID:                deployment.TeamID,
+		Metadata:              metadata,
+		ForceOwnership:        req.ForceOwnership,
+		WaitForRollout:        req.WaitForRollout,
+		RolloutTimeoutSeconds: rolloutTimeout(req.WaitForRollout || canary, req.RolloutTimeoutSeconds),
+	}
+
+	// Save to database together with the outbox message for worker processing, once a larger replica count or resource
+	// limit fits the owner's quotas
+	owner := dto.Ownership{UserID: deployment.UserID, TeamID: deployment.TeamID}
+	admit := func(ctx context.Context) error {
+		return s.admission.AdmitUpdate(ctx, deployment, req)
+	}
+	if err := s.enqueueAdmitted(ctx, owner, deploymentRequest, userID, admit); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Deployment request updated and queued for publishing",
 		zap.String("request_id", requestID),
 		zap.String("identifier", identifier),
 	)
@@ -276,6 +428,7 @@ func (s *DeploymentRequestService) UpdateDeploymentRequest(
 		Image:       deploymentRequest.Image,
 		Status:      string(deploymentRequest.Status),
 		RequestType: string(deploymentRequest.RequestType),
+		TeamID:      deploymentRequest.TeamID,
 		Metadata:    map[string]interface{}(deploymentRequest.Metadata),
 	}, nil
 }
@@ -308,9 +461,9 @@ func (s *DeploymentRequestService) DeleteDeploymentRequest(
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	// Check if deployment belongs to user
-	if deployment.UserID != userUUID {
-		return nil, dto.ErrDeploymentNotFound
+	// Check if the user may deploy to it (owner, or deployer/admin of the owning team)
+	if err := authorizeDeployment(ctx, s.policy, userUUID, deployment, dto.ActionDeploy); err != nil {
+		return nil, err
 	}
 
 	// Check if deployment is deleted
@@ -328,26 +481,219 @@ func (s *DeploymentRequestService) DeleteDeploymentRequest(
 		Status:      models.DeploymentRequestStatusCreated,
 		Image:       deployment.Image,
 		UserID:      userUUID,
+		TeamID:      deployment.TeamID,
 		Metadata:    make(models.JSONB),
 	}
 
-	// Save to database via repository
-	if err := s.repo.Create(ctx, deploymentRequest); err != nil {
-		return nil, fmt.Errorf("failed to create deployment request in database: %w", err)
+	// Save to database together with the outbox message for worker processing
+	if err := s.enqueue(ctx, deploymentRequest, userID); err != nil {
+		return nil, err
 	}
 
-	// Publish to NATS for worker processing
-	if err := s.publisher.Publish(requestID, userID); err != nil {
-		s.logger.Error("Failed to publish deployment request to NATS",
-			zap.String("request_id", requestID),
-			zap.Error(err),
-		)
-		return nil, fmt.Errorf("failed to publish deployment request: %w", err)
+	s.logger.Info("Deployment request deleted and queued for publishing",
+		zap.String("request_id", requestID),
+		zap.String("identifier", identifier),
+	)
+
+	return &dto.DeploymentRequestResponse{
+		ID:          deploymentRequest.ID,
+		RequestID:   deploymentRequest.RequestID,
+		Identifier:  deploymentRequest.Identifier,
+		Name:        deploymentRequest.Name,
+		Namespace:   deploymentRequest.Namespace,
+		Image:       deploymentRequest.Image,
+		Status:      string(deploymentRequest.Status),
+		RequestType: string(deploymentRequest.RequestType),
+		TeamID:      deploymentRequest.TeamID,
+		Metadata:    map[string]interface{}(deploymentRequest.Metadata),
+	}, nil
+}
+
+// RollbackDeployment creates a ROLLBACK request that restores the replica count, resource limits, image and
+// doc_html the deployment had after the target request. The state is resolved now by replaying the metadata of the
+// deployment's successful requests up to the target, and stored as the request metadata.
+func (s *DeploymentRequestService) RollbackDeployment(
+	ctx context.Context,
+	identifier string,
+	req *dto.RollbackDeploymentRequest,
+	requestID string,
+	userID string,
+) (*dto.DeploymentRequestResponse, error) {
+	s.logger.Info("Rolling back deployment",
+		zap.String("request_id", requestID),
+		zap.String("identifier", identifier),
+		zap.String("target_request_id", req.TargetRequestID),
+		zap.String("user_id", userID),
+	)
+
+	deployment, found, err := s.deploymentRepo.GetByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing deployment: %w", err)
+	}
+	if !found {
+		return nil, dto.ErrDeploymentNotFound
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := authorizeDeployment(ctx, s.policy, userUUID, deployment, dto.ActionDeploy); err != nil {
+		return nil, err
+	}
+	if deployment.Status == models.DeploymentStatusDeleted {
+		return nil, fmt.Errorf("deployment with identifier '%s' is deleted", identifier)
+	}
+
+	successful, err := s.repo.ListSuccessfulByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment requests: %w", err)
+	}
+	metadata, err := rollbackState(successful, req.TargetRequestID)
+	if err != nil {
+		return nil, err
+	}
+	// A state without autoscaler removes the one added since, so its replica count applies again
+	if _, ok := metadata["autoscaling"]; !ok && deployment.AutoscalingMaxReplicas != nil {
+		metadata["autoscaling"] = map[string]interface{}{"disabled": true}
+	}
+
+	// Restoring another image rolls it out again, so it must still match the deployment's template
+	image := deployment.Image
+	if restoredImage, ok := metadata["image"].(string); ok && restoredImage != deployment.Image {
+		if err := s.validateImageChange(deployment.Image, restoredImage); err != nil {
+			return nil, err
+		}
+		image = restoredImage
+	} else {
+		delete(metadata, "image")
+	}
+
+	var restored dto.UpdateDeploymentRequestMetadata
+	if err := decodeMetadata(metadata, &restored); err != nil {
+		return nil, err
+	}
+
+	deploymentRequest := &models.DeploymentRequest{
+		RequestID:             requestID,
+		Identifier:            identifier,
+		Name:                  deployment.Name,
+		Namespace:             deployment.Namespace,
+		RequestType:           models.DeploymentRequestTypeRollback,
+		Status:                models.DeploymentRequestStatusCreated,
+		Image:                 image,
+		UserID:                userUUID,
+		TeamID:                deployment.TeamID,
+		Metadata:              metadata,
+		ForceOwnership:        req.ForceOwnership,
+		WaitForRollout:        req.WaitForRollout,
+		RolloutTimeoutSeconds: rolloutTimeout(req.WaitForRollout, req.RolloutTimeoutSeconds),
+	}
+
+	// Save to database together with the outbox message for worker processing, once the restored replica count and
+	// resource limits fit the owner's quotas
+	owner := dto.Ownership{UserID: deployment.UserID, TeamID: deployment.TeamID}
+	admit := func(ctx context.Context) error {
+		return s.admission.AdmitUpdate(ctx, deployment, &restored)
+	}
+	if err := s.enqueueAdmitted(ctx, owner, deploymentRequest, userID, admit); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Deployment rollback queued for publishing",
+		zap.String("request_id", requestID),
+		zap.String("identifier", identifier),
+		zap.String("target_request_id", req.TargetRequestID),
+	)
+
+	return &dto.DeploymentRequestResponse{
+		ID:          deploymentRequest.ID,
+		RequestID:   deploymentRequest.RequestID,
+		Identifier:  deploymentRequest.Identifier,
+		Name:        deploymentRequest.Name,
+		Namespace:   deploymentRequest.Namespace,
+		Image:       deploymentRequest.Image,
+		Status:      string(deploymentRequest.Status),
+		RequestType: string(deploymentRequest.RequestType),
+		TeamID:      deploymentRequest.TeamID,
+		Metadata:    map[string]interface{}(deploymentRequest.Metadata),
+	}, nil
+}
+
+// RestartDeployment creates a RESTART request that restarts the deployment's pods with a rolling update
+func (s *DeploymentRequestService) RestartDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error) {
+	return s.operateDeployment(ctx, identifier, models.DeploymentRequestTypeRestart, requestID, userID)
+}
+
+// PauseDeployment creates a PAUSE request that pauses the deployment's rollouts
+func (s *DeploymentRequestService) PauseDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error) {
+	return s.operateDeployment(ctx, identifier, models.DeploymentRequestTypePause, requestID, userID)
+}
+
+// ResumeDeployment creates a RESUME request that resumes the deployment's rollouts
+func (s *DeploymentRequestService) ResumeDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error) {
+	return s.operateDeployment(ctx, identifier, models.DeploymentRequestTypeResume, requestID, userID)
+}
+
+// operateDeployment creates a RESTART, PAUSE or RESUME request for a deployment the user may deploy to.
+// These requests carry no metadata; they are explicit operator actions, so the worker takes over the
+// fields they set from other field managers.
+func (s *DeploymentRequestService) operateDeployment(
+	ctx context.Context,
+	identifier string,
+	requestType models.DeploymentRequestType,
+	requestID string,
+	userID string,
+) (*dto.DeploymentRequestResponse, error) {
+	s.logger.Info("Creating deployment operation request",
+		zap.String("request_id", requestID),
+		zap.String("identifier", identifier),
+		zap.String("request_type", string(requestType)),
+		zap.String("user_id", userID),
+	)
+
+	deployment, found, err := s.deploymentRepo.GetByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing deployment: %w", err)
+	}
+	if !found {
+		return nil, dto.ErrDeploymentNotFound
+	}
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if err := authorizeDeployment(ctx, s.policy, userUUID, deployment, dto.ActionDeploy); err != nil {
+		return nil, err
+	}
+	if deployment.Status == models.DeploymentStatusDeleted {
+		return nil, fmt.Errorf("deployment with identifier '%s' is deleted", identifier)
+	}
+
+	deploymentRequest := &models.DeploymentRequest{
+		RequestID:      requestID,
+		Identifier:     identifier,
+		Name:           deployment.Name,
+		Namespace:      deployment.Namespace,
+		RequestType:    requestType,
+		Status:         models.DeploymentRequestStatusCreated,
+		Image:          deployment.Image,
+		UserID:         userUUID,
+		TeamID:         deployment.TeamID,
+		Metadata:       make(models.JSONB),
+		ForceOwnership: true,
+	}
+
+	// Save to database together with the outbox message for worker processing
+	if err := s.enqueue(ctx, deploymentRequest, userID); err != nil {
+		return nil, err
 	}
 
-	s.logger.Info("Deployment request deleted and published",
+	s.logger.Info("Deployment operation request queued for publishing",
 		zap.String("request_id", requestID),
 		zap.String("identifier", identifier),
+		zap.String("request_type", string(requestType)),
 	)
 
 	return &dto.DeploymentRequestResponse{
@@ -359,6 +705,197 @@ func (s *DeploymentRequestService) DeleteDeploymentRequest(
 		Image:       deploymentRequest.Image,
 		Status:      string(deploymentRequest.Status),
 		RequestType: string(deploymentRequest.RequestType),
+		TeamID:      deploymentRequest.TeamID,
 		Metadata:    map[string]interface{}(deploymentRequest.Metadata),
 	}, nil
 }
+
+// rollbackState replays the metadata of successful requests (oldest first) up to and including the target
+// and returns the resulting state as ROLLBACK request metadata
+func rollbackState(successful []*models.DeploymentRequest, targetRequestID string) (models.JSONB, error) {
+	state := models.JSONB{"target_request_id": targetRequestID}
+	for _, r := range successful {
+		switch r.RequestType {
+		case models.DeploymentRequestTypeCreate, models.DeploymentRequestTypeUpdate, models.DeploymentRequestTypeRollback:
+		default:
+			// Deletes and operations (restart, pause, resume) carry no state to restore
+			continue
+		}
+		for _, key := range []string{"replica_count", "resource_limit", "doc_html", "autoscaling"} {
+			if value, ok := r.Metadata[key]; ok {
+				state[key] = value
+			}
+		}
+		// Every request records the image the deployment runs once it is applied
+		state["image"] = r.Image
+		if r.RequestID == targetRequestID {
+			return state, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q is not a successful create, update or rollback request of this deployment",
+		dto.ErrInvalidRollbackTarget, targetRequestID)
+}
+
+// authorizeTeam parses the team a new deployment is created for and checks that the user may deploy to it.
+// It returns nil for a personal deployment and dto.ErrTeamNotFound if the user is not a member.
+func (s *DeploymentRequestService) authorizeTeam(ctx context.Context, userID uuid.UUID, team string) (*uuid.UUID, error) {
+	if team == "" {
+		return nil, nil
+	}
+	teamID, err := uuid.Parse(team)
+	if err != nil {
+		return nil, dto.ErrTeamNotFound
+	}
+
+	err = s.policy.Authorize(ctx, userID, dto.Ownership{UserID: userID, TeamID: &teamID}, dto.ActionDeploy)
+	if errors.Is(err, dto.ErrResourceNotVisible) {
+		return nil, dto.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &teamID, nil
+}
+
+// validateTemplate resolves the template for image and checks that it accepts every key in metadata
+func (s *DeploymentRequestService) validateTemplate(image string, metadata models.JSONB) error {
+	tmpl, ok := s.templates.Match(image)
+	if !ok {
+		return fmt.Errorf("%w: %q", dto.ErrUnsupportedImage, image)
+	}
+	for key := range metadata {
+		if !tmpl.AllowsMetadataKey(key) {
+			return fmt.Errorf("%w: %q is not accepted by template %q", dto.ErrMetadataKeyNotAllowed, key, tmpl.Name)
+		}
+	}
+	return nil
+}
+
+// validateImageChange checks that a new image matches the template of the deployment's current image, so an
+// image update never changes the objects the template created
+func (s *DeploymentRequestService) validateImageChange(current, next string) error {
+	tmpl, ok := s.templates.Match(next)
+	if !ok {
+		return fmt.Errorf("%w: %q", dto.ErrUnsupportedImage, next)
+	}
+	currentTmpl, ok := s.templates.Match(current)
+	if !ok {
+		return fmt.Errorf("%w: %q", dto.ErrUnsupportedImage, current)
+	}
+	if tmpl.Name != currentTmpl.Name {
+		return fmt.Errorf("%w: %q matches template %q, the deployment uses %q",
+			dto.ErrImageTemplateMismatch, next, tmpl.Name, currentTmpl.Name)
+	}
+	return nil
+}
+
+// validateStrategy checks what the validator tags of a rollout strategy cannot: max_surge and max_unavailable
+// are only accepted for RollingUpdate as a number or percentage of pods, not both zero, and canary settings
+// go with the Canary type only
+func validateStrategy(strategy *dto.RolloutStrategy) error {
+	if (strategy.Type == dto.StrategyCanary) != (strategy.Canary != nil) {
+		return fmt.Errorf("%w: canary settings are required for and only accepted with type %s", dto.ErrInvalidStrategy, dto.StrategyCanary)
+	}
+	if strategy.Type != dto.StrategyRollingUpdate {
+		if strategy.MaxSurge != "" || strategy.MaxUnavailable != "" {
+			return fmt.Errorf("%w: max_surge and max_unavailable only apply to type %s", dto.ErrInvalidStrategy, dto.StrategyRollingUpdate)
+		}
+		return nil
+	}
+
+	surge, err := rollingUpdateValue("max_surge", strategy.MaxSurge)
+	if err != nil {
+		return err
+	}
+	unavailable, err := rollingUpdateValue("max_unavailable", strategy.MaxUnavailable)
+	if err != nil {
+		return err
+	}
+	if surge == 0 && unavailable == 0 {
+		return fmt.Errorf("%w: max_surge and max_unavailable cannot both be 0", dto.ErrInvalidStrategy)
+	}
+	return nil
+}
+
+// validateAutoscaling checks what the validator tags of autoscaling settings cannot: enabled settings need
+// min_replicas and max_replicas in order and at least one utilization target
+func validateAutoscaling(autoscaling *dto.AutoscalingSettings) error {
+	if autoscaling.Disabled {
+		return nil
+	}
+	if autoscaling.MinReplicas == 0 || autoscaling.MaxReplicas == 0 {
+		return fmt.Errorf("%w: min_replicas and max_replicas are required", dto.ErrInvalidAutoscaling)
+	}
+	if autoscaling.MinReplicas > autoscaling.MaxReplicas {
+		return fmt.Errorf("%w: min_replicas cannot exceed max_replicas", dto.ErrInvalidAutoscaling)
+	}
+	if autoscaling.TargetCPUUtilization == 0 && autoscaling.TargetMemoryUtilization == 0 {
+		return fmt.Errorf("%w: target_cpu_utilization or target_memory_utilization is required", dto.ErrInvalidAutoscaling)
+	}
+	return nil
+}
+
+// rollingUpdateValue parses a max_surge or max_unavailable value, returning it scaled to 100 pods;
+// an empty value is the Kubernetes default of 25%
+func rollingUpdateValue(name, value string) (int, error) {
+	if value == "" {
+		return 25, nil
+	}
+	parsed := intstr.Parse(value)
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(&parsed, 100, true)
+	if err != nil || scaled < 0 {
+		return 0, fmt.Errorf("%w: %s %q is not a number or percentage of pods", dto.ErrInvalidStrategy, name, value)
+	}
+	return scaled, nil
+}
+
+// rolloutTimeout returns the rollout timeout to store on a request: the default when waiting without one, zero when not waiting
+func rolloutTimeout(waitForRollout bool, timeoutSeconds int) int {
+	if !waitForRollout {
+		return 0
+	}
+	if timeoutSeconds <= 0 {
+		return dto.DefaultRolloutTimeoutSeconds
+	}
+	return timeoutSeconds
+}
+
+// enqueueAdmitted is enqueue serialized with the owner's other requests: admit runs under the owner's lock
+// and its error is returned as is, so admission violations keep their type
+func (s *DeploymentRequestService) enqueueAdmitted(
+	ctx context.Context,
+	owner dto.Ownership,
+	deploymentRequest *models.DeploymentRequest,
+	userID string,
+	admit func(ctx context.Context) error,
+) error {
+	msg, err := s.publisher.Message(deploymentRequest.RequestID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to build deployment request message: %w", err)
+	}
+	var admitErr error
+	err = s.repo.CreateWithOutboxAdmitted(ctx, owner, deploymentRequest, msg, func(ctx context.Context) error {
+		admitErr = admit(ctx)
+		return admitErr
+	})
+	if admitErr != nil {
+		return admitErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create deployment request in database: %w", err)
+	}
+	return nil
+}
+
+// enqueue stores the deployment request and its NATS message in the outbox in one transaction.
+// The outbox relay publishes the message, so a NATS outage delays processing instead of failing the request.
+func (s *DeploymentRequestService) enqueue(ctx context.Context, deploymentRequest *models.DeploymentRequest, userID string) error {
+	msg, err := s.publisher.Message(deploymentRequest.RequestID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to build deployment request message: %w", err)
+	}
+	if err := s.repo.CreateWithOutbox(ctx, deploymentRequest, msg); err != nil {
+		return fmt.Errorf("failed to create deployment request in database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// statusEventBuffer is how many status events of one stream are buffered before further events are dropped
+// (the periodic resync then catches up)
+const statusEventBuffer = 8
+
+// WatchDeploymentRequest streams the status of a deployment request the user can view: the current status
+// first, then every transition (including canary phases) until the request is SUCCESS or FAILURE or ctx is done. The channel is
+// closed when the stream ends.
+func (s *DeploymentRequestService) WatchDeploymentRequest(ctx context.Context, requestID string, userID string) (<-chan *dto.DeploymentStatusEvent, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	// Requests are only created with IDs that are a single subject token; anything else (e.g. ">") would
+	// subscribe to the status events of other requests
+	if !utils.ValidRequestID(requestID) {
+		return nil, dto.ErrDeploymentRequestNotFound
+	}
+
+	// Subscribe before reading the current status, so a transition in between is not missed
+	published := make(chan *dto.DeploymentStatusEvent, statusEventBuffer)
+	unsubscribe, err := s.statuses.Subscribe(requestID, func(event *dto.DeploymentStatusEvent) {
+		select {
+		case published <- event:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to status events: %w", err)
+	}
+
+	r, err := s.viewableRequest(ctx, requestID, userUUID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	events := make(chan *dto.DeploymentStatusEvent)
+	go s.streamStatus(ctx, r, published, events, unsubscribe)
+	return events, nil
+}
+
+// streamStatus sends the status of r and its transitions to events, from the published status events and
+// from periodic re-reads of the request. Only changes of status or phase are sent; published events of
+// other requests are dropped.
+func (s *DeploymentRequestService) streamStatus(
+	ctx context.Context,
+	r *models.DeploymentRequest,
+	published <-chan *dto.DeploymentStatusEvent,
+	events chan<- *dto.DeploymentStatusEvent,
+	unsubscribe func(),
+) {
+	defer close(events)
+	defer unsubscribe()
+
+	last := requestStatusEvent(r)
+	if !sendStatus(ctx, events, last) || models.DeploymentRequestStatus(last.Status).IsTerminal() {
+		return
+	}
+
+	resync := time.NewTicker(dto.StatusStreamResyncInterval)
+	defer resync.Stop()
+
+	for {
+		var next *dto.DeploymentStatusEvent
+		select {
+		case <-ctx.Done():
+			return
+		case next = <-published:
+			// Only the events of this request may reach its stream
+			if next.RequestID != r.RequestID {
+				continue
+			}
+		case <-resync.C:
+			current, found, err := s.repo.GetByRequestID(ctx, r.RequestID)
+			if err != nil {
+				s.logger.Warn("Failed to resync deployment request status",
+					zap.String("request_id", r.RequestID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if !found {
+				return
+			}
+			next = requestStatusEvent(current)
+		}
+
+		if next.Status == last.Status && next.Phase == last.Phase {
+			continue
+		}
+		last = next
+		if !sendStatus(ctx, events, last) || models.DeploymentRequestStatus(last.Status).IsTerminal() {
+			return
+		}
+	}
+}
+
+// requestStatusEvent builds the status event of the request's current status
+func requestStatusEvent(r *models.DeploymentRequest) *dto.DeploymentStatusEvent {
+	timestamp := time.Now().UTC()
+	if r.UpdatedOn != nil {
+		timestamp = r.UpdatedOn.UTC()
+	}
+	return &dto.DeploymentStatusEvent{
+		RequestID:     r.RequestID,
+		Status:        string(r.Status),
+		Phase:         string(r.Phase),
+		FailureReason: r.FailureReason,
+		Timestamp:     timestamp,
+	}
+}
+
+// sendStatus sends event unless ctx is done first
+func sendStatus(ctx context.Context, events chan<- *dto.DeploymentStatusEvent, event *dto.DeploymentStatusEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,207 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portstemplate "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/template"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DeploymentScheduleService implements creating, listing and deleting the scaling schedules of deployments
+// for the API. The worker's scheduler fires them.
+type DeploymentScheduleService struct {
+	repo           portsdb.DeploymentSchedule
+	deploymentRepo portsdb.Deployment
+	templates      portstemplate.Registry
+	policy         portsapi.Policy
+	admission      portsapi.Admission
+	logger         *zap.Logger
+}
+
+// NewDeploymentScheduleService creates a new DeploymentScheduleService with injected dependencies
+func NewDeploymentScheduleService(
+	repo portsdb.DeploymentSchedule,
+	deploymentRepo portsdb.Deployment,
+	templates portstemplate.Registry,
+	policy portsapi.Policy,
+	admission portsapi.Admission,
+	logger *zap.Logger,
+) portsapi.DeploymentSchedule {
+	return &DeploymentScheduleService{
+		repo:           repo,
+		deploymentRepo: deploymentRepo,
+		templates:      templates,
+		policy:         policy,
+		admission:      admission,
+		logger:         logger,
+	}
+}
+
+// CreateDeploymentSchedule adds a scaling schedule to a deployment the user may deploy to. The schedule's
+// runs are emitted on behalf of the user. The cron expression and timezone must parse and match some time
+// (dto.ErrInvalidSchedule), the deployment's template must accept replica_count, and scaling to the
+// schedule's replica count must fit the owner's quotas now.
+func (s *DeploymentScheduleService) CreateDeploymentSchedule(
+	ctx context.Context,
+	identifier string,
+	userID string,
+	req *dto.CreateDeploymentScheduleRequest,
+) (*dto.DeploymentScheduleResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	deployment, err := s.deployment(ctx, identifier, userUUID, dto.ActionDeploy)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Status == models.DeploymentStatusDeleted {
+		return nil, fmt.Errorf("deployment with identifier '%s' is deleted", identifier)
+	}
+	// Its runs would set a replica count the autoscaler owns
+	if deployment.AutoscalingMaxReplicas != nil {
+		return nil, fmt.Errorf("%w: an autoscaled deployment cannot have scaling schedules", dto.ErrInvalidAutoscaling)
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = dto.DefaultScheduleTimezone
+	}
+	nextRunAt, err := utils.NextCronRun(req.Cron, timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, ok := s.templates.Match(deployment.Image)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", dto.ErrUnsupportedImage, deployment.Image)
+	}
+	if !tmpl.AllowsMetadataKey("replica_count") {
+		return nil, fmt.Errorf("%w: %q is not accepted by template %q", dto.ErrMetadataKeyNotAllowed, "replica_count", tmpl.Name)
+	}
+	if err := s.admission.AdmitUpdate(ctx, deployment, &dto.UpdateDeploymentRequestMetadata{ReplicaCount: req.ReplicaCount}); err != nil {
+		return nil, err
+	}
+
+	schedule := &models.DeploymentSchedule{
+		Identifier:   identifier,
+		UserID:       userUUID,
+		Cron:         req.Cron,
+		Timezone:     timezone,
+		ReplicaCount: *req.ReplicaCount,
+		NextRunAt:    nextRunAt,
+	}
+	if err := s.repo.Create(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Deployment schedule created",
+		zap.String("user_id", userID),
+		zap.String("identifier", identifier),
+		zap.String("schedule_id", schedule.ID.String()),
+		zap.String("cron", schedule.Cron),
+		zap.Time("next_run_at", nextRunAt),
+	)
+	return toDeploymentScheduleResponse(schedule), nil
+}
+
+// ListDeploymentSchedules returns the scaling schedules of a deployment visible to the user, oldest first
+func (s *DeploymentScheduleService) ListDeploymentSchedules(ctx context.Context, identifier string, userID string) ([]*dto.DeploymentScheduleResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	if _, err := s.deployment(ctx, identifier, userUUID, dto.ActionView); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.ListByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*dto.DeploymentScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, toDeploymentScheduleResponse(schedule))
+	}
+	return result, nil
+}
+
+// DeleteDeploymentSchedule removes a scaling schedule from a deployment the user may deploy to; the
+// requests it emitted are kept
+func (s *DeploymentScheduleService) DeleteDeploymentSchedule(ctx context.Context, identifier string, scheduleID string, userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	if _, err := s.deployment(ctx, identifier, userUUID, dto.ActionDeploy); err != nil {
+		return err
+	}
+
+	scheduleUUID, err := uuid.Parse(scheduleID)
+	if err != nil {
+		return dto.ErrDeploymentScheduleNotFound
+	}
+	schedule, found, err := s.repo.GetByID(ctx, scheduleUUID)
+	if err != nil {
+		return err
+	}
+	if !found || schedule.Identifier != identifier {
+		return dto.ErrDeploymentScheduleNotFound
+	}
+
+	if err := s.repo.Delete(ctx, schedule.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Deployment schedule deleted",
+		zap.String("user_id", userID),
+		zap.String("identifier", identifier),
+		zap.String("schedule_id", scheduleID),
+	)
+	return nil
+}
+
+// deployment returns the deployment if the user may perform action on it; otherwise dto.ErrDeploymentNotFound
+// or the policy's error
+func (s *DeploymentScheduleService) deployment(ctx context.Context, identifier string, userID uuid.UUID, action dto.PolicyAction) (*models.Deployment, error) {
+	deployment, found, err := s.deploymentRepo.GetByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if !found {
+		return nil, dto.ErrDeploymentNotFound
+	}
+	if err := authorizeDeployment(ctx, s.policy, userID, deployment, action); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// toDeploymentScheduleResponse maps a schedule to its response; timestamps are RFC3339, empty when unset
+func toDeploymentScheduleResponse(schedule *models.DeploymentSchedule) *dto.DeploymentScheduleResponse {
+	resp := &dto.DeploymentScheduleResponse{
+		ID:           schedule.ID,
+		Identifier:   schedule.Identifier,
+		Cron:         schedule.Cron,
+		Timezone:     schedule.Timezone,
+		ReplicaCount: schedule.ReplicaCount,
+		UserID:       schedule.UserID,
+		NextRunAt:    schedule.NextRunAt.Format(time.RFC3339),
+		CreatedAt:    schedule.CreatedOn.Format(time.RFC3339),
+	}
+	if schedule.LastRunAt != nil {
+		resp.LastRunAt = schedule.LastRunAt.Format(time.RFC3339)
+	}
+	if schedule.LastRequestID != nil {
+		resp.LastRequestID = *schedule.LastRequestID
+	}
+	return resp
+}
@@ -0,0 +1,14 @@
+package apiService
+
+import "github.com/code-xd/k8s-deployment-manager/pkg/dto"
+
+// pageLimit returns the requested page size, or the default when unset, capped at dto.MaxPageLimit
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return dto.DefaultPageLimit
+	}
+	if limit > dto.MaxPageLimit {
+		return dto.MaxPageLimit
+	}
+	return limit
+}
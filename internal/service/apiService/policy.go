@@ -0,0 +1,78 @@
+package apiService
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/google/uuid"
+)
+
+// roleRank orders team roles; a role allows everything the lower ones do
+var roleRank = map[models.TeamRole]int{
+	models.TeamRoleViewer:   1,
+	models.TeamRoleDeployer: 2,
+	models.TeamRoleAdmin:    3,
+}
+
+// actionRole is the lowest team role allowed to perform each action
+var actionRole = map[dto.PolicyAction]models.TeamRole{
+	dto.ActionView:   models.TeamRoleViewer,
+	dto.ActionDeploy: models.TeamRoleDeployer,
+	dto.ActionManage: models.TeamRoleAdmin,
+}
+
+// TeamPolicy authorizes by team membership role; personal resources (no team) are only accessible to their creator
+type TeamPolicy struct {
+	teamRepo portsdb.Team
+}
+
+// NewTeamPolicy creates a new TeamPolicy with injected dependencies
+func NewTeamPolicy(teamRepo portsdb.Team) portsapi.Policy {
+	return &TeamPolicy{
+		teamRepo: teamRepo,
+	}
+}
+
+// Authorize checks the user's role in the owning team against the lowest role allowed to perform action
+func (p *TeamPolicy) Authorize(ctx context.Context, userID uuid.UUID, owner dto.Ownership, action dto.PolicyAction) error {
+	if owner.TeamID == nil {
+		if owner.UserID != userID {
+			return dto.ErrResourceNotVisible
+		}
+		return nil
+	}
+
+	membership, found, err := p.teamRepo.GetMembership(ctx, *owner.TeamID, userID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return dto.ErrResourceNotVisible
+	}
+
+	required, ok := actionRole[action]
+	if !ok {
+		return fmt.Errorf("unknown policy action %q", action)
+	}
+	if roleRank[membership.Role] < roleRank[required] {
+		return fmt.Errorf("%w: %s requires %s, user is %s", dto.ErrInsufficientTeamRole, action, required, membership.Role)
+	}
+	return nil
+}
+
+// VisibleTeamIDs returns the teams the user is a member of, with any role
+func (p *TeamPolicy) VisibleTeamIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	memberships, err := p.teamRepo.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	teamIDs := make([]uuid.UUID, 0, len(memberships))
+	for _, m := range memberships {
+		teamIDs = append(teamIDs, m.TeamID)
+	}
+	return teamIDs, nil
+}
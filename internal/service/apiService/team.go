@@ -0,0 +1,260 @@
+package apiService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TeamService implements team and membership management for the API
+type TeamService struct {
+	teamRepo portsdb.Team
+	userRepo portsdb.User
+	policy   portsapi.Policy
+	logger   *zap.Logger
+}
+
+// NewTeamService creates a new TeamService with injected dependencies
+func NewTeamService(
+	teamRepo portsdb.Team,
+	userRepo portsdb.User,
+	policy portsapi.Policy,
+	logger *zap.Logger,
+) portsapi.Team {
+	return &TeamService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		policy:   policy,
+		logger:   logger,
+	}
+}
+
+// CreateTeam creates a team with the user as its first admin
+func (s *TeamService) CreateTeam(ctx context.Context, req *dto.CreateTeamRequest, userID string) (*dto.TeamResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	_, found, err := s.teamRepo.GetByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		return nil, fmt.Errorf("%w: %q", dto.ErrTeamNameTaken, req.Name)
+	}
+
+	team := &models.Team{Name: req.Name}
+	if err := s.teamRepo.CreateWithAdmin(ctx, team, userUUID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Team created", zap.String("team_id", team.ID.String()), zap.String("name", team.Name), zap.String("user_id", userID))
+	return toTeamResponse(team, models.TeamRoleAdmin), nil
+}
+
+// ListTeams returns the teams the user is a member of, with its role in each
+func (s *TeamService) ListTeams(ctx context.Context, userID string) ([]*dto.TeamResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	memberships, err := s.teamRepo.ListMembershipsByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[uuid.UUID]models.TeamRole, len(memberships))
+	teamIDs := make([]uuid.UUID, 0, len(memberships))
+	for _, m := range memberships {
+		roles[m.TeamID] = m.Role
+		teamIDs = append(teamIDs, m.TeamID)
+	}
+
+	teams, err := s.teamRepo.ListByIDs(ctx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.TeamResponse, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, toTeamResponse(t, roles[t.ID]))
+	}
+	return result, nil
+}
+
+// ListTeamMembers returns the members of a team the user belongs to
+func (s *TeamService) ListTeamMembers(ctx context.Context, teamID string, userID string) ([]*dto.TeamMemberResponse, error) {
+	teamUUID, err := s.authorize(ctx, teamID, userID, dto.ActionView)
+	if err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.teamRepo.ListMembers(ctx, teamUUID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]uuid.UUID, 0, len(memberships))
+	for _, m := range memberships {
+		userIDs = append(userIDs, m.UserID)
+	}
+	users, err := s.userRepo.ListByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	externalIDs := make(map[uuid.UUID]string, len(users))
+	for _, u := range users {
+		externalIDs[u.ID] = u.UserExternalID
+	}
+
+	result := make([]*dto.TeamMemberResponse, 0, len(memberships))
+	for _, m := range memberships {
+		result = append(result, toTeamMemberResponse(m, externalIDs[m.UserID]))
+	}
+	return result, nil
+}
+
+// SetTeamMember adds a user to the team or changes its role; only team admins may do so.
+// Users not seen yet are created, so members can be added before their first request.
+func (s *TeamService) SetTeamMember(ctx context.Context, teamID string, req *dto.SetTeamMemberRequest, userID string) (*dto.TeamMemberResponse, error) {
+	teamUUID, err := s.authorize(ctx, teamID, userID, dto.ActionManage)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.userRepo.GetOrCreate(ctx, req.UserExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	role := models.TeamRole(req.Role)
+	if role != models.TeamRoleAdmin {
+		if err := s.keepAdmin(ctx, teamUUID, member.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	membership := &models.TeamMembership{
+		TeamID: teamUUID,
+		UserID: member.ID,
+		Role:   role,
+	}
+	if err := s.teamRepo.UpsertMember(ctx, membership); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Team member set",
+		zap.String("team_id", teamID),
+		zap.String("member", req.UserExternalID),
+		zap.String("role", req.Role),
+		zap.String("user_id", userID),
+	)
+
+	membership, _, err = s.teamRepo.GetMembership(ctx, teamUUID, member.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toTeamMemberResponse(membership, member.UserExternalID), nil
+}
+
+// RemoveTeamMember removes a user from the team; only team admins may do so
+func (s *TeamService) RemoveTeamMember(ctx context.Context, teamID string, memberExternalID string, userID string) error {
+	teamUUID, err := s.authorize(ctx, teamID, userID, dto.ActionManage)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.userRepo.GetByExternalID(ctx, memberExternalID)
+	if err != nil {
+		return dto.ErrTeamMemberNotFound
+	}
+	if err := s.keepAdmin(ctx, teamUUID, member.ID); err != nil {
+		return err
+	}
+
+	removed, err := s.teamRepo.RemoveMember(ctx, teamUUID, member.ID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return dto.ErrTeamMemberNotFound
+	}
+
+	s.logger.Info("Team member removed",
+		zap.String("team_id", teamID),
+		zap.String("member", memberExternalID),
+		zap.String("user_id", userID),
+	)
+	return nil
+}
+
+// authorize parses the team ID and checks that the user may perform action on the team;
+// teams the user is not a member of are reported as dto.ErrTeamNotFound
+func (s *TeamService) authorize(ctx context.Context, teamID string, userID string, action dto.PolicyAction) (uuid.UUID, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	teamUUID, err := uuid.Parse(teamID)
+	if err != nil {
+		return uuid.Nil, dto.ErrTeamNotFound
+	}
+
+	err = s.policy.Authorize(ctx, userUUID, dto.Ownership{TeamID: &teamUUID}, action)
+	if errors.Is(err, dto.ErrResourceNotVisible) {
+		return uuid.Nil, dto.ErrTeamNotFound
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return teamUUID, nil
+}
+
+// keepAdmin returns dto.ErrLastTeamAdmin if the user is the team's only admin,
+// so demoting or removing it would leave the team without one
+func (s *TeamService) keepAdmin(ctx context.Context, teamID, userID uuid.UUID) error {
+	membership, found, err := s.teamRepo.GetMembership(ctx, teamID, userID)
+	if err != nil {
+		return err
+	}
+	if !found || membership.Role != models.TeamRoleAdmin {
+		return nil
+	}
+
+	admins, err := s.teamRepo.CountAdmins(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return dto.ErrLastTeamAdmin
+	}
+	return nil
+}
+
+// toTeamResponse maps a team and the caller's role in it to its response
+func toTeamResponse(t *models.Team, role models.TeamRole) *dto.TeamResponse {
+	return &dto.TeamResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Role:      string(role),
+		CreatedAt: t.CreatedOn.Format(time.RFC3339),
+	}
+}
+
+// toTeamMemberResponse maps a membership to its response
+func toTeamMemberResponse(m *models.TeamMembership, userExternalID string) *dto.TeamMemberResponse {
+	return &dto.TeamMemberResponse{
+		UserID:         m.UserID,
+		UserExternalID: userExternalID,
+		Role:           string(m.Role),
+		CreatedAt:      m.CreatedOn.Format(time.RFC3339),
+	}
+}
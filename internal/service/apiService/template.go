@@ -0,0 +1,32 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portstemplate "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/template"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"go.uber.org/zap"
+)
+
+// TemplateService implements listing the deployment templates for the API
+type TemplateService struct {
+	registry portstemplate.Registry
+	logger   *zap.Logger
+}
+
+// NewTemplateService creates a new TemplateService with injected dependencies
+func NewTemplateService(
+	registry portstemplate.Registry,
+	logger *zap.Logger,
+) portsapi.Template {
+	return &TemplateService{
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// ListTemplates returns all templates with their image patterns, port, content mount path and accepted metadata keys
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]*dto.TemplateManifest, error) {
+	return s.registry.List(), nil
+}
@@ -0,0 +1,264 @@
+package apiService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsapi "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/apiService"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// WebhookService implements creating, listing and deleting webhooks and inspecting their deliveries for the API
+type WebhookService struct {
+	repo   portsdb.Webhook
+	policy portsapi.Policy
+	logger *zap.Logger
+}
+
+// NewWebhookService creates a new WebhookService with injected dependencies
+func NewWebhookService(
+	repo portsdb.Webhook,
+	policy portsapi.Policy,
+	logger *zap.Logger,
+) portsapi.Webhook {
+	return &WebhookService{
+		repo:   repo,
+		policy: policy,
+		logger: logger,
+	}
+}
+
+// CreateWebhook subscribes the URL to the event types for the user's personal deployments, or for the team's
+// deployments when a team is given. Teams the user cannot see are reported as dto.ErrTeamNotFound.
+func (s *WebhookService) CreateWebhook(ctx context.Context, userID string, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	subscription := &models.WebhookSubscription{
+		UserID:     userUUID,
+		URL:        req.URL,
+		EventTypes: strings.Join(uniqueStrings(req.EventTypes), " "),
+		Secret:     req.Secret,
+	}
+	if req.TeamID != "" {
+		teamUUID, err := uuid.Parse(req.TeamID)
+		if err != nil {
+			return nil, dto.ErrTeamNotFound
+		}
+		err = s.policy.Authorize(ctx, userUUID, dto.Ownership{TeamID: &teamUUID}, dto.ActionView)
+		if errors.Is(err, dto.ErrResourceNotVisible) {
+			return nil, dto.ErrTeamNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		subscription.TeamID = &teamUUID
+	}
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook created",
+		zap.String("user_id", userID),
+		zap.String("webhook_id", subscription.ID.String()),
+		zap.String("event_types", subscription.EventTypes),
+	)
+	return toWebhookResponse(subscription), nil
+}
+
+// ListWebhooks returns the webhooks the user created
+func (s *WebhookService) ListWebhooks(ctx context.Context, userID string) ([]*dto.WebhookResponse, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	subscriptions, err := s.repo.ListByUserID(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.WebhookResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, toWebhookResponse(subscription))
+	}
+	return result, nil
+}
+
+// DeleteWebhook removes one of the user's webhooks with its deliveries; pending deliveries are not sent
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string, userID string) error {
+	subscription, err := s.ownedWebhook(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, subscription.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Webhook deleted", zap.String("user_id", userID), zap.String("webhook_id", id))
+	return nil
+}
+
+// ListWebhookDeliveries returns the latest deliveries of one of the user's webhooks with their attempts
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, id string, userID string) ([]*dto.WebhookDeliveryResponse, error) {
+	subscription, err := s.ownedWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, subscription.ID, dto.WebhookDeliveriesLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return []*dto.WebhookDeliveryResponse{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.ID)
+	}
+	attempts, err := s.repo.ListAttempts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byDelivery := make(map[uuid.UUID][]*models.WebhookDeliveryAttempt, len(deliveries))
+	for _, attempt := range attempts {
+		byDelivery[attempt.DeliveryID] = append(byDelivery[attempt.DeliveryID], attempt)
+	}
+
+	result := make([]*dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toWebhookDeliveryResponse(delivery, byDelivery[delivery.ID]))
+	}
+	return result, nil
+}
+
+// RedeliverWebhook schedules a delivery of one of the user's webhooks to be sent again right away, whatever
+// its status; the new attempts are added to its attempt log
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, id string, deliveryID string, userID string) (*dto.WebhookDeliveryResponse, error) {
+	subscription, err := s.ownedWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryUUID, err := uuid.Parse(deliveryID)
+	if err != nil {
+		return nil, dto.ErrWebhookDeliveryNotFound
+	}
+	delivery, found, err := s.repo.GetDelivery(ctx, deliveryUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !found || delivery.SubscriptionID != subscription.ID {
+		return nil, dto.ErrWebhookDeliveryNotFound
+	}
+
+	if err := s.repo.Redeliver(ctx, deliveryUUID); err != nil {
+		return nil, err
+	}
+
+	delivery, _, err = s.repo.GetDelivery(ctx, deliveryUUID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.repo.ListAttempts(ctx, []uuid.UUID{deliveryUUID})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Webhook delivery redelivered",
+		zap.String("user_id", userID),
+		zap.String("webhook_id", id),
+		zap.String("delivery_id", deliveryID),
+	)
+	return toWebhookDeliveryResponse(delivery, attempts), nil
+}
+
+// ownedWebhook returns the webhook if the user created it; otherwise dto.ErrWebhookNotFound
+func (s *WebhookService) ownedWebhook(ctx context.Context, id string, userID string) (*models.WebhookSubscription, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	webhookUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, dto.ErrWebhookNotFound
+	}
+
+	subscription, found, err := s.repo.GetByID(ctx, webhookUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !found || subscription.UserID != userUUID {
+		return nil, dto.ErrWebhookNotFound
+	}
+	return subscription, nil
+}
+
+// uniqueStrings returns the values without duplicates, in first-seen order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// toWebhookResponse maps a webhook subscription to its response; the secret is left out
+func toWebhookResponse(subscription *models.WebhookSubscription) *dto.WebhookResponse {
+	return &dto.WebhookResponse{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: strings.Fields(subscription.EventTypes),
+		TeamID:     subscription.TeamID,
+		CreatedAt:  subscription.CreatedOn.Format(time.RFC3339),
+	}
+}
+
+// toWebhookDeliveryResponse maps a delivery and its attempts to its response; timestamps are RFC3339, empty when unset
+func toWebhookDeliveryResponse(delivery *models.WebhookDelivery, attempts []*models.WebhookDeliveryAttempt) *dto.WebhookDeliveryResponse {
+	resp := &dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		Payload:        delivery.Payload,
+		AttemptLog:     make([]*dto.WebhookAttemptResponse, 0, len(attempts)),
+		CreatedAt:      delivery.CreatedOn.Format(time.RFC3339),
+	}
+	if delivery.NextAttemptAt != nil && delivery.Status == models.WebhookDeliveryStatusPending {
+		resp.NextAttemptAt = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	if delivery.DeliveredOn != nil {
+		resp.DeliveredAt = delivery.DeliveredOn.Format(time.RFC3339)
+	}
+	for _, attempt := range attempts {
+		resp.AttemptLog = append(resp.AttemptLog, &dto.WebhookAttemptResponse{
+			Attempt:        attempt.Attempt,
+			StatusCode:     attempt.StatusCode,
+			Error:          attempt.Error,
+			DurationMillis: attempt.DurationMillis,
+			AttemptedAt:    attempt.CreatedOn.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
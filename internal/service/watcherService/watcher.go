@@ -27,10 +27,11 @@ func NewWatcherService(
 }
 
 // PublishDeploymentUpdate publishes a deployment update message to NATS
-// It builds the identifier from namespace/name and publishes via the deployment update producer
-func (s *WatcherService) PublishDeploymentUpdate(ctx context.Context, namespace, name, eventType string) error {
+// It builds the identifier from namespace/name and publishes via the deployment update producer;
+// the resource version identifies the event for deduplication
+func (s *WatcherService) PublishDeploymentUpdate(ctx context.Context, namespace, name, resourceVersion, eventType string) error {
 	identifier := namespace + "/" + name
-	if err := s.deploymentUpdate.Publish(identifier, eventType); err != nil {
+	if err := s.deploymentUpdate.Publish(identifier, resourceVersion, eventType); err != nil {
 		return fmt.Errorf("publish deployment update: %w", err)
 	}
 	return nil
@@ -0,0 +1,165 @@
+package workerService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/go-viper/mapstructure/v2"
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// runCanary rolls out the pod template of a canary UPDATE on the canary deployment, lets it soak and checks
+// its health, moving the request through the CANARY_DEPLOYING and CANARY_SOAKING phases to PROMOTING.
+// A canary that fails to roll out or is unhealthy after the soak is aborted: it is removed, the request is
+// marked FAILURE and failed is reported. Other errors are returned so the message is retried.
+func (s *DeploymentRequestService) runCanary(
+	ctx context.Context,
+	req *models.DeploymentRequest,
+	existingDeployment *appsv1.Deployment,
+	canary *dto.CanaryStrategy,
+	lastRetryAttempt bool,
+) (bool, error) {
+	if err := s.setPhase(ctx, req, models.DeploymentRequestPhaseCanaryDeploying); err != nil {
+		return false, fmt.Errorf("update phase to %s: %w", models.DeploymentRequestPhaseCanaryDeploying, err)
+	}
+	canaryDeployment, err := s.k8sDeploymentManager.ApplyCanary(ctx, req, existingDeployment, canary.Replicas)
+	if err != nil {
+		if lastRetryAttempt {
+			return true, s.abortCanary(ctx, req, err)
+		}
+		return false, fmt.Errorf("apply canary: %w", err)
+	}
+
+	if err := s.k8sDeploymentManager.WaitForRollout(ctx, canaryDeployment, rolloutTimeout(req)); err != nil {
+		if errors.Is(err, dto.ErrRolloutFailed) || lastRetryAttempt {
+			return true, s.abortCanary(ctx, req, err)
+		}
+		return false, fmt.Errorf("wait for canary rollout: %w", err)
+	}
+
+	if err := s.setPhase(ctx, req, models.DeploymentRequestPhaseCanarySoaking); err != nil {
+		return false, fmt.Errorf("update phase to %s: %w", models.DeploymentRequestPhaseCanarySoaking, err)
+	}
+	soak := time.NewTimer(canarySoak(canary))
+	defer soak.Stop()
+	select {
+	case <-ctx.Done():
+		if lastRetryAttempt {
+			return true, s.abortCanary(ctx, req, fmt.Errorf("canary soak interrupted: %w", ctx.Err()))
+		}
+		return false, ctx.Err()
+	case <-soak.C:
+	}
+
+	if err := s.k8sDeploymentManager.CheckCanary(ctx, canaryDeployment); err != nil {
+		if errors.Is(err, dto.ErrCanaryUnhealthy) || lastRetryAttempt {
+			return true, s.abortCanary(ctx, req, err)
+		}
+		return false, fmt.Errorf("check canary: %w", err)
+	}
+
+	s.logger.Info("Canary healthy, promoting",
+		zap.String("request_id", req.RequestID),
+		zap.String("identifier", req.Identifier),
+	)
+	if err := s.setPhase(ctx, req, models.DeploymentRequestPhasePromoting); err != nil {
+		return false, fmt.Errorf("update phase to %s: %w", models.DeploymentRequestPhasePromoting, err)
+	}
+	return false, nil
+}
+
+// abortCanary removes the canary of a request whose canary failed and marks the request FAILURE. The
+// deployment itself was not changed, so there is nothing to roll back. It runs on a fresh context, so a
+// canary is not leaked when the context it was processed with has expired.
+func (s *DeploymentRequestService) abortCanary(ctx context.Context, req *models.DeploymentRequest, cause error) error {
+	ctx, cancel := finalContext(ctx)
+	defer cancel()
+
+	errMsg := s.removeCanary(ctx, req, fmt.Sprintf("canary aborted: %v", cause))
+	s.logger.Warn("Canary aborted",
+		zap.String("request_id", req.RequestID),
+		zap.String("identifier", req.Identifier),
+		zap.Error(cause),
+	)
+	if err := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); err != nil {
+		return fmt.Errorf("mark deployment request as FAILURE: %w", err)
+	}
+	return nil
+}
+
+// removeCanary deletes the canary of a failed request and moves it to the ABORTED phase, appending a
+// failed deletion to the failure reason.
+func (s *DeploymentRequestService) removeCanary(ctx context.Context, req *models.DeploymentRequest, reason string) string {
+	if err := s.k8sDeploymentManager.DeleteCanary(ctx, req.Namespace, req.Identifier); err != nil {
+		s.logger.Error("Failed to delete canary deployment",
+			zap.String("request_id", req.RequestID),
+			zap.String("identifier", req.Identifier),
+			zap.Error(err),
+		)
+		reason = fmt.Sprintf("%s; deleting the canary failed: %v", reason, err)
+	}
+	if err := s.setPhase(ctx, req, models.DeploymentRequestPhaseAborted); err != nil {
+		s.logger.Error("Failed to update phase to ABORTED", zap.Error(err))
+	}
+	return reason
+}
+
+// promoteCanary deletes the canary once the update is applied to the deployment and moves the request to
+// the PROMOTED phase. A canary that cannot be deleted on the last attempt is only logged: the update
+// itself succeeded.
+func (s *DeploymentRequestService) promoteCanary(ctx context.Context, req *models.DeploymentRequest, lastRetryAttempt bool) error {
+	if err := s.k8sDeploymentManager.DeleteCanary(ctx, req.Namespace, req.Identifier); err != nil {
+		if !lastRetryAttempt {
+			return err
+		}
+		s.logger.Error("Failed to delete promoted canary deployment",
+			zap.String("request_id", req.RequestID),
+			zap.String("identifier", req.Identifier),
+			zap.Error(err),
+		)
+	}
+	if err := s.setPhase(ctx, req, models.DeploymentRequestPhasePromoted); err != nil {
+		return fmt.Errorf("update phase to %s: %w", models.DeploymentRequestPhasePromoted, err)
+	}
+	return nil
+}
+
+// setPhase stores and publishes the phase of the request
+func (s *DeploymentRequestService) setPhase(ctx context.Context, req *models.DeploymentRequest, phase models.DeploymentRequestPhase) error {
+	return setRequestPhase(ctx, s.deploymentRequestRepo, s.statusPublisher, s.logger, req, phase)
+}
+
+// requestCanary returns the canary settings of an UPDATE with the Canary strategy, nil for other requests
+func requestCanary(req *models.DeploymentRequest) (*dto.CanaryStrategy, error) {
+	var updateMetadata dto.UpdateDeploymentRequestMetadata
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:  &updateMetadata,
+		TagName: dto.MapstructureTagJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if err := decoder.Decode(map[string]interface{}(req.Metadata)); err != nil {
+		return nil, fmt.Errorf("failed to decode update metadata: %w", err)
+	}
+	if updateMetadata.Strategy == nil || updateMetadata.Strategy.Type != dto.StrategyCanary {
+		return nil, nil
+	}
+	if updateMetadata.Strategy.Canary == nil {
+		return nil, fmt.Errorf("%w: canary settings missing", dto.ErrInvalidStrategy)
+	}
+	return updateMetadata.Strategy.Canary, nil
+}
+
+// canarySoak is how long the canary must stay healthy
+func canarySoak(canary *dto.CanaryStrategy) time.Duration {
+	if canary.SoakSeconds <= 0 {
+		return dto.DefaultCanarySoakSeconds * time.Second
+	}
+	return time.Duration(canary.SoakSeconds) * time.Second
+}
@@ -0,0 +1,81 @@
+package workerService
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DeadLetterService implements worker-side recording of dead-lettered messages
+type DeadLetterService struct {
+	deadLetterRepo portsdb.DeadLetter
+	logger         *zap.Logger
+}
+
+// NewDeadLetterService creates a new worker dead-letter service
+func NewDeadLetterService(
+	deadLetterRepo portsdb.DeadLetter,
+	logger *zap.Logger,
+) portsworker.DeadLetter {
+	return &DeadLetterService{
+		deadLetterRepo: deadLetterRepo,
+		logger:         logger,
+	}
+}
+
+// ProcessDeadLetter stores the dead-lettered message so it can be listed and replayed via the API.
+// request_id and user_id headers (when present) are kept as columns for ownership and lookup.
+func (s *DeadLetterService) ProcessDeadLetter(ctx context.Context, msg *dto.DeadLetterMessage) error {
+	headers := make(models.JSONB, len(msg.Headers))
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+
+	deadLetter := &models.DeadLetter{
+		Subject:         msg.Subject,
+		Stream:          msg.Stream,
+		Sequence:        msg.Sequence,
+		StoredOn:        &msg.StoredOn,
+		OriginalSubject: msg.OriginalSubject,
+		Status:          models.DeadLetterStatusPending,
+		Attempts:        msg.Attempts,
+		LastError:       msg.LastError,
+		Payload:         msg.Payload,
+		Headers:         headers,
+	}
+	if vals := msg.Headers[dto.HeaderKeyRequestID]; len(vals) > 0 && vals[0] != "" {
+		requestID := vals[0]
+		deadLetter.RequestID = &requestID
+	}
+	if userID := utils.GetUserIDFromWorkerHeader(msg.Headers); userID != uuid.Nil {
+		deadLetter.UserID = &userID
+	}
+
+	created, err := s.deadLetterRepo.Create(ctx, deadLetter)
+	if err != nil {
+		return fmt.Errorf("store dead-lettered message: %w", err)
+	}
+	if !created {
+		s.logger.Info("Dead-lettered message already recorded, skipping",
+			zap.String("stream", msg.Stream),
+			zap.Uint64("sequence", msg.Sequence),
+			zap.Time("stored_on", msg.StoredOn),
+		)
+		return nil
+	}
+
+	s.logger.Warn("Recorded dead-lettered message",
+		zap.String("original_subject", msg.OriginalSubject),
+		zap.Uint64("sequence", msg.Sequence),
+		zap.Int("attempts", msg.Attempts),
+		zap.String("last_error", msg.LastError),
+	)
+	return nil
+}
@@ -2,23 +2,34 @@ package workerService
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	portsk8s "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/k8s"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
 	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
 	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
 )
 
+// requestFinalizeTimeout bounds the work left once a request's rollout wait ends: the rollback and status
+// writes of a failed rollout, or the status write of a completed one
+const requestFinalizeTimeout = time.Minute
+
 // DeploymentRequestService implements worker-side deployment request processing
 type DeploymentRequestService struct {
 	deploymentRequestRepo portsdb.DeploymentRequest
 	k8sDeploymentManager  portsk8s.DeploymentManager
+	statusPublisher       portsqueue.DeploymentStatus
+	eventPublisher        portsqueue.WebhookEvent
 	logger                *zap.Logger
 }
 
@@ -26,11 +37,15 @@ type DeploymentRequestService struct {
 func NewDeploymentRequestService(
 	deploymentRequestRepo portsdb.DeploymentRequest,
 	k8sDeploymentManager portsk8s.DeploymentManager,
+	statusPublisher portsqueue.DeploymentStatus,
+	eventPublisher portsqueue.WebhookEvent,
 	logger *zap.Logger,
 ) portsworker.DeploymentRequest {
 	return &DeploymentRequestService{
 		deploymentRequestRepo: deploymentRequestRepo,
 		k8sDeploymentManager:  k8sDeploymentManager,
+		statusPublisher:       statusPublisher,
+		eventPublisher:        eventPublisher,
 		logger:                logger,
 	}
 }
@@ -46,8 +61,14 @@ func (s *DeploymentRequestService) ProcessDeploymentRequest(ctx context.Context,
 		return fmt.Errorf("deployment request not found: request_id=%s", msg.RequestID)
 	}
 
+	// A request completed by an earlier delivery (e.g. a duplicate republished by the reaper) is acked
+	// without being processed again
 	if req.Status != models.DeploymentRequestStatusCreated {
-		return fmt.Errorf("deployment request is in terminal or invalid state: status=%s", req.Status)
+		s.logger.Info("Skipping deployment request that is no longer CREATED",
+			zap.String("request_id", req.RequestID),
+			zap.String("status", string(req.Status)),
+		)
+		return nil
 	}
 
 	headerUserID := utils.GetUserIDFromWorkerHeader(consumer.HeadersFromContext(ctx))
@@ -57,13 +78,19 @@ func (s *DeploymentRequestService) ProcessDeploymentRequest(ctx context.Context,
 
 	lastRetryAttempt := consumer.LastAttemptFromContext(ctx)
 
+	ctx, cancel := rolloutContext(ctx, req)
+	defer cancel()
+
 	switch req.RequestType {
 	case models.DeploymentRequestTypeCreate:
 		return s.processCreate(ctx, req, lastRetryAttempt)
-	case models.DeploymentRequestTypeUpdate:
+	case models.DeploymentRequestTypeUpdate, models.DeploymentRequestTypeRollback:
+		// A rollback carries the state to restore as update metadata
 		return s.processUpdate(ctx, req, lastRetryAttempt)
 	case models.DeploymentRequestTypeDelete:
 		return s.processDelete(ctx, req, lastRetryAttempt)
+	case models.DeploymentRequestTypeRestart, models.DeploymentRequestTypePause, models.DeploymentRequestTypeResume:
+		return s.processOperation(ctx, req, lastRetryAttempt)
 	default:
 		return fmt.Errorf("unknown request type: %s", req.RequestType)
 	}
@@ -71,31 +98,40 @@ func (s *DeploymentRequestService) ProcessDeploymentRequest(ctx context.Context,
 
 // processCreate invokes k8s deployment creation and updates the deployment request status.
 func (s *DeploymentRequestService) processCreate(ctx context.Context, req *models.DeploymentRequest, lastRetryAttempt bool) error {
-	_, err := s.k8sDeploymentManager.Create(ctx, req)
+	deployment, err := s.k8sDeploymentManager.Create(ctx, req)
 	if err != nil {
+		if errors.Is(err, dto.ErrFieldOwnershipConflict) {
+			return s.failOwnershipConflict(ctx, req, err)
+		}
 		if lastRetryAttempt {
 			errMsg := err.Error()
-			if updateErr := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
 				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
 			}
 		}
 		return fmt.Errorf("create deployment: %w", err)
 	}
 
-	if err := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusSuccess, nil); err != nil {
+	if failed, err := s.awaitRollout(ctx, req, deployment, lastRetryAttempt); err != nil || failed {
+		return err
+	}
+
+	if err := s.setStatus(ctx, req, models.DeploymentRequestStatusSuccess, nil); err != nil {
 		return fmt.Errorf("update status to SUCCESS: %w", err)
 	}
 	return nil
 }
 
-// processUpdate invokes k8s deployment update and updates the deployment request status.
+// processUpdate snapshots the fields the request changes, runs the canary of a canary update, invokes k8s
+// deployment update and updates the deployment request status. When the request fails for good (last attempt, ownership conflict or failed
+// rollout) the snapshot is restored.
 func (s *DeploymentRequestService) processUpdate(ctx context.Context, req *models.DeploymentRequest, lastRetryAttempt bool) error {
 	// Get existing deployment from K8s
 	existingDeployment, found, err := s.k8sDeploymentManager.GetOptional(ctx, req.Namespace, req.Identifier)
 	if err != nil {
 		if lastRetryAttempt {
 			errMsg := fmt.Sprintf("failed to get existing deployment: %v", err)
-			if updateErr := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
 				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
 			}
 		}
@@ -104,31 +140,185 @@ func (s *DeploymentRequestService) processUpdate(ctx context.Context, req *model
 	if !found {
 		if lastRetryAttempt {
 			errMsg := fmt.Sprintf("deployment not found in Kubernetes: namespace=%s, name=%s", req.Namespace, req.Name)
-			if updateErr := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
 				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
 			}
 		}
 		return fmt.Errorf("deployment not found in Kubernetes: namespace=%s, name=%s", req.Namespace, req.Name)
 	}
 
-	// Update the deployment in K8s
-	_, err = s.k8sDeploymentManager.Update(ctx, req, existingDeployment)
+	// Snapshot once: a redelivered request must not snapshot the state it already changed
+	if len(req.Snapshot) == 0 {
+		snapshot, err := s.k8sDeploymentManager.Snapshot(ctx, req, existingDeployment)
+		if err != nil {
+			if lastRetryAttempt {
+				errMsg := fmt.Sprintf("failed to snapshot deployment: %v", err)
+				if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+					s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
+				}
+			}
+			return fmt.Errorf("snapshot deployment: %w", err)
+		}
+		if err := s.deploymentRequestRepo.SaveSnapshot(ctx, req.ID, snapshot); err != nil {
+			return fmt.Errorf("save snapshot: %w", err)
+		}
+		req.Snapshot = snapshot
+	}
+
+	// A canary update runs and soaks the canary first; a redelivered request that already got past its
+	// canary goes on with the promotion
+	canary, err := requestCanary(req)
 	if err != nil {
 		if lastRetryAttempt {
 			errMsg := err.Error()
-			if updateErr := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
+			}
+		}
+		return fmt.Errorf("decode canary strategy: %w", err)
+	}
+	if canary != nil && req.Phase != models.DeploymentRequestPhasePromoting && req.Phase != models.DeploymentRequestPhasePromoted {
+		if failed, err := s.runCanary(ctx, req, existingDeployment, canary, lastRetryAttempt); err != nil || failed {
+			return err
+		}
+	}
+
+	// Update the deployment in K8s
+	deployment, err := s.k8sDeploymentManager.Update(ctx, req, existingDeployment)
+	if err != nil {
+		if errors.Is(err, dto.ErrFieldOwnershipConflict) {
+			return s.failOwnershipConflict(ctx, req, err)
+		}
+		if lastRetryAttempt {
+			errMsg := s.withRollback(ctx, req, err.Error())
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
 				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
 			}
 		}
 		return fmt.Errorf("update deployment: %w", err)
 	}
 
-	if err := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusSuccess, nil); err != nil {
+	if failed, err := s.awaitRollout(ctx, req, deployment, lastRetryAttempt); err != nil || failed {
+		return err
+	}
+
+	if canary != nil {
+		if err := s.promoteCanary(ctx, req, lastRetryAttempt); err != nil {
+			return fmt.Errorf("promote canary: %w", err)
+		}
+	}
+
+	if err := s.setStatus(ctx, req, models.DeploymentRequestStatusSuccess, nil); err != nil {
 		return fmt.Errorf("update status to SUCCESS: %w", err)
 	}
 	return nil
 }
 
+// awaitRollout waits for the rollout of deployment when the request sets wait_for_rollout. A stuck or timed
+// out rollout marks the request FAILURE with the reason and reports failed; other errors (e.g. the cluster
+// is unreachable) are returned so the message is retried.
+func (s *DeploymentRequestService) awaitRollout(ctx context.Context, req *models.DeploymentRequest, deployment *appsv1.Deployment, lastRetryAttempt bool) (bool, error) {
+	if !req.WaitForRollout {
+		return false, nil
+	}
+
+	err := s.k8sDeploymentManager.WaitForRollout(ctx, deployment, rolloutTimeout(req))
+	if err == nil {
+		return false, nil
+	}
+
+	if errors.Is(err, dto.ErrRolloutFailed) || lastRetryAttempt {
+		// The rollback and FAILURE write must happen even if the wait used up the context
+		finalCtx, cancel := finalContext(ctx)
+		defer cancel()
+		errMsg := s.withRollback(finalCtx, req, err.Error())
+		if updateErr := s.setStatus(finalCtx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			return false, fmt.Errorf("mark deployment request as FAILURE: %w", updateErr)
+		}
+		if errors.Is(err, dto.ErrRolloutFailed) {
+			s.logger.Warn("Deployment rollout failed",
+				zap.String("request_id", req.RequestID),
+				zap.String("identifier", req.Identifier),
+				zap.Error(err),
+			)
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("wait for rollout: %w", err)
+}
+
+// rolloutContext returns the context a request that waits for its rollout or runs a canary is processed with.
+// The consumer's task timeout (1m by default) is usually shorter than a rollout or a canary soak, so the
+// request keeps the handler context's values but not its deadline, and is bounded by its own rollout timeout
+// and soak instead. Other requests keep the handler context.
+func rolloutContext(ctx context.Context, req *models.DeploymentRequest) (context.Context, context.CancelFunc) {
+	var budget time.Duration
+	if req.WaitForRollout {
+		budget += rolloutTimeout(req)
+	}
+	if canary, err := requestCanary(req); err == nil && canary != nil {
+		budget += rolloutTimeout(req) + canarySoak(canary)
+	}
+	if budget == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(context.WithoutCancel(ctx), budget+requestFinalizeTimeout)
+}
+
+// finalContext returns a fresh context for the rollback and status writes of a request that failed for good,
+// so they still run when the context it was processed with has expired
+func finalContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), requestFinalizeTimeout)
+}
+
+// rolloutTimeout bounds the rollout wait of a request and of its canary
+func rolloutTimeout(req *models.DeploymentRequest) time.Duration {
+	if req.RolloutTimeoutSeconds <= 0 {
+		return dto.DefaultRolloutTimeoutSeconds * time.Second
+	}
+	return time.Duration(req.RolloutTimeoutSeconds) * time.Second
+}
+
+// withRollback restores the snapshot of an UPDATE or ROLLBACK request that failed for good and appends
+// the outcome to the failure reason; the canary of a failed promotion is removed as well. Requests without
+// a snapshot (CREATE) are returned unchanged.
+func (s *DeploymentRequestService) withRollback(ctx context.Context, req *models.DeploymentRequest, reason string) string {
+	if req.Phase == models.DeploymentRequestPhasePromoting {
+		reason = s.removeCanary(ctx, req, reason)
+	}
+	if len(req.Snapshot) == 0 {
+		return reason
+	}
+	if err := s.k8sDeploymentManager.Restore(ctx, req, req.Snapshot); err != nil {
+		s.logger.Error("Failed to restore deployment snapshot",
+			zap.String("request_id", req.RequestID),
+			zap.String("identifier", req.Identifier),
+			zap.Error(err),
+		)
+		return fmt.Sprintf("%s; rollback to the pre-update state failed: %v", reason, err)
+	}
+	s.logger.Info("Restored deployment snapshot after failed request",
+		zap.String("request_id", req.RequestID),
+		zap.String("identifier", req.Identifier),
+	)
+	return reason + "; rolled back to the pre-update state"
+}
+
+// failOwnershipConflict marks the request FAILURE right away: retrying an apply that conflicts with
+// another field manager cannot succeed until the request is resubmitted with force_ownership.
+func (s *DeploymentRequestService) failOwnershipConflict(ctx context.Context, req *models.DeploymentRequest, err error) error {
+	errMsg := s.withRollback(ctx, req, fmt.Sprintf("%v; %s", err, dto.FieldOwnershipConflictHint))
+	s.logger.Warn("Deployment request conflicts with another field manager",
+		zap.String("request_id", req.RequestID),
+		zap.String("identifier", req.Identifier),
+		zap.Error(err),
+	)
+	if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+		return fmt.Errorf("mark deployment request as FAILURE: %w", updateErr)
+	}
+	return nil
+}
+
 // processDelete invokes k8s deployment deletion and updates the deployment request status.
 func (s *DeploymentRequestService) processDelete(ctx context.Context, req *models.DeploymentRequest, lastRetryAttempt bool) error {
 	// Delete the deployment from K8s
@@ -136,15 +326,75 @@ func (s *DeploymentRequestService) processDelete(ctx context.Context, req *model
 	if err != nil {
 		if lastRetryAttempt {
 			errMsg := err.Error()
-			if updateErr := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
 				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
 			}
 		}
 		return fmt.Errorf("delete deployment: %w", err)
 	}
+	s.collectNamespace(ctx, req.Namespace)
 
-	if err := s.deploymentRequestRepo.UpdateStatus(ctx, req.ID, models.DeploymentRequestStatusSuccess, nil); err != nil {
+	if err := s.setStatus(ctx, req, models.DeploymentRequestStatusSuccess, nil); err != nil {
 		return fmt.Errorf("update status to SUCCESS: %w", err)
 	}
 	return nil
 }
+
+// collectNamespace lets the deployment manager delete the namespace of a deleted deployment once it is empty,
+// unless a CREATE request for the namespace is pending: its worker may have provisioned the namespace and not
+// applied the deployment yet. The namespace is then left for the delete of a later deployment.
+func (s *DeploymentRequestService) collectNamespace(ctx context.Context, namespace string) {
+	pending, err := s.deploymentRequestRepo.CountPendingCreatesInNamespace(ctx, namespace)
+	if err != nil {
+		s.logger.Warn("Failed to count pending create requests for namespace garbage collection",
+			zap.String("namespace", namespace),
+			zap.Error(err),
+		)
+		return
+	}
+	if pending > 0 {
+		s.logger.Debug("Keeping namespace with pending create requests",
+			zap.String("namespace", namespace),
+			zap.Int64("pending", pending),
+		)
+		return
+	}
+	s.k8sDeploymentManager.CollectNamespace(ctx, namespace)
+}
+
+// processOperation restarts, pauses or resumes the deployment and updates the deployment request status.
+func (s *DeploymentRequestService) processOperation(ctx context.Context, req *models.DeploymentRequest, lastRetryAttempt bool) error {
+	existingDeployment, found, err := s.k8sDeploymentManager.GetOptional(ctx, req.Namespace, req.Identifier)
+	if err == nil && !found {
+		err = fmt.Errorf("deployment not found in Kubernetes: namespace=%s, name=%s", req.Namespace, req.Name)
+	}
+	if err == nil {
+		switch req.RequestType {
+		case models.DeploymentRequestTypeRestart:
+			_, err = s.k8sDeploymentManager.Restart(ctx, req, existingDeployment)
+		case models.DeploymentRequestTypePause:
+			_, err = s.k8sDeploymentManager.SetPaused(ctx, req, existingDeployment, true)
+		case models.DeploymentRequestTypeResume:
+			_, err = s.k8sDeploymentManager.SetPaused(ctx, req, existingDeployment, false)
+		}
+	}
+	if err != nil {
+		if lastRetryAttempt {
+			errMsg := err.Error()
+			if updateErr := s.setStatus(ctx, req, models.DeploymentRequestStatusFailure, &errMsg); updateErr != nil {
+				s.logger.Error("Failed to mark deployment request as FAILURE", zap.Error(updateErr))
+			}
+		}
+		return fmt.Errorf("%s deployment: %w", strings.ToLower(string(req.RequestType)), err)
+	}
+
+	if err := s.setStatus(ctx, req, models.DeploymentRequestStatusSuccess, nil); err != nil {
+		return fmt.Errorf("update status to SUCCESS: %w", err)
+	}
+	return nil
+}
+
+// setStatus stores the status of the request and publishes the transition and, when terminal, its webhook event.
+func (s *DeploymentRequestService) setStatus(ctx context.Context, req *models.DeploymentRequest, status models.DeploymentRequestStatus, failureReason *string) error {
+	return setRequestStatus(ctx, s.deploymentRequestRepo, s.statusPublisher, s.eventPublisher, s.logger, req, status, failureReason)
+}
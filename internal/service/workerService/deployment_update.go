@@ -10,28 +10,39 @@ import (
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
 	portsk8s "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/k8s"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
 	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeploymentUpdateService implements worker-side deployment update processing
 type DeploymentUpdateService struct {
 	deploymentRepo       portsdb.Deployment
+	revisionRepo         portsdb.DeploymentRevision
 	k8sDeploymentManager portsk8s.DeploymentManager
+	eventPublisher       portsqueue.WebhookEvent
 	logger               *zap.Logger
 }
 
 // NewDeploymentUpdateService creates a new worker deployment update service
 func NewDeploymentUpdateService(
 	deploymentRepo portsdb.Deployment,
+	revisionRepo portsdb.DeploymentRevision,
 	k8sDeploymentManager portsk8s.DeploymentManager,
+	eventPublisher portsqueue.WebhookEvent,
 	logger *zap.Logger,
 ) portsworker.DeploymentUpdate {
 	return &DeploymentUpdateService{
 		deploymentRepo:       deploymentRepo,
+		revisionRepo:         revisionRepo,
 		k8sDeploymentManager: k8sDeploymentManager,
+		eventPublisher:       eventPublisher,
 		logger:               logger,
 	}
 }
@@ -40,7 +51,9 @@ func NewDeploymentUpdateService(
 // 1. Fetches from both DB (by identifier) and K8s (by namespace/name), with error checks.
 // 2. If not in DB and not in K8s → return as is.
 // 3. If in DB and not in K8s → mark as deleted.
-// 4. Else (in K8s) → extract metadata and upsert as usual.
+// 4. Else (in K8s) → extract metadata, including its HorizontalPodAutoscaler if any, and upsert as usual,
+// recording a revision when the generation is new.
+// A deployment becoming DELETED or DEGRADED is published as a webhook event before it is stored.
 func (s *DeploymentUpdateService) ProcessDeploymentUpdate(ctx context.Context, msg *dto.DeploymentUpdateMessage) error {
 	// Parse identifier (format: namespace/name)
 	parts := strings.Split(msg.Identifier, "/")
@@ -68,13 +81,28 @@ func (s *DeploymentUpdateService) ProcessDeploymentUpdate(ctx context.Context, m
 	}
 
 	// Usual flow: in K8s — extract metadata and upsert
-	deployment, err := s.extractDeploymentFromK8s(k8sDeployment)
+	hpa, _, err := s.k8sDeploymentManager.GetAutoscaler(ctx, namespace, name)
+	if err != nil {
+		return fmt.Errorf("get autoscaler from k8s: %w", err)
+	}
+	deployment, err := s.extractDeploymentFromK8s(k8sDeployment, hpa)
 	if err != nil {
 		return fmt.Errorf("extract deployment from k8s object: %w", err)
 	}
+	var previous *models.Deployment
+	if dbExists {
+		previous = dbDeployment
+	}
+	reason, _ := degradedReason(k8sDeployment)
+	if err := s.publishTransition(previous, deployment, reason); err != nil {
+		return err
+	}
 	if err := s.deploymentRepo.Upsert(ctx, deployment); err != nil {
 		return fmt.Errorf("upsert deployment: %w", err)
 	}
+	if err := s.recordRevision(ctx, deployment.Identifier, k8sDeployment); err != nil {
+		return err
+	}
 	s.logger.Info("Processed deployment update",
 		zap.String("identifier", deployment.Identifier),
 		zap.String("resource_version", deployment.ResourceVersion),
@@ -83,11 +111,59 @@ func (s *DeploymentUpdateService) ProcessDeploymentUpdate(ctx context.Context, m
 	return nil
 }
 
+// recordRevision stores the deployment spec at its current generation; generations already recorded are skipped.
+func (s *DeploymentUpdateService) recordRevision(ctx context.Context, identifier string, k8sDeployment *appsv1.Deployment) error {
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&k8sDeployment.Spec)
+	if err != nil {
+		return fmt.Errorf("convert deployment spec: %w", err)
+	}
+
+	revision := &models.DeploymentRevision{
+		Identifier: identifier,
+		Revision:   k8sDeployment.Generation,
+		Replicas:   1,
+		Spec:       models.JSONB(spec),
+		RequestID:  k8sDeployment.Annotations[dto.AnnotationKeyLastRequestID],
+	}
+	if revision.RequestID == "" {
+		revision.RequestID = k8sDeployment.Labels[dto.LabelKeyRequestID]
+	}
+	if k8sDeployment.Spec.Replicas != nil {
+		revision.Replicas = *k8sDeployment.Spec.Replicas
+	}
+	if containers := k8sDeployment.Spec.Template.Spec.Containers; len(containers) > 0 {
+		revision.Image = containers[0].Image
+		revision.Resources = models.JSONB{
+			"requests": utils.ResourceListToMap(containers[0].Resources.Requests),
+			"limits":   utils.ResourceListToMap(containers[0].Resources.Limits),
+		}
+	}
+
+	created, err := s.revisionRepo.Create(ctx, revision)
+	if err != nil {
+		return fmt.Errorf("record deployment revision: %w", err)
+	}
+	if created {
+		s.logger.Info("Recorded deployment revision",
+			zap.String("identifier", identifier),
+			zap.Int64("revision", revision.Revision),
+			zap.String("request_id", revision.RequestID),
+		)
+	}
+	return nil
+}
+
 // markDeploymentDeleted sets deployment status to DELETED and updates the DB when the deployment exists in DB but not in K8s.
 func (s *DeploymentUpdateService) markDeploymentDeleted(ctx context.Context, dbDeployment *models.Deployment, identifier string) error {
 	if dbDeployment.Status == models.DeploymentStatusDeleted {
 		return nil
 	}
+	deleted := *dbDeployment
+	deleted.Status = models.DeploymentStatusDeleted
+	if err := s.publishTransition(dbDeployment, &deleted, ""); err != nil {
+		return err
+	}
+
 	now := time.Now()
 	dbDeployment.Status = models.DeploymentStatusDeleted
 	dbDeployment.UpdatedOn = &now
@@ -100,8 +176,46 @@ func (s *DeploymentUpdateService) markDeploymentDeleted(ctx context.Context, dbD
 	return nil
 }
 
-// extractDeploymentFromK8s extracts deployment fields from Kubernetes deployment object
-func (s *DeploymentUpdateService) extractDeploymentFromK8s(k8sDeployment *appsv1.Deployment) (*models.Deployment, error) {
+// publishTransition publishes the webhook event of a deployment moving to DELETED or DEGRADED from another
+// status. The event ID includes the resource version, so a retried update publishes the same event while a
+// later transition to the same status is a new one.
+func (s *DeploymentUpdateService) publishTransition(previous, next *models.Deployment, reason string) error {
+	var eventType string
+	switch next.Status {
+	case models.DeploymentStatusDeleted:
+		eventType = dto.EventDeploymentDeleted
+	case models.DeploymentStatusDegraded:
+		eventType = dto.EventDeploymentDegraded
+	default:
+		return nil
+	}
+	if previous != nil && previous.Status == next.Status {
+		return nil
+	}
+
+	event := &dto.WebhookEvent{
+		ID:         next.Identifier + "@" + next.ResourceVersion + ":" + eventType,
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		UserID:     next.UserID,
+		TeamID:     next.TeamID,
+		Deployment: &dto.WebhookEventDeployment{
+			Identifier: next.Identifier,
+			Name:       next.Name,
+			Namespace:  next.Namespace,
+			Status:     string(next.Status),
+			Reason:     reason,
+		},
+	}
+	if err := s.eventPublisher.Publish(event); err != nil {
+		return fmt.Errorf("publish webhook event: %w", err)
+	}
+	return nil
+}
+
+// extractDeploymentFromK8s extracts deployment fields from Kubernetes deployment object and its
+// HorizontalPodAutoscaler (nil when it is not autoscaled)
+func (s *DeploymentUpdateService) extractDeploymentFromK8s(k8sDeployment *appsv1.Deployment, hpa *autoscalingv2.HorizontalPodAutoscaler) (*models.Deployment, error) {
 	now := time.Now()
 	deployment := &models.Deployment{
 		Common: models.Common{
@@ -127,6 +241,15 @@ func (s *DeploymentUpdateService) extractDeploymentFromK8s(k8sDeployment *appsv1
 	}
 	deployment.UserID = userID
 
+	// Extract the owning team from labels (absent for personal deployments)
+	if teamIDStr, ok := k8sDeployment.Labels[dto.LabelKeyTeamID]; ok {
+		teamID, err := uuid.Parse(teamIDStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid team-id format: %w", err)
+		}
+		deployment.TeamID = &teamID
+	}
+
 	// Extract name and namespace from metadata
 	deployment.Name, ok = k8sDeployment.Labels["name"]
 	if !ok {
@@ -143,8 +266,31 @@ func (s *DeploymentUpdateService) extractDeploymentFromK8s(k8sDeployment *appsv1
 		deployment.Image = k8sDeployment.Spec.Template.Spec.Containers[0].Image
 	}
 
-	// Determine status from deployment conditions
-	deployment.Status = s.determineStatus(k8sDeployment)
+	// Size the deployment for quotas: desired replicas and the CPU/memory limits of one pod
+	deployment.Replicas = 1
+	if k8sDeployment.Spec.Replicas != nil {
+		deployment.Replicas = int(*k8sDeployment.Spec.Replicas)
+	}
+	for _, container := range k8sDeployment.Spec.Template.Spec.Containers {
+		deployment.PodCPUMillis += container.Resources.Limits.Cpu().MilliValue()
+		deployment.PodMemoryBytes += container.Resources.Limits.Memory().Value()
+	}
+
+	// Determine status from deployment conditions and the autoscaler's replica counts
+	deployment.Status = s.determineStatus(k8sDeployment, hpa)
+	if hpa != nil {
+		minReplicas := 1
+		if hpa.Spec.MinReplicas != nil {
+			minReplicas = int(*hpa.Spec.MinReplicas)
+		}
+		maxReplicas := int(hpa.Spec.MaxReplicas)
+		desiredReplicas := int(hpa.Status.DesiredReplicas)
+		currentReplicas := int(hpa.Status.CurrentReplicas)
+		deployment.AutoscalingMinReplicas = &minReplicas
+		deployment.AutoscalingMaxReplicas = &maxReplicas
+		deployment.AutoscalingDesiredReplicas = &desiredReplicas
+		deployment.AutoscalingCurrentReplicas = &currentReplicas
+	}
 
 	// dump relevant metadata to deployment.Metadata
 	deployment.Metadata = models.JSONB{
@@ -154,13 +300,28 @@ func (s *DeploymentUpdateService) extractDeploymentFromK8s(k8sDeployment *appsv1
 	return deployment, nil
 }
 
-// determineStatus determines the deployment status from Kubernetes deployment conditions
-func (s *DeploymentUpdateService) determineStatus(k8sDeployment *appsv1.Deployment) models.DeploymentStatus {
+// determineStatus determines the deployment status from Kubernetes deployment conditions. An autoscaled
+// deployment (hpa is not nil) is UPDATING while its autoscaler wants another replica count than it sees.
+func (s *DeploymentUpdateService) determineStatus(k8sDeployment *appsv1.Deployment, hpa *autoscalingv2.HorizontalPodAutoscaler) models.DeploymentStatus {
 	// Check if deployment is being deleted
 	if k8sDeployment.DeletionTimestamp != nil {
 		return models.DeploymentStatusDeleted
 	}
 
+	// A deployment scaled to zero is at rest once its last pod is gone
+	if k8sDeployment.Spec.Replicas != nil && *k8sDeployment.Spec.Replicas == 0 && k8sDeployment.Status.Replicas == 0 {
+		return models.DeploymentStatusScaledToZero
+	}
+
+	if _, degraded := degradedReason(k8sDeployment); degraded {
+		return models.DeploymentStatusDegraded
+	}
+
+	// The autoscaler is scaling the deployment
+	if hpa != nil && hpa.Status.DesiredReplicas != hpa.Status.CurrentReplicas {
+		return models.DeploymentStatusUpdating
+	}
+
 	// Check if deployment has ready replicas
 	if k8sDeployment.Status.ReadyReplicas > 0 && k8sDeployment.Status.ReadyReplicas == k8sDeployment.Status.Replicas {
 		return models.DeploymentStatusCreated
@@ -179,3 +340,29 @@ func (s *DeploymentUpdateService) determineStatus(k8sDeployment *appsv1.Deployme
 	// Default to initiated if we can't determine status
 	return models.DeploymentStatusInitiated
 }
+
+// degradedReason reports whether a deployment is degraded, with the message of the condition explaining it:
+// its rollout exceeded the progress deadline, or it completed its rollout but is no longer available.
+func degradedReason(k8sDeployment *appsv1.Deployment) (string, bool) {
+	var progressing, available *appsv1.DeploymentCondition
+	for i := range k8sDeployment.Status.Conditions {
+		condition := &k8sDeployment.Status.Conditions[i]
+		switch condition.Type {
+		case appsv1.DeploymentProgressing:
+			progressing = condition
+		case appsv1.DeploymentAvailable:
+			available = condition
+		}
+	}
+	if progressing == nil {
+		return "", false
+	}
+
+	if progressing.Status == corev1.ConditionFalse && progressing.Reason == dto.ReasonProgressDeadlineExceeded {
+		return progressing.Message, true
+	}
+	if progressing.Reason == dto.ReasonNewReplicaSetAvailable && available != nil && available.Status == corev1.ConditionFalse {
+		return available.Message, true
+	}
+	return "", false
+}
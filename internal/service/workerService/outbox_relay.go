@@ -0,0 +1,130 @@
+package workerService
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOutboxPollInterval = time.Second
+	defaultOutboxBatchSize    = 100
+	outboxCleanupInterval     = 10 * time.Minute
+)
+
+// OutboxRelayService periodically publishes pending outbox messages to NATS and
+// removes published messages once they are older than the retention. Both the API and
+// the worker run one: the API writes request messages, the worker's reaper and scheduler
+// write their own, and PublishPending locks rows with SKIP LOCKED so relays never collide.
+type OutboxRelayService struct {
+	repo      portsdb.Outbox
+	publisher portsqueue.Outbox
+	cfg       dto.OutboxConfig
+	logger    *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewOutboxRelayService creates a new OutboxRelayService with injected dependencies
+func NewOutboxRelayService(
+	repo portsdb.Outbox,
+	publisher portsqueue.Outbox,
+	cfg dto.OutboxConfig,
+	logger *zap.Logger,
+) portsworker.OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultOutboxPollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultOutboxBatchSize
+	}
+	return &OutboxRelayService{
+		repo:      repo,
+		publisher: publisher,
+		cfg:       cfg,
+		logger:    logger,
+	}
+}
+
+// Start runs the relay loop in a background goroutine until Stop is called
+func (s *OutboxRelayService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.cfg.PollInterval)
+		defer ticker.Stop()
+
+		var lastCleanup time.Time
+		for {
+			s.relay(ctx)
+			if s.cfg.Retention > 0 && time.Since(lastCleanup) >= outboxCleanupInterval {
+				s.cleanup(ctx)
+				lastCleanup = time.Now()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	s.logger.Info("Outbox relay started",
+		zap.Duration("poll_interval", s.cfg.PollInterval),
+		zap.Int("batch_size", s.cfg.BatchSize),
+	)
+}
+
+// Stop stops the relay loop and waits for the current batch to finish
+func (s *OutboxRelayService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.logger.Info("Outbox relay stopped")
+}
+
+// relay publishes pending messages batch by batch until the outbox is drained or publishing fails
+func (s *OutboxRelayService) relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := s.repo.PublishPending(ctx, s.cfg.BatchSize, func(msg *models.OutboxMessage) error {
+			return s.publisher.Publish(msg.MsgID, msg.Subject, msg.Payload, utils.HeadersFromJSONB(msg.Headers))
+		})
+		if published > 0 {
+			s.logger.Debug("Relayed outbox messages", zap.Int("count", published))
+		}
+		if err != nil {
+			s.logger.Error("Failed to relay outbox messages", zap.Error(err))
+			return
+		}
+		if published < s.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// cleanup deletes published messages older than the retention
+func (s *OutboxRelayService) cleanup(ctx context.Context) {
+	deleted, err := s.repo.DeletePublishedBefore(ctx, time.Now().Add(-s.cfg.Retention))
+	if err != nil {
+		s.logger.Error("Failed to clean up outbox", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		s.logger.Info("Cleaned up published outbox messages", zap.Int64("count", deleted))
+	}
+}
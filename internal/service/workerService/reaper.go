@@ -0,0 +1,260 @@
+package workerService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsk8s "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/k8s"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultReaperInterval     = time.Minute
+	defaultReaperMinAge       = 10 * time.Minute
+	defaultReaperMaxRepublish = 3
+	defaultReaperBatchSize    = 100
+)
+
+// ReaperService periodically repairs deployment requests left in CREATED because their
+// message was lost or their worker crashed mid-process. Each stale request is checked
+// against the cluster and either marked SUCCESS/FAILURE or republished through the outbox.
+type ReaperService struct {
+	deploymentRequestRepo portsdb.DeploymentRequest
+	publisher             portsqueue.DeploymentRequest
+	statusPublisher       portsqueue.DeploymentStatus
+	eventPublisher        portsqueue.WebhookEvent
+	k8sDeploymentManager  portsk8s.DeploymentManager
+	cfg                   dto.ReaperConfig
+	logger                *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewReaperService creates a new stuck-request reaper
+func NewReaperService(
+	deploymentRequestRepo portsdb.DeploymentRequest,
+	publisher portsqueue.DeploymentRequest,
+	statusPublisher portsqueue.DeploymentStatus,
+	eventPublisher portsqueue.WebhookEvent,
+	k8sDeploymentManager portsk8s.DeploymentManager,
+	cfg dto.ReaperConfig,
+	logger *zap.Logger,
+) portsworker.Reaper {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultReaperInterval
+	}
+	if cfg.MinAge <= 0 {
+		cfg.MinAge = defaultReaperMinAge
+	}
+	if cfg.MaxRepublish <= 0 {
+		cfg.MaxRepublish = defaultReaperMaxRepublish
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultReaperBatchSize
+	}
+	return &ReaperService{
+		deploymentRequestRepo: deploymentRequestRepo,
+		publisher:             publisher,
+		statusPublisher:       statusPublisher,
+		eventPublisher:        eventPublisher,
+		k8sDeploymentManager:  k8sDeploymentManager,
+		cfg:                   cfg,
+		logger:                logger,
+	}
+}
+
+// Start runs Reap every interval in a background goroutine until Stop is called
+func (s *ReaperService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			result, err := s.Reap(ctx)
+			if err != nil {
+				s.logger.Error("Reaper run failed", zap.Error(err))
+				continue
+			}
+			if result.Examined > 0 {
+				s.logger.Info("Reaper run complete",
+					zap.Int("examined", result.Examined),
+					zap.Int("repaired", result.Repaired()),
+					zap.Int("republished", result.Republished),
+					zap.Int("succeeded", result.Succeeded),
+					zap.Int("failed", result.Failed),
+					zap.Int("errors", result.Errors),
+				)
+			}
+		}
+	}()
+
+	s.logger.Info("Reaper started",
+		zap.Duration("interval", s.cfg.Interval),
+		zap.Duration("min_age", s.cfg.MinAge),
+	)
+}
+
+// Stop stops the reaper loop and waits for the current run to finish
+func (s *ReaperService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.logger.Info("Reaper stopped")
+}
+
+// Reap examines CREATED requests older than the minimum age and repairs each of them.
+// Requests that cannot be examined (e.g. the cluster is unreachable) are counted as errors
+// and left for the next run.
+func (s *ReaperService) Reap(ctx context.Context) (*dto.ReapResult, error) {
+	stale, err := s.deploymentRequestRepo.ListStale(ctx, time.Now().Add(-s.cfg.MinAge), s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("list stale deployment requests: %w", err)
+	}
+
+	result := &dto.ReapResult{Examined: len(stale)}
+	for _, req := range stale {
+		if err := s.reap(ctx, req, result); err != nil {
+			result.Errors++
+			s.logger.Warn("Failed to reap deployment request",
+				zap.String("request_id", req.RequestID),
+				zap.Error(err),
+			)
+		}
+	}
+	return result, nil
+}
+
+// reap decides the fate of one stale request from the state of its deployment in the cluster
+func (s *ReaperService) reap(ctx context.Context, req *models.DeploymentRequest, result *dto.ReapResult) error {
+	if req.WaitForRollout && time.Since(lastTouched(req)) < s.cfg.MinAge+rolloutTimeout(req) {
+		// A worker may still be waiting for the rollout
+		return nil
+	}
+	if req.Phase.IsCanaryInProgress() {
+		canary, err := requestCanary(req)
+		if err == nil && canary != nil && time.Since(lastTouched(req)) < s.cfg.MinAge+rolloutTimeout(req)+canarySoak(canary) {
+			// A worker may still be rolling out, soaking or promoting the canary
+			return nil
+		}
+	}
+
+	existing, found, err := s.k8sDeploymentManager.GetOptional(ctx, req.Namespace, req.Identifier)
+	if err != nil {
+		return fmt.Errorf("get deployment from cluster: %w", err)
+	}
+
+	switch req.RequestType {
+	case models.DeploymentRequestTypeCreate:
+		if found {
+			if existing.Labels[dto.LabelKeyRequestID] == req.RequestID {
+				if req.WaitForRollout {
+					// Applied, but the rollout was never confirmed; the worker re-applies and waits again
+					break
+				}
+				return s.markSuccess(ctx, req, result)
+			}
+			return s.markFailure(ctx, req, result, "deployment already exists in Kubernetes and was created by another request")
+		}
+	case models.DeploymentRequestTypeUpdate, models.DeploymentRequestTypeRollback,
+		models.DeploymentRequestTypeRestart, models.DeploymentRequestTypePause, models.DeploymentRequestTypeResume:
+		if !found {
+			return s.markFailure(ctx, req, result,
+				fmt.Sprintf("deployment not found in Kubernetes: namespace=%s, name=%s", req.Namespace, req.Name))
+		}
+	case models.DeploymentRequestTypeDelete:
+		if !found {
+			return s.markSuccess(ctx, req, result)
+		}
+	default:
+		return s.markFailure(ctx, req, result, fmt.Sprintf("unknown request type: %s", req.RequestType))
+	}
+
+	return s.republish(ctx, req, result)
+}
+
+// lastTouched returns when the request was last written (created or requeued)
+func lastTouched(req *models.DeploymentRequest) time.Time {
+	if req.UpdatedOn != nil {
+		return *req.UpdatedOn
+	}
+	return req.CreatedOn
+}
+
+// republish hands the request to the workers again through the outbox, or marks it FAILURE
+// once it has been republished the maximum number of times
+func (s *ReaperService) republish(ctx context.Context, req *models.DeploymentRequest, result *dto.ReapResult) error {
+	if req.ReapCount >= s.cfg.MaxRepublish {
+		return s.markFailure(ctx, req, result,
+			fmt.Sprintf("request was not processed after %d republishes", req.ReapCount))
+	}
+
+	msg, err := s.publisher.Message(req.RequestID, req.UserID.String())
+	if err != nil {
+		return fmt.Errorf("build deployment request message: %w", err)
+	}
+	// A distinct message ID per republish so the stream does not drop it as a duplicate
+	msg.MsgID = fmt.Sprintf("%s-reap-%d", req.RequestID, req.ReapCount+1)
+
+	requeued, err := s.deploymentRequestRepo.Requeue(ctx, req, msg)
+	if err != nil {
+		return fmt.Errorf("requeue deployment request: %w", err)
+	}
+	if !requeued {
+		// Another worker reaped or processed it in the meantime
+		return nil
+	}
+
+	result.Republished++
+	s.logger.Info("Reaper republished deployment request",
+		zap.String("request_id", req.RequestID),
+		zap.String("request_type", string(req.RequestType)),
+		zap.Int("republish", req.ReapCount+1),
+	)
+	return nil
+}
+
+func (s *ReaperService) markSuccess(ctx context.Context, req *models.DeploymentRequest, result *dto.ReapResult) error {
+	if err := setRequestStatus(ctx, s.deploymentRequestRepo, s.statusPublisher, s.eventPublisher, s.logger, req, models.DeploymentRequestStatusSuccess, nil); err != nil {
+		return fmt.Errorf("update status to SUCCESS: %w", err)
+	}
+	result.Succeeded++
+	s.logger.Info("Reaper marked deployment request SUCCESS",
+		zap.String("request_id", req.RequestID),
+		zap.String("request_type", string(req.RequestType)),
+	)
+	return nil
+}
+
+func (s *ReaperService) markFailure(ctx context.Context, req *models.DeploymentRequest, result *dto.ReapResult, reason string) error {
+	reason = "reaper: " + reason
+	if err := setRequestStatus(ctx, s.deploymentRequestRepo, s.statusPublisher, s.eventPublisher, s.logger, req, models.DeploymentRequestStatusFailure, &reason); err != nil {
+		return fmt.Errorf("update status to FAILURE: %w", err)
+	}
+	result.Failed++
+	s.logger.Info("Reaper marked deployment request FAILURE",
+		zap.String("request_id", req.RequestID),
+		zap.String("reason", reason),
+	)
+	return nil
+}
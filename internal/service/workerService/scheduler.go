@@ -0,0 +1,260 @@
+package workerService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"github.com/code-xd/k8s-deployment-manager/pkg/utils"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSchedulerInterval  = 30 * time.Second
+	defaultSchedulerBatchSize = 100
+)
+
+// SchedulerService periodically fires the scaling schedules whose next run has passed. Each run is
+// emitted as an UPDATE request through the outbox, like the requests made through the API, so it is
+// processed by the workers and recorded with the other requests of the deployment. Each run is checked
+// against the owner's quotas again, since they and the owner's other deployments may have changed since
+// the schedule was created.
+type SchedulerService struct {
+	scheduleRepo    portsdb.DeploymentSchedule
+	deploymentRepo  portsdb.Deployment
+	publisher       portsqueue.DeploymentRequest
+	statusPublisher portsqueue.DeploymentStatus
+	eventPublisher  portsqueue.WebhookEvent
+	admission       portsworker.ScheduleAdmission
+	cfg             dto.SchedulerConfig
+	logger          *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSchedulerService creates a new scaling schedule runner
+func NewSchedulerService(
+	scheduleRepo portsdb.DeploymentSchedule,
+	deploymentRepo portsdb.Deployment,
+	publisher portsqueue.DeploymentRequest,
+	statusPublisher portsqueue.DeploymentStatus,
+	eventPublisher portsqueue.WebhookEvent,
+	admission portsworker.ScheduleAdmission,
+	cfg dto.SchedulerConfig,
+	logger *zap.Logger,
+) portsworker.Scheduler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultSchedulerInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultSchedulerBatchSize
+	}
+	return &SchedulerService{
+		scheduleRepo:    scheduleRepo,
+		deploymentRepo:  deploymentRepo,
+		publisher:       publisher,
+		statusPublisher: statusPublisher,
+		eventPublisher:  eventPublisher,
+		admission:       admission,
+		cfg:             cfg,
+		logger:          logger,
+	}
+}
+
+// Start runs RunDue every interval in a background goroutine until Stop is called
+func (s *SchedulerService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			result, err := s.RunDue(ctx)
+			if err != nil {
+				s.logger.Error("Scheduler run failed", zap.Error(err))
+				continue
+			}
+			if result.Due > 0 {
+				s.logger.Info("Scheduler run complete",
+					zap.Int("due", result.Due),
+					zap.Int("fired", result.Fired),
+					zap.Int("rejected", result.Rejected),
+					zap.Int("removed", result.Removed),
+					zap.Int("errors", result.Errors),
+				)
+			}
+		}
+	}()
+
+	s.logger.Info("Scheduler started", zap.Duration("interval", s.cfg.Interval))
+}
+
+// Stop stops the scheduler loop and waits for the current run to finish
+func (s *SchedulerService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.logger.Info("Scheduler stopped")
+}
+
+// RunDue fires the schedules whose next run has passed. Schedules that cannot be fired (e.g. the database
+// is unreachable) are counted as errors and left for the next run.
+func (s *SchedulerService) RunDue(ctx context.Context) (*dto.ScheduleRunResult, error) {
+	now := time.Now().UTC()
+	due, err := s.scheduleRepo.ListDue(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("list due deployment schedules: %w", err)
+	}
+
+	result := &dto.ScheduleRunResult{Due: len(due)}
+	for _, schedule := range due {
+		if err := s.fire(ctx, schedule, now, result); err != nil {
+			result.Errors++
+			s.logger.Warn("Failed to fire deployment schedule",
+				zap.String("schedule_id", schedule.ID.String()),
+				zap.String("identifier", schedule.Identifier),
+				zap.Error(err),
+			)
+		}
+	}
+	return result, nil
+}
+
+// fire emits the UPDATE request of one due run and moves the schedule to its next run after now; runs
+// missed while no scheduler was running are not made up. A run on an autoscaled deployment or rejected by the
+// admission check is recorded as a FAILURE request with the reason and is not queued. The schedules of a deleted
+// deployment are removed.
+func (s *SchedulerService) fire(ctx context.Context, schedule *models.DeploymentSchedule, now time.Time, result *dto.ScheduleRunResult) error {
+	deployment, found, err := s.deploymentRepo.GetByIdentifier(ctx, schedule.Identifier)
+	if err != nil {
+		return fmt.Errorf("get deployment: %w", err)
+	}
+	if !found || deployment.Status == models.DeploymentStatusDeleted {
+		if err := s.scheduleRepo.DeleteByIdentifier(ctx, schedule.Identifier); err != nil {
+			return err
+		}
+		result.Removed++
+		s.logger.Info("Removed schedules of deleted deployment", zap.String("identifier", schedule.Identifier))
+		return nil
+	}
+
+	nextRunAt, err := utils.NextCronRun(schedule.Cron, schedule.Timezone, now)
+	if err != nil {
+		return fmt.Errorf("next run: %w", err)
+	}
+
+	// One request ID per run, so a run is never emitted twice
+	requestID := fmt.Sprintf("%s%s-%d", dto.ScheduleRequestIDPrefix, schedule.ID, schedule.NextRunAt.Unix())
+	scheduleID := schedule.ID
+	req := &models.DeploymentRequest{
+		RequestID:   requestID,
+		Identifier:  deployment.Identifier,
+		Name:        deployment.Name,
+		Namespace:   deployment.Namespace,
+		RequestType: models.DeploymentRequestTypeUpdate,
+		Status:      models.DeploymentRequestStatusCreated,
+		Image:       deployment.Image,
+		UserID:      schedule.UserID,
+		TeamID:      deployment.TeamID,
+		Metadata:    models.JSONB{"replica_count": schedule.ReplicaCount},
+		// The schedule states the replica count the deployment must have, whoever scaled it since
+		ForceOwnership: true,
+		ScheduleID:     &scheduleID,
+	}
+
+	// The worker would leave the replica count to the autoscaler and still report the run as a success
+	if deployment.AutoscalingMaxReplicas != nil {
+		reason := fmt.Errorf("%w: replica_count cannot be set while the deployment is autoscaled", dto.ErrInvalidAutoscaling)
+		return s.reject(ctx, schedule, nextRunAt, req, reason.Error(), result)
+	}
+
+	replicaCount := schedule.ReplicaCount
+	err = s.admission.AdmitUpdate(ctx, deployment, &dto.UpdateDeploymentRequestMetadata{ReplicaCount: &replicaCount})
+	var violation *dto.AdmissionViolation
+	if errors.As(err, &violation) {
+		return s.reject(ctx, schedule, nextRunAt, req, violation.Error(), result)
+	}
+	if err != nil {
+		return fmt.Errorf("admission: %w", err)
+	}
+
+	msg, err := s.publisher.Message(requestID, schedule.UserID.String())
+	if err != nil {
+		return fmt.Errorf("build deployment request message: %w", err)
+	}
+
+	fired, err := s.scheduleRepo.Fire(ctx, schedule, nextRunAt, req, msg)
+	if err != nil {
+		return err
+	}
+	if !fired {
+		// Another scheduler fired this run
+		return nil
+	}
+	result.Fired++
+	s.logger.Info("Deployment schedule fired",
+		zap.String("schedule_id", schedule.ID.String()),
+		zap.String("identifier", schedule.Identifier),
+		zap.String("request_id", requestID),
+		zap.Int("replica_count", schedule.ReplicaCount),
+		zap.Time("next_run_at", nextRunAt),
+	)
+	return nil
+}
+
+// reject records a run that must not be applied as a FAILURE request with reason and moves the schedule to
+// its next run. The request is not queued; like any completed request it is announced to webhooks through
+// an outbox message stored in the same transaction, and to the status streams once stored.
+func (s *SchedulerService) reject(
+	ctx context.Context,
+	schedule *models.DeploymentSchedule,
+	nextRunAt time.Time,
+	req *models.DeploymentRequest,
+	reason string,
+	result *dto.ScheduleRunResult,
+) error {
+	req.Status = models.DeploymentRequestStatusFailure
+	req.FailureReason = &reason
+	msg, err := requestCompletionMessage(s.eventPublisher, req, req.Status, req.FailureReason)
+	if err != nil {
+		return err
+	}
+
+	fired, err := s.scheduleRepo.Fire(ctx, schedule, nextRunAt, req, msg)
+	if err != nil {
+		return err
+	}
+	if !fired {
+		return nil
+	}
+	publishRequestStatus(s.statusPublisher, s.logger, req, req.Status, req.FailureReason)
+	result.Rejected++
+	s.logger.Warn("Deployment schedule run rejected",
+		zap.String("schedule_id", schedule.ID.String()),
+		zap.String("identifier", schedule.Identifier),
+		zap.String("request_id", req.RequestID),
+		zap.String("reason", reason),
+		zap.Time("next_run_at", nextRunAt),
+	)
+	return nil
+}
@@ -0,0 +1,150 @@
+package workerService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsqueue "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/queue"
+	"go.uber.org/zap"
+)
+
+// setRequestStatus stores the status of a deployment request, then publishes the transition for the status
+// streams of the API. Publishing is best effort: the streams re-read the request periodically, so a failed
+// publish is only logged.
+//
+// A terminal status is also announced as a webhook event, through an outbox message stored in the same
+// transaction as the status, so the event is neither lost nor sent for an outcome that was not stored.
+//
+// The write only applies to a request that is still CREATED: when a duplicate delivery already completed
+// it, the stored outcome is kept and neither the transition nor the webhook event is published.
+func setRequestStatus(
+	ctx context.Context,
+	repo portsdb.DeploymentRequest,
+	publisher portsqueue.DeploymentStatus,
+	events portsqueue.WebhookEvent,
+	logger *zap.Logger,
+	req *models.DeploymentRequest,
+	status models.DeploymentRequestStatus,
+	failureReason *string,
+) error {
+	msg, err := requestCompletionMessage(events, req, status, failureReason)
+	if err != nil {
+		return err
+	}
+
+	updated, err := repo.CompleteStatus(ctx, req.ID, status, failureReason, msg)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		logger.Info("Deployment request was already completed by another delivery, keeping its status",
+			zap.String("request_id", req.RequestID),
+			zap.String("status", string(status)),
+		)
+		return nil
+	}
+
+	publishRequestStatus(publisher, logger, req, status, failureReason)
+	return nil
+}
+
+// requestCompletionMessage builds the outbox message of the webhook event announcing a terminal status, to be
+// stored in the transaction that stores the status; nil for other statuses or without an event channel
+func requestCompletionMessage(
+	events portsqueue.WebhookEvent,
+	req *models.DeploymentRequest,
+	status models.DeploymentRequestStatus,
+	failureReason *string,
+) (*models.OutboxMessage, error) {
+	if !status.IsTerminal() {
+		return nil, nil
+	}
+	msg, err := events.Message(requestWebhookEvent(req, status, failureReason))
+	if err != nil {
+		return nil, fmt.Errorf("build webhook event message: %w", err)
+	}
+	return msg, nil
+}
+
+// publishRequestStatus publishes a stored status of a request for the status streams of the API, best effort
+func publishRequestStatus(
+	publisher portsqueue.DeploymentStatus,
+	logger *zap.Logger,
+	req *models.DeploymentRequest,
+	status models.DeploymentRequestStatus,
+	failureReason *string,
+) {
+	event := &dto.DeploymentStatusEvent{
+		RequestID:     req.RequestID,
+		Status:        string(status),
+		Phase:         string(req.Phase),
+		FailureReason: failureReason,
+		Timestamp:     time.Now().UTC(),
+	}
+	if err := publisher.Publish(event); err != nil {
+		logger.Warn("Failed to publish deployment status event",
+			zap.String("request_id", req.RequestID),
+			zap.String("status", string(status)),
+			zap.Error(err),
+		)
+	}
+}
+
+// setRequestPhase stores the phase of a request that is still CREATED and publishes it for the status
+// streams of the API, best effort like setRequestStatus.
+func setRequestPhase(
+	ctx context.Context,
+	repo portsdb.DeploymentRequest,
+	publisher portsqueue.DeploymentStatus,
+	logger *zap.Logger,
+	req *models.DeploymentRequest,
+	phase models.DeploymentRequestPhase,
+) error {
+	if err := repo.UpdatePhase(ctx, req.ID, phase); err != nil {
+		return err
+	}
+	req.Phase = phase
+
+	event := &dto.DeploymentStatusEvent{
+		RequestID: req.RequestID,
+		Status:    string(req.Status),
+		Phase:     string(phase),
+		Timestamp: time.Now().UTC(),
+	}
+	if err := publisher.Publish(event); err != nil {
+		logger.Warn("Failed to publish deployment status event",
+			zap.String("request_id", req.RequestID),
+			zap.String("phase", string(phase)),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// requestWebhookEvent builds the completion event of a request moving to a terminal status
+func requestWebhookEvent(req *models.DeploymentRequest, status models.DeploymentRequestStatus, failureReason *string) *dto.WebhookEvent {
+	eventType := dto.EventRequestSucceeded
+	if status == models.DeploymentRequestStatusFailure {
+		eventType = dto.EventRequestFailed
+	}
+	return &dto.WebhookEvent{
+		ID:         req.RequestID + ":" + string(status),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		UserID:     req.UserID,
+		TeamID:     req.TeamID,
+		Request: &dto.WebhookEventRequest{
+			RequestID:     req.RequestID,
+			RequestType:   string(req.RequestType),
+			Identifier:    req.Identifier,
+			Name:          req.Name,
+			Namespace:     req.Namespace,
+			Status:        string(status),
+			FailureReason: failureReason,
+		},
+	}
+}
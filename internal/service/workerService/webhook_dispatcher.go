@@ -0,0 +1,244 @@
+package workerService
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portswebhook "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/webhook"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookInterval       = 5 * time.Second
+	defaultWebhookBatchSize      = 50
+	defaultWebhookRequestTimeout = 10 * time.Second
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookInitialDelay   = 10 * time.Second
+	defaultWebhookMultiplier     = 2.0
+	defaultWebhookMaxDelay       = time.Hour
+)
+
+// WebhookDispatcherService periodically POSTs due webhook deliveries and records each attempt. A failed
+// delivery is retried with exponential backoff until it succeeds or uses up its attempts.
+type WebhookDispatcherService struct {
+	webhookRepo portsdb.Webhook
+	sender      portswebhook.Sender
+	cfg         dto.WebhookConfig
+	backoff     consumer.Backoff
+	logger      *zap.Logger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWebhookDispatcherService creates a new webhook delivery dispatcher
+func NewWebhookDispatcherService(
+	webhookRepo portsdb.Webhook,
+	sender portswebhook.Sender,
+	cfg dto.WebhookConfig,
+	logger *zap.Logger,
+) portsworker.WebhookDispatcher {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultWebhookInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaultWebhookRequestTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+
+	backoff := consumer.Backoff{
+		InitialDelay: defaultWebhookInitialDelay,
+		Multiplier:   defaultWebhookMultiplier,
+		MaxDelay:     defaultWebhookMaxDelay,
+	}
+	if b := cfg.Backoff.InitialDelay; b != nil {
+		backoff.InitialDelay = *b
+	}
+	if b := cfg.Backoff.Multiplier; b != nil {
+		backoff.Multiplier = *b
+	}
+	if b := cfg.Backoff.MaxDelay; b != nil {
+		backoff.MaxDelay = *b
+	}
+	if b := cfg.Backoff.Jitter; b != nil {
+		backoff.Jitter = *b
+	}
+
+	return &WebhookDispatcherService{
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		cfg:         cfg,
+		backoff:     backoff,
+		logger:      logger,
+	}
+}
+
+// Start runs Dispatch every interval in a background goroutine until Stop is called
+func (s *WebhookDispatcherService) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			result, err := s.Dispatch(ctx)
+			if err != nil {
+				s.logger.Error("Webhook dispatch run failed", zap.Error(err))
+				continue
+			}
+			if result.Sent > 0 {
+				s.logger.Info("Webhook dispatch run complete",
+					zap.Int("sent", result.Sent),
+					zap.Int("delivered", result.Delivered),
+					zap.Int("retrying", result.Retrying),
+					zap.Int("failed", result.Failed),
+					zap.Int("errors", result.Errors),
+				)
+			}
+		}
+	}()
+
+	s.logger.Info("Webhook dispatcher started",
+		zap.Duration("interval", s.cfg.Interval),
+		zap.Int("max_attempts", s.cfg.MaxAttempts),
+	)
+}
+
+// Stop stops the dispatcher loop and waits for the current run to finish
+func (s *WebhookDispatcherService) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	s.logger.Info("Webhook dispatcher stopped")
+}
+
+// Dispatch claims due deliveries and sends them one after another. A claim leases the deliveries for as long
+// as sending the whole batch may take (a request timeout per delivery, plus one for recording the attempts),
+// so a dispatcher that dies mid-run leaves them to be sent again once the lease expires. Deliveries the run
+// has no time left for before the lease ends are not sent; they are claimed again after it.
+func (s *WebhookDispatcherService) Dispatch(ctx context.Context) (*dto.DispatchResult, error) {
+	lease := time.Duration(s.cfg.BatchSize+1) * s.cfg.RequestTimeout
+	leaseEnd := time.Now().Add(lease)
+	deliveries, err := s.webhookRepo.ClaimDueDeliveries(ctx, s.cfg.BatchSize, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim due webhook deliveries: %w", err)
+	}
+	result := &dto.DispatchResult{}
+	if len(deliveries) == 0 {
+		return result, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionID)
+	}
+	subscriptions, err := s.webhookRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	byID := make(map[uuid.UUID]*models.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		byID[subscription.ID] = subscription
+	}
+
+	for i, delivery := range deliveries {
+		// Another dispatcher may claim the delivery once the lease ends
+		if time.Until(leaseEnd) < s.cfg.RequestTimeout {
+			s.logger.Warn("Webhook delivery lease running out, leaving the rest of the batch",
+				zap.Int("remaining", len(deliveries)-i),
+			)
+			break
+		}
+		subscription, ok := byID[delivery.SubscriptionID]
+		if !ok {
+			// Deleted since the claim; its deliveries are gone with it
+			continue
+		}
+		result.Sent++
+		if err := s.send(ctx, subscription, delivery, result); err != nil {
+			result.Errors++
+			s.logger.Warn("Failed to record webhook delivery attempt",
+				zap.String("delivery_id", delivery.ID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+	return result, nil
+}
+
+// send POSTs the delivery once and records the attempt: SUCCESS on a 2xx response, otherwise PENDING with the
+// next attempt pushed back, or FAILURE once the attempts are used up
+func (s *WebhookDispatcherService) send(
+	ctx context.Context,
+	subscription *models.WebhookSubscription,
+	delivery *models.WebhookDelivery,
+	result *dto.DispatchResult,
+) error {
+	sendCtx, cancel := context.WithTimeout(ctx, s.cfg.RequestTimeout)
+	start := time.Now()
+	statusCode, sendErr := s.sender.Send(sendCtx, subscription.URL, subscription.Secret, delivery.EventType, delivery.ID.String(), delivery.Payload)
+	cancel()
+	now := time.Now()
+
+	delivery.Attempts++
+	attempt := &models.WebhookDeliveryAttempt{
+		DeliveryID:     delivery.ID,
+		Attempt:        delivery.Attempts,
+		DurationMillis: now.Sub(start).Milliseconds(),
+	}
+	delivery.LastStatusCode = nil
+	delivery.LastError = nil
+	if statusCode != 0 {
+		attempt.StatusCode = &statusCode
+		delivery.LastStatusCode = &statusCode
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookDeliveryStatusSuccess
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredOn = &now
+		result.Delivered++
+	case delivery.Attempts >= s.cfg.MaxAttempts:
+		errMsg := sendErr.Error()
+		attempt.Error = &errMsg
+		delivery.LastError = &errMsg
+		delivery.Status = models.WebhookDeliveryStatusFailure
+		delivery.NextAttemptAt = nil
+		result.Failed++
+	default:
+		errMsg := sendErr.Error()
+		attempt.Error = &errMsg
+		delivery.LastError = &errMsg
+		next := now.Add(s.backoff.Delay(delivery.Attempts - 1))
+		delivery.NextAttemptAt = &next
+		result.Retrying++
+	}
+
+	return s.webhookRepo.RecordAttempt(ctx, delivery, attempt)
+}
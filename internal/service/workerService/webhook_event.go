@@ -0,0 +1,98 @@
+package workerService
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	portsdb "github.com/code-xd/k8s-deployment-manager/pkg/ports/repo/db"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"go.uber.org/zap"
+)
+
+// WebhookEventService implements worker-side fan-out of webhook events to subscriptions
+type WebhookEventService struct {
+	webhookRepo portsdb.Webhook
+	teamRepo    portsdb.Team
+	logger      *zap.Logger
+}
+
+// NewWebhookEventService creates a new worker webhook event service
+func NewWebhookEventService(
+	webhookRepo portsdb.Webhook,
+	teamRepo portsdb.Team,
+	logger *zap.Logger,
+) portsworker.WebhookEvent {
+	return &WebhookEventService{
+		webhookRepo: webhookRepo,
+		teamRepo:    teamRepo,
+		logger:      logger,
+	}
+}
+
+// ProcessWebhookEvent stores a PENDING delivery, due now, for every subscription of the event's owner that
+// subscribes to its type. Team subscriptions whose creator has left the team are skipped. Deliveries already
+// stored for the event are kept, so a redelivered message does not send the event twice.
+func (s *WebhookEventService) ProcessWebhookEvent(ctx context.Context, event *dto.WebhookEvent) error {
+	subscriptions, err := s.webhookRepo.ListByOwner(ctx, event.UserID, event.TeamID)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal webhook event: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]*models.WebhookDelivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if !subscribesTo(subscription, event.Type) {
+			continue
+		}
+		if subscription.TeamID != nil {
+			_, member, err := s.teamRepo.GetMembership(ctx, *subscription.TeamID, subscription.UserID)
+			if err != nil {
+				return fmt.Errorf("get team membership: %w", err)
+			}
+			if !member {
+				continue
+			}
+		}
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+			Status:         models.WebhookDeliveryStatusPending,
+			NextAttemptAt:  &now,
+		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("create webhook deliveries: %w", err)
+	}
+	s.logger.Info("Queued webhook deliveries",
+		zap.String("event_id", event.ID),
+		zap.String("event_type", event.Type),
+		zap.Int("deliveries", len(deliveries)),
+	)
+	return nil
+}
+
+// subscribesTo reports whether the subscription lists the event type
+func subscribesTo(subscription *models.WebhookSubscription, eventType string) bool {
+	for _, t := range strings.Fields(subscription.EventTypes) {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,7 @@ package watcher
 import (
 	"context"
 
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	portswatcher "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/watcherService"
 	"github.com/code-xd/k8s-deployment-manager/pkg/routedinformer"
 	"go.uber.org/zap"
@@ -25,11 +26,12 @@ func NewHandler(watcherService portswatcher.WatcherService, logger *zap.Logger)
 
 // Handle is the DeploymentEventHandler: it extracts namespace, name, and eventType and publishes via WatcherService
 func (h *Handler) Handle(ctx context.Context, eventType routedinformer.DeploymentEventType, deployment *appsv1.Deployment) {
-	if deployment == nil {
+	// Canary deployments are run by the worker for a request and are not deployments of their own
+	if deployment == nil || deployment.Labels[dto.LabelKeyTrack] == dto.TrackCanary {
 		return
 	}
 	eventTypeStr := eventType.String()
-	if err := h.watcherService.PublishDeploymentUpdate(ctx, deployment.Namespace, deployment.Name, eventTypeStr); err != nil {
+	if err := h.watcherService.PublishDeploymentUpdate(ctx, deployment.Namespace, deployment.Name, deployment.ResourceVersion, eventTypeStr); err != nil {
 		h.logger.Error("Failed to publish deployment update",
 			zap.String("namespace", deployment.Namespace),
 			zap.String("name", deployment.Name),
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"go.uber.org/zap"
+)
+
+// DeadLetterHandler handles messages from the dead-letter NATS channel
+type DeadLetterHandler struct {
+	deadLetter portsworker.DeadLetter
+	log        *zap.Logger
+}
+
+// NewDeadLetterHandler creates a new dead-letter handler
+func NewDeadLetterHandler(deadLetter portsworker.DeadLetter, log *zap.Logger) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		deadLetter: deadLetter,
+		log:        log,
+	}
+}
+
+// Handle processes a dead-lettered message: extracts dead-letter headers and stream sequence, passes to service
+func (h *DeadLetterHandler) Handle(ctx context.Context, msg *consumer.Message) error {
+	if msg.RawMsg == nil {
+		return fmt.Errorf("dead-letter handler requires the raw NATS message")
+	}
+
+	meta, err := msg.RawMsg.Metadata()
+	if err != nil {
+		h.log.Error("Failed to read dead-letter message metadata", zap.Error(err))
+		return err
+	}
+
+	headers := consumer.HeadersFromContext(ctx)
+	attempts, _ := strconv.Atoi(headers.Get(consumer.HeaderDeadLetterAttempts))
+
+	body := &dto.DeadLetterMessage{
+		Subject:         msg.RawMsg.Subject,
+		Stream:          meta.Stream,
+		Sequence:        meta.Sequence.Stream,
+		StoredOn:        meta.Timestamp.UTC(),
+		OriginalSubject: headers.Get(consumer.HeaderDeadLetterOriginalSubject),
+		Attempts:        attempts,
+		LastError:       headers.Get(consumer.HeaderDeadLetterError),
+		Payload:         msg.Data,
+		Headers:         headers,
+	}
+	if body.OriginalSubject == "" {
+		h.log.Error("Dead-letter message missing original subject header", zap.Uint64("sequence", body.Sequence))
+		return fmt.Errorf("dead-letter message missing %s header", consumer.HeaderDeadLetterOriginalSubject)
+	}
+
+	return h.deadLetter.ProcessDeadLetter(ctx, body)
+}
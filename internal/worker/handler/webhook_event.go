@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/consumer"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	portsworker "github.com/code-xd/k8s-deployment-manager/pkg/ports/service/workerService"
+	"go.uber.org/zap"
+)
+
+// WebhookEventHandler handles webhook events from the deployment event channel
+type WebhookEventHandler struct {
+	webhookEvent portsworker.WebhookEvent
+	log          *zap.Logger
+}
+
+// NewWebhookEventHandler creates a new webhook event handler
+func NewWebhookEventHandler(webhookEvent portsworker.WebhookEvent, log *zap.Logger) *WebhookEventHandler {
+	return &WebhookEventHandler{
+		webhookEvent: webhookEvent,
+		log:          log,
+	}
+}
+
+// Handle processes a webhook event: unmarshals body, passes to service
+func (h *WebhookEventHandler) Handle(ctx context.Context, msg *consumer.Message) error {
+	var body dto.WebhookEvent
+	if err := json.Unmarshal(msg.Data, &body); err != nil {
+		h.log.Error("Failed to unmarshal webhook event", zap.Error(err))
+		return err
+	}
+
+	return h.webhookEvent.ProcessWebhookEvent(ctx, &body)
+}
@@ -15,10 +15,12 @@ func SetupRouter(
 	cfg *dto.ConsumerConfig,
 	deploymentRequest portsworker.DeploymentRequest,
 	deploymentUpdate portsworker.DeploymentUpdate,
+	deadLetter portsworker.DeadLetter,
 	log *zap.Logger,
 ) {
 	registerDeploymentRequestRoutes(nc, cfg, deploymentRequest, log)
 	registerDeploymentUpdateRoutes(nc, cfg, deploymentUpdate, log)
+	registerDeadLetterRoutes(nc, cfg, deadLetter, log)
 }
 
 // registerDeploymentRequestRoutes initializes the deployment request handler and registers its route
@@ -31,21 +33,7 @@ func registerDeploymentRequestRoutes(
 	deploymentRequestHandler := handler.NewDeploymentRequestHandler(deploymentRequest, log)
 
 	taskCfg := cfg.DeploymentRequestTask
-	channel := taskCfg.Channel
-	queueGroup := taskCfg.QueueGroup
-	if queueGroup == "" {
-		queueGroup = dto.QueueGroupDeploymentWorkers
-	}
-
-	opts := []consumer.OptionFunc{}
-	if taskCfg.TaskTimeout != nil && *taskCfg.TaskTimeout > 0 {
-		opts = append(opts, consumer.TaskTimeout(*taskCfg.TaskTimeout))
-	}
-	if taskCfg.RetryCount != nil && *taskCfg.RetryCount >= 0 {
-		opts = append(opts, consumer.RetryCount(*taskCfg.RetryCount))
-	}
-
-	nc.Route(channel, queueGroup, deploymentRequestHandler.Handle, opts...)
+	nc.Route(taskCfg.Channel, queueGroup(taskCfg, dto.QueueGroupDeploymentWorkers), deploymentRequestHandler.Handle, routeOptions(taskCfg)...)
 }
 
 // registerDeploymentUpdateRoutes initializes the deployment update handler and registers its route
@@ -58,12 +46,54 @@ func registerDeploymentUpdateRoutes(
 	deploymentUpdateHandler := handler.NewDeploymentUpdateHandler(deploymentUpdate, log)
 
 	taskCfg := cfg.DeploymentUpdateTask
-	channel := taskCfg.Channel
-	queueGroup := taskCfg.QueueGroup
-	if queueGroup == "" {
-		queueGroup = dto.QueueGroupDeploymentWorkers
+	nc.Route(taskCfg.Channel, queueGroup(taskCfg, dto.QueueGroupDeploymentUpdateWorkers), deploymentUpdateHandler.Handle, routeOptions(taskCfg)...)
+}
+
+// registerDeadLetterRoutes initializes the dead-letter handler and registers its route.
+// Skipped when no dead-letter channel is configured.
+func registerDeadLetterRoutes(
+	nc *consumer.NATSConsumer,
+	cfg *dto.ConsumerConfig,
+	deadLetter portsworker.DeadLetter,
+	log *zap.Logger,
+) {
+	taskCfg := cfg.DeadLetterTask
+	if taskCfg.Channel == "" {
+		log.Info("No dead-letter channel configured, skipping dead-letter route")
+		return
 	}
 
+	// The dead-letter route must never dead-letter its own messages
+	taskCfg.DeadLetterChannel = ""
+
+	deadLetterHandler := handler.NewDeadLetterHandler(deadLetter, log)
+	nc.Route(taskCfg.Channel, queueGroup(taskCfg, dto.QueueGroupDeadLetterWorkers), deadLetterHandler.Handle, routeOptions(taskCfg)...)
+}
+
+// SetupWebhookRouter registers the webhook event route of the webhook worker on the NATS consumer
+func SetupWebhookRouter(
+	nc *consumer.NATSConsumer,
+	cfg *dto.WebhookConfig,
+	webhookEvent portsworker.WebhookEvent,
+	log *zap.Logger,
+) {
+	webhookEventHandler := handler.NewWebhookEventHandler(webhookEvent, log)
+
+	taskCfg := cfg.EventTask
+	nc.Route(taskCfg.Channel, queueGroup(taskCfg, dto.QueueGroupWebhookWorkers), webhookEventHandler.Handle, routeOptions(taskCfg)...)
+}
+
+// queueGroup returns the configured queue group, falling back to the route's own default so that
+// no two routes share a queue group (and with it a durable consumer name)
+func queueGroup(taskCfg dto.ConsumerTypeConfig, fallback string) string {
+	if taskCfg.QueueGroup == "" {
+		return fallback
+	}
+	return taskCfg.QueueGroup
+}
+
+// routeOptions builds consumer options from the optional per-task config values
+func routeOptions(taskCfg dto.ConsumerTypeConfig) []consumer.OptionFunc {
 	opts := []consumer.OptionFunc{}
 	if taskCfg.TaskTimeout != nil && *taskCfg.TaskTimeout > 0 {
 		opts = append(opts, consumer.TaskTimeout(*taskCfg.TaskTimeout))
@@ -71,6 +101,45 @@ func registerDeploymentUpdateRoutes(
 	if taskCfg.RetryCount != nil && *taskCfg.RetryCount >= 0 {
 		opts = append(opts, consumer.RetryCount(*taskCfg.RetryCount))
 	}
-
-	nc.Route(channel, queueGroup, deploymentUpdateHandler.Handle, opts...)
+	if b := taskCfg.Backoff; b != nil {
+		if b.InitialDelay != nil {
+			opts = append(opts, consumer.BackoffInitialDelay(*b.InitialDelay))
+		}
+		if b.Multiplier != nil {
+			opts = append(opts, consumer.BackoffMultiplier(*b.Multiplier))
+		}
+		if b.MaxDelay != nil {
+			opts = append(opts, consumer.BackoffMaxDelay(*b.MaxDelay))
+		}
+		if b.Jitter != nil {
+			opts = append(opts, consumer.BackoffJitter(*b.Jitter))
+		}
+	}
+	if taskCfg.DeadLetterChannel != "" {
+		opts = append(opts, consumer.DeadLetter(taskCfg.DeadLetterChannel))
+	}
+	if p := taskCfg.Pull; p != nil {
+		if p.Durable != "" {
+			opts = append(opts, consumer.Durable(p.Durable))
+		}
+		if p.BatchSize != nil {
+			opts = append(opts, consumer.BatchSize(*p.BatchSize))
+		}
+		if p.FetchTimeout != nil {
+			opts = append(opts, consumer.FetchTimeout(*p.FetchTimeout))
+		}
+		if p.AckWait != nil {
+			opts = append(opts, consumer.AckWait(*p.AckWait))
+		}
+		if p.MaxDeliver != nil {
+			opts = append(opts, consumer.MaxDeliver(*p.MaxDeliver))
+		}
+		if p.MaxAckPending != nil {
+			opts = append(opts, consumer.MaxAckPending(*p.MaxAckPending))
+		}
+		if p.MaxInFlight != nil {
+			opts = append(opts, consumer.MaxInFlight(*p.MaxInFlight))
+		}
+	}
+	return opts
 }
@@ -2,4 +2,6 @@ package constants
 
 const (
 	APP_ENV_VAR = "APP_ENV"
+	// JWT_SECRET_ENV_VAR overrides auth.jwt.secret, so the secret need not be kept in the config file
+	JWT_SECRET_ENV_VAR = "AUTH_JWT_SECRET"
 )
@@ -0,0 +1,36 @@
+package consumer
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff holds the redelivery delay policy for a route. The delay before retry n
+// (0-based) is InitialDelay * Multiplier^n, capped at MaxDelay, then spread by
+// ±Jitter (a fraction of the delay) so that failing messages do not retry in lockstep.
+type Backoff struct {
+	InitialDelay time.Duration
+	Multiplier   float64
+	MaxDelay     time.Duration
+	Jitter       float64
+}
+
+// Delay returns the redelivery delay after the given (0-based) failed attempt.
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b.InitialDelay <= 0 {
+		return 0
+	}
+
+	d := float64(b.InitialDelay) * math.Pow(b.Multiplier, float64(attempt))
+	if b.MaxDelay > 0 && d > float64(b.MaxDelay) {
+		d = float64(b.MaxDelay)
+	}
+	if b.Jitter > 0 {
+		d += d * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if d < 0 {
+		return 0
+	}
+	return time.Duration(d)
+}
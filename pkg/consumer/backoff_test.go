@@ -0,0 +1,102 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "first attempt uses the initial delay",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute},
+			attempt: 0,
+			want:    time.Second,
+		},
+		{
+			name:    "grows by the multiplier",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute},
+			attempt: 3,
+			want:    8 * time.Second,
+		},
+		{
+			name:    "capped at the max delay",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: 10 * time.Second},
+			attempt: 10,
+			want:    10 * time.Second,
+		},
+		{
+			name:    "no cap without a max delay",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 3},
+			attempt: 4,
+			want:    81 * time.Second,
+		},
+		{
+			name:    "multiplier of one keeps the delay constant",
+			backoff: Backoff{InitialDelay: 500 * time.Millisecond, Multiplier: 1, MaxDelay: time.Minute},
+			attempt: 7,
+			want:    500 * time.Millisecond,
+		},
+		{
+			name:    "no initial delay means no delay",
+			backoff: Backoff{Multiplier: 2, MaxDelay: time.Minute, Jitter: 0.5},
+			attempt: 2,
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.backoff.Delay(tt.attempt); got != tt.want {
+				t.Errorf("Delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelayJitter(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  Backoff
+		attempt  int
+		min, max time.Duration
+	}{
+		{
+			name:    "spread around the base delay",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute, Jitter: 0.2},
+			attempt: 2,
+			min:     3200 * time.Millisecond,
+			max:     4800 * time.Millisecond,
+		},
+		{
+			name:    "applied after the cap",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: 10 * time.Second, Jitter: 0.1},
+			attempt: 20,
+			min:     9 * time.Second,
+			max:     11 * time.Second,
+		},
+		{
+			name:    "full jitter never goes negative",
+			backoff: Backoff{InitialDelay: time.Second, Multiplier: 2, MaxDelay: time.Minute, Jitter: 1},
+			attempt: 0,
+			min:     0,
+			max:     2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				got := tt.backoff.Delay(tt.attempt)
+				if got < tt.min || got > tt.max {
+					t.Fatalf("Delay(%d) = %v, want within [%v, %v]", tt.attempt, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
@@ -6,8 +6,27 @@ import "time"
 type contextKey string
 
 const (
-	headerContextKey       contextKey = "nats.headers"
-	lastAttemptContextKey  contextKey = "consumer.last_attempt"
-	defaultTaskTimeout                = time.Minute
-	defaultRetryCount                 = 1
+	headerContextKey           contextKey = "nats.headers"
+	lastAttemptContextKey      contextKey = "consumer.last_attempt"
+	defaultTaskTimeout                    = time.Minute
+	defaultRetryCount                     = 1
+	defaultBackoffInitialDelay            = time.Second
+	defaultBackoffMultiplier              = 2.0
+	defaultBackoffMaxDelay                = time.Minute
+	defaultBackoffJitter                  = 0.2
+	defaultBatchSize                      = 10
+	defaultFetchTimeout                   = 5 * time.Second
+	defaultAckWait                        = 30 * time.Second
+	defaultMaxInFlight                    = 10
+)
+
+// Header keys added to messages republished to a route's dead-letter subject.
+// All original headers are preserved alongside these.
+const (
+	// HeaderDeadLetterOriginalSubject is the subject the message was originally consumed from.
+	HeaderDeadLetterOriginalSubject = "Dead-Letter-Original-Subject"
+	// HeaderDeadLetterAttempts is the number of handler attempts made before dead-lettering.
+	HeaderDeadLetterAttempts = "Dead-Letter-Attempts"
+	// HeaderDeadLetterError is the error returned by the handler on the last attempt.
+	HeaderDeadLetterError = "Dead-Letter-Error"
 )
@@ -2,7 +2,9 @@ package consumer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -10,8 +12,9 @@ import (
 	"go.uber.org/zap"
 )
 
-// NATSConsumer subscribes to NATS JetStream subjects with queue groups,
-// runs handlers with configurable timeout and retries, and supports graceful shutdown.
+// NATSConsumer consumes NATS JetStream subjects through durable pull consumers shared
+// by all workers of a route, runs handlers with configurable timeout, concurrency and
+// retries (JetStream redelivery with exponential backoff), and supports graceful shutdown.
 type NATSConsumer struct {
 	js     nats.JetStreamContext
 	conn   *nats.Conn
@@ -19,6 +22,9 @@ type NATSConsumer struct {
 
 	mu              sync.Mutex
 	wg              sync.WaitGroup
+	fetchWg         sync.WaitGroup
+	ctx             context.Context
+	cancel          context.CancelFunc
 	routes          []*RouteConfig
 	subs            []*nats.Subscription
 	shutdownTimeout time.Duration
@@ -30,20 +36,24 @@ func NewNATSConsumer(js nats.JetStreamContext, conn *nats.Conn, logger *zap.Logg
 	if logger == nil {
 		logger = zap.NewNop()
 	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &NATSConsumer{
 		js:              js,
 		conn:            conn,
 		logger:          logger,
+		ctx:             ctx,
+		cancel:          cancel,
 		routes:          nil,
 		subs:            nil,
 		shutdownTimeout: shutdownTimeout,
 	}
 }
 
-// Route registers a route: when Run() is called, the consumer will subscribe to
-// channel with the given queue group and invoke handler for each message.
-// Options (e.g. TaskTimeout, RetryCount) are applied to this route only.
-// Route must be called before Run().
+// Route registers a route: when Run() is called, the consumer will pull messages
+// published to channel from a durable consumer (named after the queue group unless
+// Durable is set) and invoke handler for each message.
+// Options (e.g. TaskTimeout, RetryCount, Backoff*, DeadLetter, BatchSize, AckWait) are
+// applied to this route only. Route must be called before Run().
 func (c *NATSConsumer) Route(channel, queueGroupName string, handler HandlerFunc, opts ...OptionFunc) {
 	cfg := &RouteConfig{
 		Channel:     channel,
@@ -51,6 +61,17 @@ func (c *NATSConsumer) Route(channel, queueGroupName string, handler HandlerFunc
 		Handler:     handler,
 		TaskTimeout: defaultTaskTimeout,
 		RetryCount:  defaultRetryCount,
+		Backoff: Backoff{
+			InitialDelay: defaultBackoffInitialDelay,
+			Multiplier:   defaultBackoffMultiplier,
+			MaxDelay:     defaultBackoffMaxDelay,
+			Jitter:       defaultBackoffJitter,
+		},
+		Durable:      queueGroupName,
+		BatchSize:    defaultBatchSize,
+		FetchTimeout: defaultFetchTimeout,
+		AckWait:      defaultAckWait,
+		MaxInFlight:  defaultMaxInFlight,
 	}
 	for _, opt := range opts {
 		opt(cfg)
@@ -60,9 +81,9 @@ func (c *NATSConsumer) Route(channel, queueGroupName string, handler HandlerFunc
 	c.mu.Unlock()
 }
 
-// Run subscribes to all registered routes and starts consuming. Each handler
+// Run subscribes to all registered routes and starts a fetch loop per route. Each handler
 // is run with a context that includes message headers and a timeout (TaskTimeout).
-// Run blocks until Shutdown is called or the connection is closed.
+// Run returns once all routes are subscribed; messages are processed until Shutdown is called.
 func (c *NATSConsumer) Run() error {
 	c.mu.Lock()
 	routes := make([]*RouteConfig, len(c.routes))
@@ -73,10 +94,21 @@ func (c *NATSConsumer) Run() error {
 		return fmt.Errorf("consumer: no routes registered; call Route before Run")
 	}
 
+	// A durable consumer has a single filter subject, so routes cannot share one
+	durables := make(map[string]string, len(routes))
+	for _, r := range routes {
+		if channel, ok := durables[r.Durable]; ok {
+			return fmt.Errorf("consumer: routes %s and %s share durable %q; give each route its own queue group or durable", channel, r.Channel, r.Durable)
+		}
+		durables[r.Durable] = r.Channel
+	}
+
 	for _, r := range routes {
 		sub, err := c.subscribe(r)
 		if err != nil {
 			// best-effort cleanup of already-subscribed
+			c.cancel()
+			c.fetchWg.Wait()
 			_ = c.drainSubs()
 			return err
 		}
@@ -84,98 +116,320 @@ func (c *NATSConsumer) Run() error {
 		c.subs = append(c.subs, sub)
 		c.mu.Unlock()
 
-		c.logger.Info("Subscribed to channel with queue group",
+		c.fetchWg.Add(1)
+		go c.fetchLoop(r, sub)
+
+		c.logger.Info("Subscribed to channel with durable pull consumer",
 			zap.String("channel", r.Channel),
-			zap.String("queue_group", r.QueueGroup),
+			zap.String("durable", r.Durable),
+			zap.Int("batch_size", r.BatchSize),
+			zap.Int("max_in_flight", r.MaxInFlight),
 		)
 	}
 
 	return nil
 }
 
-// subscribe creates a single subscription for the given route and returns it.
+// subscribe ensures the route's durable consumer exists with the configured settings and
+// returns a pull subscription bound to it. The consumer is created explicitly and bound
+// (rather than created by PullSubscribe) so that unsubscribing on shutdown does not delete
+// it and the route keeps its position across restarts.
 func (c *NATSConsumer) subscribe(r *RouteConfig) (*nats.Subscription, error) {
-	handler := c.wrapHandler(r)
-	sub, err := c.js.QueueSubscribe(r.Channel, r.QueueGroup, func(msg *nats.Msg) {
-		c.logger.Debug("Message received",
-			zap.String("subject", r.Channel),
-			zap.String("queue_group", r.QueueGroup),
-			zap.Int("payload_size", len(msg.Data)),
-		)
-		handler(msg)
-	})
+	stream, err := c.js.StreamNameBySubject(r.Channel)
+	if err != nil {
+		return nil, fmt.Errorf("lookup stream for %s: %w", r.Channel, err)
+	}
+	if err := c.ensureConsumer(stream, r); err != nil {
+		return nil, err
+	}
+	sub, err := c.js.PullSubscribe(r.Channel, r.Durable, nats.Bind(stream, r.Durable))
 	if err != nil {
-		return nil, fmt.Errorf("subscribe to %s (queue %s): %w", r.Channel, r.QueueGroup, err)
+		return nil, fmt.Errorf("subscribe to %s (durable %s): %w", r.Channel, r.Durable, err)
 	}
 	return sub, nil
 }
 
-// wrapHandler returns a function that runs the route handler with timeout and
-// retries, injects headers into context, and Ack/Nak the message.
+// ensureConsumer creates the durable pull consumer for r, or updates its ack settings if it
+// already exists. A push consumer left under the same name (from the former queue
+// subscription) is replaced, since JetStream cannot convert push consumers to pull.
+func (c *NATSConsumer) ensureConsumer(stream string, r *RouteConfig) error {
+	maxDeliver := r.MaxDeliver
+	if maxDeliver == 0 {
+		maxDeliver = -1
+	}
+
+	info, err := c.js.ConsumerInfo(stream, r.Durable)
+	if err != nil && !errors.Is(err, nats.ErrConsumerNotFound) {
+		return fmt.Errorf("get consumer %s on stream %s: %w", r.Durable, stream, err)
+	}
+	if err == nil && info.Config.DeliverSubject != "" {
+		c.logger.Warn("Replacing push consumer with durable pull consumer",
+			zap.String("stream", stream),
+			zap.String("durable", r.Durable),
+		)
+		if err := c.js.DeleteConsumer(stream, r.Durable); err != nil {
+			return fmt.Errorf("delete push consumer %s on stream %s: %w", r.Durable, stream, err)
+		}
+		info = nil
+	}
+
+	if info == nil {
+		if _, err := c.js.AddConsumer(stream, &nats.ConsumerConfig{
+			Durable:       r.Durable,
+			FilterSubject: r.Channel,
+			DeliverPolicy: nats.DeliverAllPolicy,
+			AckPolicy:     nats.AckExplicitPolicy,
+			AckWait:       r.AckWait,
+			MaxDeliver:    maxDeliver,
+			MaxAckPending: r.MaxAckPending,
+		}); err != nil {
+			return fmt.Errorf("create consumer %s on stream %s: %w", r.Durable, stream, err)
+		}
+		return nil
+	}
+
+	cfg := info.Config
+	cfg.AckWait = r.AckWait
+	cfg.MaxDeliver = maxDeliver
+	cfg.MaxAckPending = r.MaxAckPending
+	if _, err := c.js.UpdateConsumer(stream, &cfg); err != nil {
+		return fmt.Errorf("update consumer %s on stream %s: %w", r.Durable, stream, err)
+	}
+	return nil
+}
+
+// fetchLoop pulls messages for r until Shutdown is called. It only requests as many
+// messages as there are free handler slots (bounded by BatchSize and MaxInFlight), so
+// messages are not held by this worker while they wait for a slot.
+func (c *NATSConsumer) fetchLoop(r *RouteConfig, sub *nats.Subscription) {
+	defer c.fetchWg.Done()
+
+	handler := c.wrapHandler(r)
+	slots := make(chan struct{}, r.MaxInFlight)
+	for {
+		// Block until at least one handler slot is free, then take as many as are available.
+		select {
+		case <-c.ctx.Done():
+			return
+		case slots <- struct{}{}:
+		}
+		n := 1
+	acquire:
+		for n < r.BatchSize {
+			select {
+			case slots <- struct{}{}:
+				n++
+			default:
+				break acquire
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.ctx, r.FetchTimeout)
+		msgs, err := sub.Fetch(n, nats.Context(ctx))
+		cancel()
+
+		for i := len(msgs); i < n; i++ {
+			<-slots
+		}
+		if err != nil && len(msgs) == 0 {
+			if c.ctx.Err() != nil {
+				return
+			}
+			if !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, nats.ErrTimeout) {
+				c.logger.Warn("Error fetching messages",
+					zap.String("channel", r.Channel),
+					zap.String("durable", r.Durable),
+					zap.Error(err),
+				)
+				select {
+				case <-c.ctx.Done():
+					return
+				case <-time.After(r.FetchTimeout):
+				}
+			}
+			continue
+		}
+
+		for _, msg := range msgs {
+			c.logger.Debug("Message received",
+				zap.String("subject", r.Channel),
+				zap.String("durable", r.Durable),
+				zap.Int("payload_size", len(msg.Data)),
+			)
+			c.wg.Add(1)
+			go func(msg *nats.Msg) {
+				defer c.wg.Done()
+				defer func() { <-slots }()
+				stop := c.keepAlive(r, msg)
+				defer stop()
+				handler(msg)
+			}(msg)
+		}
+	}
+}
+
+// keepAlive sends progress acks for msg every AckWait/2 until the returned function is
+// called, so the server does not redeliver a message whose handler is still running.
+func (c *NATSConsumer) keepAlive(r *RouteConfig, msg *nats.Msg) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(r.AckWait / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := msg.InProgress(); err != nil {
+					c.logger.Debug("Failed to send progress ack",
+						zap.String("channel", r.Channel),
+						zap.Error(err),
+					)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// wrapHandler returns a function that runs the route handler once per delivery with
+// a fresh timeout, injects headers into context, and Ack/Nak the message. Retries are
+// driven by JetStream redelivery: on error the message is NAKed with a backoff delay,
+// and the attempt number comes from the delivery count, so a worker restart does not
+// reset the retry budget. A MaxDeliver cap lower than RetryCount+1 also ends retries, so
+// the server never silently stops redelivering a message. Once retries are exhausted the message is dead-lettered and acked.
 func (c *NATSConsumer) wrapHandler(r *RouteConfig) func(*nats.Msg) {
 	return func(msg *nats.Msg) {
-		c.wg.Add(1)
-		defer c.wg.Done()
+		attempt := deliveryAttempt(msg)
+		lastAttempt := attempt >= r.RetryCount || (r.MaxDeliver > 0 && attempt+1 >= r.MaxDeliver)
 
 		ctx := contextWithHeaders(context.Background(), msg.Header)
 		ctx, cancel := context.WithTimeout(ctx, r.TaskTimeout)
 		defer cancel()
 
+		// If this is the last allowed attempt, flag the context that it is the last attempt.
+		if lastAttempt {
+			ctx = context.WithValue(ctx, lastAttemptContextKey, true)
+		}
+
 		m := &Message{Data: msg.Data, RawMsg: msg}
-		var lastErr error
-		for attempt := 0; attempt <= r.RetryCount; attempt++ {
-			// If this is the last allowed attempt, flag the context that it is the last attempt.
-			if attempt == r.RetryCount {
-				ctx = context.WithValue(ctx, lastAttemptContextKey, true)
+		lastErr := r.Handler(ctx, m)
+		if lastErr == nil {
+			if err := msg.Ack(); err != nil {
+				c.logger.Error("Failed to ack message",
+					zap.String("channel", r.Channel),
+					zap.String("queue_group", r.QueueGroup),
+					zap.Error(err),
+				)
 			}
+			return
+		}
 
-			lastErr = r.Handler(ctx, m)
-			if lastErr == nil {
-				if err := msg.Ack(); err != nil {
-					c.logger.Error("Failed to ack message",
-						zap.String("channel", r.Channel),
-						zap.String("queue_group", r.QueueGroup),
-						zap.Error(err),
-					)
-				}
-				return
-			}
-			if attempt < r.RetryCount {
-				c.logger.Debug("Handler error, retrying",
+		if !lastAttempt {
+			delay := r.Backoff.Delay(attempt)
+			c.logger.Debug("Handler error, scheduling redelivery",
+				zap.String("channel", r.Channel),
+				zap.Int("attempt", attempt+1),
+				zap.Duration("delay", delay),
+				zap.Error(lastErr),
+			)
+			if err := msg.NakWithDelay(delay); err != nil {
+				c.logger.Error("Failed to nak message for redelivery",
 					zap.String("channel", r.Channel),
-					zap.Int("attempt", attempt+1),
-					zap.Error(lastErr),
+					zap.String("queue_group", r.QueueGroup),
+					zap.Error(err),
 				)
 			}
+			return
 		}
 
 		c.logger.Error("Error processing message after retries",
 			zap.String("channel", r.Channel),
 			zap.String("queue_group", r.QueueGroup),
+			zap.Int("attempts", attempt+1),
 			zap.Error(lastErr),
 		)
-		// Note: In case of retry failures, we ACK the message. In future, implement DLQ shift here.
+
+		// Shift the message to the dead-letter subject (if configured) before acking it.
+		// If that fails, NAK so the message is redelivered instead of being lost.
+		if err := c.deadLetter(r, msg, attempt+1, lastErr); err != nil {
+			c.logger.Error("Failed to publish message to dead-letter subject",
+				zap.String("channel", r.Channel),
+				zap.String("dead_letter_subject", r.DeadLetterSubject),
+				zap.Error(err),
+			)
+			if err := msg.NakWithDelay(r.Backoff.Delay(attempt)); err != nil {
+				c.logger.Error("Failed to nak message after dead-letter failure", zap.Error(err))
+			}
+			return
+		}
 		if err := msg.Ack(); err != nil {
 			c.logger.Error("Failed to ack message after retries", zap.Error(err))
 		}
 	}
 }
 
-// Shutdown gracefully stops the consumer: it drains all subscriptions (no new
+// deliveryAttempt returns the 0-based attempt number of msg from its JetStream delivery count.
+// Messages without JetStream metadata are treated as the first attempt.
+func deliveryAttempt(msg *nats.Msg) int {
+	meta, err := msg.Metadata()
+	if err != nil || meta.NumDelivered == 0 {
+		return 0
+	}
+	return int(meta.NumDelivered - 1)
+}
+
+// deadLetter republishes msg to the route's dead-letter subject, keeping the original
+// payload and headers and adding the attempt count, last error and original subject.
+// It is a no-op when the route has no dead-letter subject.
+func (c *NATSConsumer) deadLetter(r *RouteConfig, msg *nats.Msg, attempts int, lastErr error) error {
+	if r.DeadLetterSubject == "" {
+		return nil
+	}
+
+	header := nats.Header{}
+	for k, v := range msg.Header {
+		header[k] = append([]string(nil), v...)
+	}
+	// The dead-letter subject lives in the same stream as the original, so the original
+	// message ID would make the stream drop this publish as a duplicate
+	header.Del(nats.MsgIdHdr)
+	header.Set(HeaderDeadLetterOriginalSubject, msg.Subject)
+	header.Set(HeaderDeadLetterAttempts, strconv.Itoa(attempts))
+	if lastErr != nil {
+		header.Set(HeaderDeadLetterError, lastErr.Error())
+	}
+
+	if _, err := c.js.PublishMsg(&nats.Msg{
+		Subject: r.DeadLetterSubject,
+		Data:    msg.Data,
+		Header:  header,
+	}); err != nil {
+		return fmt.Errorf("publish to %s: %w", r.DeadLetterSubject, err)
+	}
+
+	c.logger.Warn("Message moved to dead-letter subject",
+		zap.String("channel", r.Channel),
+		zap.String("dead_letter_subject", r.DeadLetterSubject),
+		zap.Int("attempts", attempts),
+	)
+	return nil
+}
+
+// Shutdown gracefully stops the consumer: it stops the fetch loops (no new
 // messages), waits for in-flight handler tasks to complete (via WaitGroup), then
-// drains the NATS connection. If ctx is cancelled before the wait completes,
+// unsubscribes (durable consumers are kept) and drains the NATS connection. If ctx is cancelled before the wait completes,
 // Shutdown proceeds to close the connection and returns ctx.Err().
 func (c *NATSConsumer) Shutdown() {
 	ctx, cancel := context.WithTimeout(context.Background(), c.shutdownTimeout)
 	defer cancel()
 
-	if err := c.drainSubs(); err != nil {
-		c.logger.Warn("Error draining subscriptions", zap.Error(err))
-	}
+	c.cancel()
 
-	// Wait for in-flight handlers to finish, or for ctx to be cancelled.
+	// Wait for fetch loops and in-flight handlers to finish, or for ctx to be cancelled.
 	waitDone := make(chan struct{})
 	go func() {
+		c.fetchWg.Wait()
 		c.wg.Wait()
 		close(waitDone)
 	}()
@@ -185,6 +439,10 @@ func (c *NATSConsumer) Shutdown() {
 	case <-waitDone:
 	}
 
+	if err := c.drainSubs(); err != nil {
+		c.logger.Warn("Error draining subscriptions", zap.Error(err))
+	}
+
 	done := make(chan struct{})
 	go func() {
 		if c.conn != nil {
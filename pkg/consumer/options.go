@@ -12,8 +12,8 @@ func TaskTimeout(d time.Duration) OptionFunc {
 	}
 }
 
-// RetryCount sets how many times to retry the handler on error before NAK.
-// Default: 1 (no retries beyond the first attempt).
+// RetryCount sets how many times the message is redelivered (NAK with backoff delay)
+// after a handler error before it is dead-lettered. Default: 1.
 func RetryCount(n int) OptionFunc {
 	return func(c *RouteConfig) {
 		if n >= 0 {
@@ -21,3 +21,120 @@ func RetryCount(n int) OptionFunc {
 		}
 	}
 }
+
+// DeadLetter sets the subject that messages are republished to once all retries
+// are exhausted. The original payload and headers are kept, and the attempt count,
+// last error and original subject are added as headers (see HeaderDeadLetter*).
+// The subject must be bound to a JetStream stream. Default: none (message is acked and dropped).
+func DeadLetter(subject string) OptionFunc {
+	return func(c *RouteConfig) {
+		c.DeadLetterSubject = subject
+	}
+}
+
+// BackoffInitialDelay sets the redelivery delay after the first failed attempt. Default: 1 second.
+func BackoffInitialDelay(d time.Duration) OptionFunc {
+	return func(c *RouteConfig) {
+		if d > 0 {
+			c.Backoff.InitialDelay = d
+		}
+	}
+}
+
+// BackoffMultiplier sets the factor the redelivery delay grows by after each failed attempt. Default: 2.
+func BackoffMultiplier(m float64) OptionFunc {
+	return func(c *RouteConfig) {
+		if m >= 1 {
+			c.Backoff.Multiplier = m
+		}
+	}
+}
+
+// BackoffMaxDelay caps the redelivery delay. Default: 1 minute.
+func BackoffMaxDelay(d time.Duration) OptionFunc {
+	return func(c *RouteConfig) {
+		if d > 0 {
+			c.Backoff.MaxDelay = d
+		}
+	}
+}
+
+// BackoffJitter sets the random spread applied to each delay as a fraction of it
+// (e.g. 0.2 means ±20%). Default: 0.2.
+func BackoffJitter(j float64) OptionFunc {
+	return func(c *RouteConfig) {
+		if j >= 0 && j <= 1 {
+			c.Backoff.Jitter = j
+		}
+	}
+}
+
+// Durable sets the name of the JetStream durable pull consumer for the route.
+// All workers using the same durable share its position and pending acks. Default: the queue group.
+func Durable(name string) OptionFunc {
+	return func(c *RouteConfig) {
+		if name != "" {
+			c.Durable = name
+		}
+	}
+}
+
+// BatchSize sets the maximum number of messages requested per fetch. Default: 10.
+func BatchSize(n int) OptionFunc {
+	return func(c *RouteConfig) {
+		if n > 0 {
+			c.BatchSize = n
+		}
+	}
+}
+
+// FetchTimeout sets how long a fetch waits for messages before polling again. Default: 5 seconds.
+func FetchTimeout(d time.Duration) OptionFunc {
+	return func(c *RouteConfig) {
+		if d > 0 {
+			c.FetchTimeout = d
+		}
+	}
+}
+
+// AckWait sets how long the server waits for an ack before redelivering a message.
+// While a handler runs the consumer sends progress acks every AckWait/2, so slow
+// handlers do not cause redeliveries. Default: 30 seconds.
+func AckWait(d time.Duration) OptionFunc {
+	return func(c *RouteConfig) {
+		if d > 0 {
+			c.AckWait = d
+		}
+	}
+}
+
+// MaxDeliver caps how many times the server delivers a message. When the cap is lower
+// than RetryCount+1 the last delivery is treated as the last attempt so the message is
+// still dead-lettered. Default: 0 (unlimited).
+func MaxDeliver(n int) OptionFunc {
+	return func(c *RouteConfig) {
+		if n >= 0 {
+			c.MaxDeliver = n
+		}
+	}
+}
+
+// MaxAckPending caps unacknowledged messages across all workers sharing the durable.
+// Default: 0 (server default).
+func MaxAckPending(n int) OptionFunc {
+	return func(c *RouteConfig) {
+		if n >= 0 {
+			c.MaxAckPending = n
+		}
+	}
+}
+
+// MaxInFlight caps how many handlers run concurrently in this worker for the route.
+// Fetches only request as many messages as there are free slots. Default: 10.
+func MaxInFlight(n int) OptionFunc {
+	return func(c *RouteConfig) {
+		if n > 0 {
+			c.MaxInFlight = n
+		}
+	}
+}
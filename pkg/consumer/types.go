@@ -25,15 +25,36 @@ type OptionFunc func(*RouteConfig)
 
 // RouteConfig holds the configuration for a single route, stored when Route() is called.
 type RouteConfig struct {
-	Channel       string
-	QueueGroup    string
-	Handler       HandlerFunc
-	TaskTimeout   time.Duration
-	RetryCount    int
+	Channel           string
+	QueueGroup        string
+	Handler           HandlerFunc
+	TaskTimeout       time.Duration
+	RetryCount        int
+	Backoff           Backoff
+	DeadLetterSubject string
+
+	// Durable is the name of the JetStream pull consumer shared by all workers of this route.
+	// Defaults to QueueGroup.
+	Durable string
+	// BatchSize is the maximum number of messages requested per fetch.
+	BatchSize int
+	// FetchTimeout is how long a fetch waits for messages before polling again.
+	FetchTimeout time.Duration
+	// AckWait is how long the server waits for an ack before redelivering. Handlers that
+	// run longer keep the message alive with progress acks.
+	AckWait time.Duration
+	// MaxDeliver caps server-side deliveries of a message (0 means unlimited).
+	MaxDeliver int
+	// MaxAckPending caps unacknowledged messages across all workers of the durable
+	// (0 means server default).
+	MaxAckPending int
+	// MaxInFlight caps concurrently running handlers in this worker.
+	MaxInFlight int
 }
 
 // LastAttemptFromContext returns true if the handler is running on the last
-// allowed attempt (no more retries after this). Set by the consumer when attempt == RetryCount.
+// allowed attempt (no more retries after this). Set by the consumer when the JetStream
+// delivery count shows attempt >= RetryCount, so it survives worker restarts.
 func LastAttemptFromContext(ctx context.Context) bool {
 	v := ctx.Value(lastAttemptContextKey)
 	b, _ := v.(bool)
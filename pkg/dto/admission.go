@@ -0,0 +1,37 @@
+package dto
+
+import "fmt"
+
+// Admission rules reported in an AdmissionViolation
+const (
+	RuleNamespace               = "namespace"
+	RuleDeploymentsPerNamespace = "deployments_per_namespace"
+	RuleReplicas                = "replicas"
+	RuleCPU                     = "cpu"
+	RuleMemory                  = "memory"
+)
+
+// AdmissionViolation describes why admission refused a deployment request. It wraps ErrNamespaceNotAllowed
+// (answered with 403) or ErrQuotaExceeded (answered with 422) and is returned as the violation detail.
+type AdmissionViolation struct {
+	Err       error  `json:"-"`
+	Rule      string `json:"rule"`
+	Namespace string `json:"namespace,omitempty"`
+	// Limit, Used and Requested are set for quota rules; Used excludes the request
+	Limit     string `json:"limit,omitempty"`
+	Used      string `json:"used,omitempty"`
+	Requested string `json:"requested,omitempty"`
+}
+
+// Error describes the violated rule
+func (v *AdmissionViolation) Error() string {
+	if v.Rule == RuleNamespace {
+		return fmt.Sprintf("%v: %q", v.Err, v.Namespace)
+	}
+	return fmt.Sprintf("%v: %s limit %s, used %s, requested %s", v.Err, v.Rule, v.Limit, v.Used, v.Requested)
+}
+
+// Unwrap returns ErrNamespaceNotAllowed or ErrQuotaExceeded
+func (v *AdmissionViolation) Unwrap() error {
+	return v.Err
+}
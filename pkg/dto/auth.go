@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"slices"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// Principal is the authenticated caller of an API request
+type Principal struct {
+	User *models.User
+	// Scopes granted to the credentials; nil means unrestricted (JWT without a scope claim, passthrough)
+	Scopes []string
+	// APIKeyID is the API key the request was authenticated with, if any
+	APIKeyID *uuid.UUID
+}
+
+// HasScope reports whether the principal's credentials grant scope
+func (p *Principal) HasScope(scope string) bool {
+	return p.Scopes == nil || slices.Contains(p.Scopes, scope)
+}
+
+// PolicyAction is what a caller wants to do with a deployment, deployment request or team
+type PolicyAction string
+
+const (
+	// ActionView reads a resource (team viewer and up)
+	ActionView PolicyAction = "view"
+	// ActionDeploy creates, updates, deletes or rolls back a deployment (team deployer and up)
+	ActionDeploy PolicyAction = "deploy"
+	// ActionManage manages a team's members (team admin)
+	ActionManage PolicyAction = "manage"
+)
+
+// Ownership identifies who owns a resource: a team, or only its creator when TeamID is nil
+type Ownership struct {
+	UserID uuid.UUID
+	TeamID *uuid.UUID
+}
@@ -6,6 +6,59 @@ type APIConfig struct {
 	Server   serverConfig   `mapstructure:"server"`
 	Database databaseConfig `mapstructure:"database"`
 	Nats     natsConfig     `mapstructure:"nats"`
+	Outbox   OutboxConfig   `mapstructure:"outbox"`
+	Auth     AuthConfig     `mapstructure:"auth"`
+	// Admission holds the namespace and quota checks applied before deployment requests are queued
+	Admission AdmissionConfig `mapstructure:"admission"`
+}
+
+// AdmissionConfig holds the namespace allowlists and quotas of users and teams
+type AdmissionConfig struct {
+	// ReservedNamespaces are path.Match patterns no one may deploy to (e.g. "kube-*")
+	ReservedNamespaces []string `mapstructure:"reserved_namespaces"`
+	// Default applies to users and teams without a policy of their own
+	Default AdmissionPolicy `mapstructure:"default"`
+	// Policies are matched by team name (team deployments) or user external ID (personal deployments)
+	Policies []AdmissionPolicy `mapstructure:"policies"`
+}
+
+// AdmissionPolicy limits where an owner may deploy and how much it may run; zero values are unlimited
+type AdmissionPolicy struct {
+	Team string `mapstructure:"team"` // team name; set either team or user
+	User string `mapstructure:"user"` // user external ID
+	// Namespaces are path.Match patterns (e.g. "team-a-*"); empty allows every non-reserved namespace
+	Namespaces []string `mapstructure:"namespaces"`
+	// MaxDeploymentsPerNamespace caps the owner's deployments in any one namespace
+	MaxDeploymentsPerNamespace int    `mapstructure:"max_deployments_per_namespace"`
+	MaxReplicas                int    `mapstructure:"max_replicas"` // total replicas over the owner's deployments
+	MaxCPU                     string `mapstructure:"max_cpu"`      // total CPU limits, e.g. "8"
+	MaxMemory                  string `mapstructure:"max_memory"`   // total memory limits, e.g. "16Gi"
+}
+
+// AuthConfig selects the authenticators tried, in order, for every API request
+type AuthConfig struct {
+	// Authenticators lists api_key, jwt and/or passthrough; the first one that finds its credentials decides.
+	// passthrough trusts the X-User-ID header and must only be used in development.
+	Authenticators []string  `mapstructure:"authenticators"`
+	JWT            JWTConfig `mapstructure:"jwt"`
+}
+
+// JWTConfig holds the settings for HMAC-signed (HS256/HS384/HS512) bearer tokens
+type JWTConfig struct {
+	Secret   string `mapstructure:"secret"`
+	Issuer   string `mapstructure:"issuer"`   // required iss claim; empty skips the check
+	Audience string `mapstructure:"audience"` // required aud claim; empty skips the check
+	// UserClaim is the claim mapped to the user's external ID (default "sub")
+	UserClaim string        `mapstructure:"user_claim"`
+	Leeway    time.Duration `mapstructure:"leeway"` // clock skew allowed on exp and nbf
+}
+
+// OutboxConfig holds the outbox relay settings (zero values use the relay defaults)
+type OutboxConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+	BatchSize    int           `mapstructure:"batch_size"`
+	// Retention is how long published messages are kept before being deleted (0 keeps them)
+	Retention time.Duration `mapstructure:"retention"`
 }
 
 type serverConfig struct {
@@ -34,21 +87,109 @@ type ProducerConfig struct {
 	StreamName               string `mapstructure:"stream_name"`
 	DeploymentRequestChannel string `mapstructure:"deployment_request_channel"`
 	DeploymentUpdateChannel  string `mapstructure:"deployment_update_channel"`
+	DeadLetterChannel        string `mapstructure:"dead_letter_channel"`
+	// DeploymentStatusChannel is the core NATS subject prefix of deployment request status events
+	// (<channel>.<request_id>). It is not bound to the stream; empty disables status events.
+	DeploymentStatusChannel string `mapstructure:"deployment_status_channel"`
+	// DeploymentEventChannel carries the request completion and deployment state events delivered to
+	// webhooks; empty disables them
+	DeploymentEventChannel string `mapstructure:"deployment_event_channel"`
+	// Stream holds the declarative stream settings; an existing stream is updated when it drifts, except for
+	// retention and storage, which JetStream fixes at creation
+	Stream StreamConfig `mapstructure:"stream"`
+}
+
+// StreamConfig holds the JetStream stream settings (zero values use the server defaults)
+type StreamConfig struct {
+	// Duplicates is the window in which messages with the same Nats-Msg-Id are dropped (default 2m)
+	Duplicates time.Duration `mapstructure:"duplicates"`
+	Retention  string        `mapstructure:"retention"` // limits (default), interest or workqueue
+	MaxAge     time.Duration `mapstructure:"max_age"`   // 0 keeps messages indefinitely
+	Replicas   int           `mapstructure:"replicas"`  // default 1
+	Storage    string        `mapstructure:"storage"`   // file (default) or memory
+}
+
+// Subjects returns the non-empty channels that must be bound to the JetStream stream
+func (p *ProducerConfig) Subjects() []string {
+	subjects := make([]string, 0, 4)
+	for _, s := range []string{p.DeploymentRequestChannel, p.DeploymentUpdateChannel, p.DeadLetterChannel, p.DeploymentEventChannel} {
+		if s != "" {
+			subjects = append(subjects, s)
+		}
+	}
+	return subjects
 }
 
 // WorkerConfig holds configuration for the worker consumer
 type WorkerConfig struct {
+	Database  DatabaseConfig  `mapstructure:"database"`
+	K8s       K8sConfig       `mapstructure:"k8s"`
+	Nats      NatsConfig      `mapstructure:"nats"`
+	Consumer  ConsumerConfig  `mapstructure:"consumer"`
+	Watcher   WatcherConfig   `mapstructure:"watcher"`
+	Reaper    ReaperConfig    `mapstructure:"reaper"`
+	Scheduler SchedulerConfig `mapstructure:"scheduler"`
+	// Admission holds the quotas the scheduler checks again when a scaling schedule fires; use the API's
+	Admission AdmissionConfig `mapstructure:"admission"`
+	// Outbox configures the worker's relay, which publishes the messages written by the reaper and scheduler
+	Outbox OutboxConfig `mapstructure:"outbox"`
+}
+
+// WebhookWorkerConfig holds configuration for the webhook delivery worker
+type WebhookWorkerConfig struct {
 	Database DatabaseConfig `mapstructure:"database"`
-	K8s      K8sConfig      `mapstructure:"k8s"`
 	Nats     NatsConfig     `mapstructure:"nats"`
-	Consumer ConsumerConfig `mapstructure:"consumer"`
-	Watcher  WatcherConfig  `mapstructure:"watcher"`
+	Webhook  WebhookConfig  `mapstructure:"webhook"`
+}
+
+// WebhookConfig holds the webhook event consumer and delivery settings (zero values use the defaults)
+type WebhookConfig struct {
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// EventTask consumes the deployment event channel and stores a delivery per matching subscription
+	EventTask ConsumerTypeConfig `mapstructure:"event_task"`
+	// Interval between runs of the dispatcher that sends due deliveries
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize caps the deliveries sent per run
+	BatchSize int `mapstructure:"batch_size"`
+	// RequestTimeout bounds one POST to a webhook URL
+	RequestTimeout time.Duration `mapstructure:"request_timeout"`
+	// AllowPrivateNetworks lets webhooks reach loopback, private and link-local addresses (development only)
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+	// MaxAttempts is how many times a delivery is sent before it is marked FAILURE
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// Backoff is the delay between attempts of a delivery (jitter is not applied when unset)
+	Backoff BackoffConfig `mapstructure:"backoff"`
+}
+
+// ReaperConfig holds configuration for the stuck-request reaper (zero values use the reaper defaults)
+type ReaperConfig struct {
+	// Enabled turns the reaper on
+	Enabled bool `mapstructure:"enabled"`
+	// Interval between reaper runs
+	Interval time.Duration `mapstructure:"interval"`
+	// MinAge is how long a request must stay CREATED (since creation or last republish) before it is
+	// reaped; keep it above the consumer task timeout times the retry budget
+	MinAge time.Duration `mapstructure:"min_age"`
+	// MaxRepublish is how many times a request is republished before it is marked FAILURE
+	MaxRepublish int `mapstructure:"max_republish"`
+	// BatchSize caps the requests examined per run
+	BatchSize int `mapstructure:"batch_size"`
+}
+
+// SchedulerConfig holds configuration for the scaling schedule runner (zero values use the scheduler defaults)
+type SchedulerConfig struct {
+	// Enabled turns the scheduler on
+	Enabled bool `mapstructure:"enabled"`
+	// Interval between checks for due schedules; a run is emitted at most this late
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize caps the schedules fired per check
+	BatchSize int `mapstructure:"batch_size"`
 }
 
 // WatcherConfig holds configuration for the deployment informer (resync, task timeout)
 type WatcherConfig struct {
-	ResyncPeriod  time.Duration `mapstructure:"resync_period"`
-	TaskTimeout   time.Duration `mapstructure:"task_timeout"`
+	ResyncPeriod time.Duration `mapstructure:"resync_period"`
+	TaskTimeout  time.Duration `mapstructure:"task_timeout"`
 }
 
 // K8sConfig holds Kubernetes client configuration
@@ -59,6 +200,11 @@ type K8sConfig struct {
 	Kubeconfig string `mapstructure:"kubeconfig"`
 	// ManagerTag is the value for the managed-by label on created resources (from config key manager-tag).
 	ManagerTag string `mapstructure:"manager_tag"`
+	// NamespaceTemplate is the template (relative to the templates base path) with the ResourceQuota,
+	// LimitRange and NetworkPolicy objects applied to namespaces the manager creates. Empty applies none.
+	NamespaceTemplate string `mapstructure:"namespace_template"`
+	// NamespaceGC deletes a namespace the manager created once its last deployment is deleted.
+	NamespaceGC bool `mapstructure:"namespace_gc"`
 }
 
 // ConsumerConfig holds worker consumer settings
@@ -66,6 +212,8 @@ type ConsumerConfig struct {
 	ShutdownTimeout       time.Duration      `mapstructure:"shutdown_timeout"`
 	DeploymentRequestTask ConsumerTypeConfig `mapstructure:"deployment_request_task"`
 	DeploymentUpdateTask  ConsumerTypeConfig `mapstructure:"deployment_update_task"`
+	// DeadLetterTask consumes the dead-letter channel and records messages for listing/replay
+	DeadLetterTask ConsumerTypeConfig `mapstructure:"dead_letter_task"`
 }
 
 // ConsumerTypeConfig holds per-task-type configuration for a consumer route
@@ -74,6 +222,31 @@ type ConsumerTypeConfig struct {
 	QueueGroup  string         `mapstructure:"queue_group"`
 	TaskTimeout *time.Duration `mapstructure:"task_timeout"` // optional, uses consumer default if nil
 	RetryCount  *int           `mapstructure:"retry_count"`  // optional, uses consumer default if nil
+	// Backoff controls the redelivery delay between retries (optional, uses consumer defaults if nil)
+	Backoff *BackoffConfig `mapstructure:"backoff"`
+	// DeadLetterChannel receives messages whose retries are exhausted (optional; dropped if empty)
+	DeadLetterChannel string `mapstructure:"dead_letter_channel"`
+	// Pull controls the durable pull consumer behind the route (optional, uses consumer defaults if nil)
+	Pull *PullConfig `mapstructure:"pull"`
+}
+
+// PullConfig holds the per-route durable pull consumer settings; every field is optional
+type PullConfig struct {
+	Durable       string         `mapstructure:"durable"` // defaults to the queue group
+	BatchSize     *int           `mapstructure:"batch_size"`
+	FetchTimeout  *time.Duration `mapstructure:"fetch_timeout"`
+	AckWait       *time.Duration `mapstructure:"ack_wait"`
+	MaxDeliver    *int           `mapstructure:"max_deliver"`     // 0 = unlimited
+	MaxAckPending *int           `mapstructure:"max_ack_pending"` // across all workers; 0 = server default
+	MaxInFlight   *int           `mapstructure:"max_in_flight"`   // concurrent handlers per worker
+}
+
+// BackoffConfig holds the per-route redelivery backoff; every field is optional
+type BackoffConfig struct {
+	InitialDelay *time.Duration `mapstructure:"initial_delay"`
+	Multiplier   *float64       `mapstructure:"multiplier"`
+	MaxDelay     *time.Duration `mapstructure:"max_delay"`
+	Jitter       *float64       `mapstructure:"jitter"` // fraction of the delay, e.g. 0.2 = ±20%
 }
 
 // natsConfig is an alias for backward compatibility
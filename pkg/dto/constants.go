@@ -1,36 +1,117 @@
 package dto
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // HTTP Header constants
 const (
 	// RequestIDHeader is the header name for request ID
 	RequestIDHeader = "X-Request-ID"
-	// UserIDHeader is the header name for user ID
+	// UserIDHeader is the header name for user ID (passthrough authenticator only)
 	UserIDHeader = "X-User-ID"
+	// APIKeyHeader is the header name for API keys
+	APIKeyHeader = "X-API-Key"
+	// AuthorizationHeader is the header name for bearer tokens
+	AuthorizationHeader = "Authorization"
+	// BearerPrefix prefixes the token in the Authorization header
+	BearerPrefix = "Bearer "
+)
+
+// Authenticator names (config auth.authenticators)
+const (
+	AuthenticatorAPIKey      = "api_key"
+	AuthenticatorJWT         = "jwt"
+	AuthenticatorPassthrough = "passthrough"
+)
+
+// API key format: <APIKeyPrefix>_<key id>_<secret>; the key id is stored in clear for lookup, the secret hashed
+const (
+	APIKeyPrefix       = "kdm"
+	APIKeyIDLength     = 12
+	APIKeySecretLength = 32
+)
+
+// API key scopes; routes declare the scope they require with middleware.RequireScope
+const (
+	ScopeDeploymentsRead  = "deployments:read"
+	ScopeDeploymentsWrite = "deployments:write"
+	ScopeRequestsRead     = "requests:read"
+	ScopeAPIKeysManage    = "api_keys:manage"
+	ScopeTeamsManage      = "teams:manage"
+	ScopeWebhooksManage   = "webhooks:manage"
+	// ScopeDeadLettersAdmin lists and replays every dead-lettered message, including unowned ones
+	ScopeDeadLettersAdmin = "dead_letters:admin"
 )
 
+// AllScopes lists every scope an API key can be granted
+var AllScopes = []string{ScopeDeploymentsRead, ScopeDeploymentsWrite, ScopeRequestsRead, ScopeAPIKeysManage, ScopeTeamsManage, ScopeWebhooksManage, ScopeDeadLettersAdmin}
+
+// JWTScopeClaim is the JWT claim holding the token's scopes, space-delimited or as an array; tokens without it
+// are unrestricted
+const JWTScopeClaim = "scope"
+
+// MinJWTSecretLength is the minimum length in bytes of the HMAC secret of the jwt authenticator
+const MinJWTSecretLength = 32
+
+// DefaultJWTUserClaim is the JWT claim mapped to the user's external ID when none is configured
+const DefaultJWTUserClaim = "sub"
+
 // API path constants
 const (
-	PathPing                   = "/api/v1/ping"
-	PathDeploymentRequestsList = "/api/v1/deployments/requests"
-	PathDeploymentRequestByID  = "/api/v1/deployments/requests/:id"
-	PathDeploymentsCreate      = "/api/v1/deployments/requests/create"
-	PathDeploymentsList        = "/api/v1/deployments"
-	PathDeploymentByID         = "/api/v1/deployments/:id"
+	PathPing                    = "/api/v1/ping"
+	PathDeploymentRequestsList  = "/api/v1/deployments/requests"
+	PathDeploymentRequestByID   = "/api/v1/deployments/requests/:id"
+	PathDeploymentRequestEvents = "/api/v1/deployments/requests/:id/events"
+	PathDeploymentsCreate       = "/api/v1/deployments/requests/create"
+	PathDeploymentsList         = "/api/v1/deployments"
+	PathDeploymentByID          = "/api/v1/deployments/:id"
+	PathDeploymentRollback      = "/api/v1/deployments/:id/rollback"
+	PathDeploymentRestart       = "/api/v1/deployments/:id/restart"
+	PathDeploymentPause         = "/api/v1/deployments/:id/pause"
+	PathDeploymentResume        = "/api/v1/deployments/:id/resume"
+	PathDeploymentRevisions     = "/api/v1/deployments/:id/revisions"
+	PathDeploymentRevisionDiff  = "/api/v1/deployments/:id/revisions/diff"
+	PathDeploymentSchedules     = "/api/v1/deployments/:id/schedules"
+	PathDeploymentSchedule      = "/api/v1/deployments/:id/schedules/:schedule"
+	PathDeadLettersList         = "/api/v1/dead-letters"
+	PathDeadLetterReplay        = "/api/v1/dead-letters/:id/replay"
+	PathAdminDeadLettersList    = "/api/v1/admin/dead-letters"
+	PathAdminDeadLetterReplay   = "/api/v1/admin/dead-letters/:id/replay"
+	PathTemplatesList           = "/api/v1/templates"
+	PathAPIKeys                 = "/api/v1/api-keys"
+	PathAPIKeyByID              = "/api/v1/api-keys/:id"
+	PathTeams                   = "/api/v1/teams"
+	PathTeamMembers             = "/api/v1/teams/:id/members"
+	PathTeamMember              = "/api/v1/teams/:id/members/:member"
+	PathQuotas                  = "/api/v1/quotas"
+	PathWebhooks                = "/api/v1/webhooks"
+	PathWebhookByID             = "/api/v1/webhooks/:id"
+	PathWebhookDeliveries       = "/api/v1/webhooks/:id/deliveries"
+	PathWebhookRedeliver        = "/api/v1/webhooks/:id/deliveries/:delivery/redeliver"
 )
 
 // API response message constants (user-facing)
 const (
 	MessagePong = "pong"
 
-	MsgDeploymentRequestsRetrieved = "Deployment requests retrieved successfully"
-	MsgDeploymentRequestRetrieved  = "Deployment request retrieved successfully"
-	MsgDeploymentRequestCreated    = "Deployment request created successfully"
-	MsgDeploymentRequestUpdated    = "Deployment request updated successfully"
-	MsgDeploymentRequestDeleted    = "Deployment request deleted successfully"
-	MsgDeploymentsRetrieved        = "Deployments retrieved successfully"
-	MsgDeploymentRetrieved         = "Deployment retrieved successfully"
+	MsgDeploymentRequestsRetrieved  = "Deployment requests retrieved successfully"
+	MsgDeploymentRequestRetrieved   = "Deployment request retrieved successfully"
+	MsgDeploymentRequestCreated     = "Deployment request created successfully"
+	MsgDeploymentRequestUpdated     = "Deployment request updated successfully"
+	MsgDeploymentRequestDeleted     = "Deployment request deleted successfully"
+	MsgDeploymentRollbackRequested  = "Deployment rollback request created successfully"
+	MsgDeploymentRestartRequested   = "Deployment restart request created successfully"
+	MsgDeploymentPauseRequested     = "Deployment pause request created successfully"
+	MsgDeploymentResumeRequested    = "Deployment resume request created successfully"
+	MsgDeploymentsRetrieved         = "Deployments retrieved successfully"
+	MsgDeploymentRetrieved          = "Deployment retrieved successfully"
+	MsgDeadLettersRetrieved         = "Dead-lettered messages retrieved successfully"
+	MsgDeadLetterReplayed           = "Dead-lettered message replayed successfully"
+	MsgTemplatesRetrieved           = "Templates retrieved successfully"
+	MsgDeploymentRevisionsRetrieved = "Deployment revisions retrieved successfully"
+	MsgDeploymentRevisionDiff       = "Deployment revision diff computed successfully"
 
 	ErrMsgUserIDNotFound                       = "User ID not found"
 	ErrMsgRequestIDNotFound                    = "Request ID not found"
@@ -38,31 +119,105 @@ const (
 	ErrMsgFailedToListDeploymentRequests       = "Failed to list deployment requests"
 	ErrMsgDeploymentRequestNotFound            = "Deployment request not found"
 	ErrMsgFailedToGetDeploymentRequest         = "Failed to get deployment request"
+	ErrMsgFailedToStreamDeploymentRequest      = "Failed to stream deployment request status"
 	ErrMsgDeploymentAlreadyExists              = "Deployment already exists"
 	ErrMsgFailedToCreateDeploymentRequest      = "Failed to create deployment request"
 	ErrMsgRequestIDHeaderRequired              = "X-Request-ID header is required"
+	ErrMsgInvalidRequestIDHeader               = "X-Request-ID may only contain letters, digits, '-', '_' and ':' (at most 255)"
 	ErrMsgFailedToCheckDeploymentRequest       = "Failed to check existing deployment request"
 	ErrMsgDeploymentRequestSameRequestIDExists = "Deployment request with same request ID already exists"
-	ErrMsgUserIDHeaderRequired                 = "X-User-ID header is required"
-	ErrMsgUserNotFoundResponse                 = "User not found"
-	ErrMsgFailedToCreateUser                   = "Failed to create user"
+	ErrMsgAuthenticationRequired               = "Authentication required"
+	ErrMsgInvalidCredentials                   = "Invalid credentials"
+	ErrMsgFailedToAuthenticate                 = "Failed to authenticate"
+	ErrMsgInsufficientScope                    = "Credentials lack the required scope"
+	MsgAPIKeyCreated                           = "API key created successfully; store the key now, it is not shown again"
+	MsgAPIKeysRetrieved                        = "API keys retrieved successfully"
+	MsgAPIKeyRevoked                           = "API key revoked successfully"
+	ErrMsgFailedToCreateAPIKey                 = "Failed to create API key"
+	ErrMsgFailedToListAPIKeys                  = "Failed to list API keys"
+	ErrMsgFailedToRevokeAPIKey                 = "Failed to revoke API key"
+	ErrMsgAPIKeyNotFound                       = "API key not found"
+	ErrMsgAPIKeyIDInvalid                      = "API key ID must be a valid UUID"
+	ErrMsgInsufficientTeamRole                 = "Your team role does not allow this action"
+	MsgTeamCreated                             = "Team created successfully"
+	MsgTeamsRetrieved                          = "Teams retrieved successfully"
+	MsgTeamMembersRetrieved                    = "Team members retrieved successfully"
+	MsgTeamMemberSet                           = "Team member saved successfully"
+	MsgTeamMemberRemoved                       = "Team member removed successfully"
+	ErrMsgFailedToCreateTeam                   = "Failed to create team"
+	ErrMsgFailedToListTeams                    = "Failed to list teams"
+	ErrMsgFailedToListTeamMembers              = "Failed to list team members"
+	ErrMsgFailedToSetTeamMember                = "Failed to save team member"
+	ErrMsgFailedToRemoveTeamMember             = "Failed to remove team member"
+	ErrMsgTeamNotFound                         = "Team not found"
+	ErrMsgTeamMemberNotFound                   = "Team member not found"
+	ErrMsgTeamNameTaken                        = "Team name is already taken"
+	ErrMsgLastTeamAdmin                        = "A team must keep at least one admin"
 	ErrMsgFailedToListDeployments              = "Failed to list deployments"
 	ErrMsgDeploymentNotFound                   = "Deployment not found"
 	ErrMsgFailedToGetDeployment                = "Failed to get deployment"
 	ErrMsgIdentifierRequired                   = "Identifier is required"
+	ErrMsgFailedToListDeadLetters              = "Failed to list dead-lettered messages"
+	ErrMsgDeadLetterNotFound                   = "Dead-lettered message not found"
+	ErrMsgDeadLetterIDInvalid                  = "Dead-lettered message ID must be a valid UUID"
+	ErrMsgFailedToReplayDeadLetter             = "Failed to replay dead-lettered message"
+	ErrMsgInvalidDeploymentRequest             = "Invalid deployment request"
+	ErrMsgFailedToListTemplates                = "Failed to list templates"
+	ErrMsgFailedToCreateRollbackRequest        = "Failed to create rollback request"
+	ErrMsgFailedToCreateOperationRequest       = "Failed to create deployment operation request"
+	ErrMsgFailedToListDeploymentRevisions      = "Failed to list deployment revisions"
+	ErrMsgFailedToDiffDeploymentRevisions      = "Failed to diff deployment revisions"
+	ErrMsgInvalidRevisionParams                = "Query parameters from and to must be revision numbers"
+	ErrMsgDeploymentRevisionNotFound           = "Deployment revision not found"
+	ErrMsgNamespaceNotAllowed                  = "Namespace is not allowed"
+	ErrMsgQuotaExceeded                        = "Quota exceeded"
+	MsgQuotasRetrieved                         = "Quotas retrieved successfully"
+	ErrMsgFailedToGetQuotas                    = "Failed to get quotas"
+	MsgWebhookCreated                          = "Webhook created successfully"
+	MsgWebhooksRetrieved                       = "Webhooks retrieved successfully"
+	MsgWebhookDeleted                          = "Webhook deleted successfully"
+	MsgWebhookDeliveriesRetrieved              = "Webhook deliveries retrieved successfully"
+	MsgWebhookRedelivered                      = "Webhook delivery scheduled for redelivery"
+	ErrMsgFailedToCreateWebhook                = "Failed to create webhook"
+	ErrMsgFailedToListWebhooks                 = "Failed to list webhooks"
+	ErrMsgFailedToDeleteWebhook                = "Failed to delete webhook"
+	ErrMsgFailedToListWebhookDeliveries        = "Failed to list webhook deliveries"
+	ErrMsgFailedToRedeliverWebhook             = "Failed to redeliver webhook delivery"
+	ErrMsgWebhookNotFound                      = "Webhook not found"
+	ErrMsgWebhookDeliveryNotFound              = "Webhook delivery not found"
+	ErrMsgInvalidCursor                        = "Invalid pagination cursor"
+	MsgDeploymentScheduleCreated               = "Deployment schedule created successfully"
+	MsgDeploymentSchedulesRetrieved            = "Deployment schedules retrieved successfully"
+	MsgDeploymentScheduleDeleted               = "Deployment schedule deleted successfully"
+	ErrMsgFailedToCreateDeploymentSchedule     = "Failed to create deployment schedule"
+	ErrMsgFailedToListDeploymentSchedules      = "Failed to list deployment schedules"
+	ErrMsgFailedToDeleteDeploymentSchedule     = "Failed to delete deployment schedule"
+	ErrMsgDeploymentScheduleNotFound           = "Deployment schedule not found"
+	ErrMsgInvalidSchedule                      = "Invalid schedule"
 )
 
 // API response body keys
 const (
-	ResponseKeyMessage = "message"
-	ResponseKeyError   = "error"
-	ResponseKeyDetails = "details"
-	ResponseKeyParam   = "param"
+	ResponseKeyMessage   = "message"
+	ResponseKeyError     = "error"
+	ResponseKeyDetails   = "details"
+	ResponseKeyParam     = "param"
+	ResponseKeyScope     = "scope"
+	ResponseKeyViolation = "violation"
 )
 
 // Path param names
 const (
-	ParamID = "id"
+	ParamID       = "id"
+	ParamMember   = "member"   // external user ID of a team member
+	ParamDelivery = "delivery" // ID of a webhook delivery
+	ParamSchedule = "schedule" // ID of a deployment schedule
+)
+
+// Query param names
+const (
+	QueryParamFrom = "from"
+	QueryParamTo   = "to"
 )
 
 // Context key constants
@@ -73,16 +228,41 @@ const (
 	UserIDKey = "user_id"
 	// UserKey is the context key for storing user object
 	UserKey = "user"
+	// PrincipalKey is the context key for storing the authenticated principal (user and scopes)
+	PrincipalKey = "principal"
 )
 
 // K8s / template constants
 const (
-	TemplateNginx       = "nginx"
-	ConfigMapIndexHTML  = "index.html"
-	ConfigMapHTMLSuffix = "-html"
-	MapstructureTagJSON = "json"
+	TemplatesDir           = "templates"
+	TemplateManifestFile   = "manifest.yaml"
+	TemplateDeploymentFile = "deployment.yaml"
+	ConfigMapIndexHTML     = "index.html"
+	ConfigMapHTMLSuffix    = "-html"
+	MapstructureTagJSON    = "json"
 	// LabelKeyManagedBy is the label key for filtering deployments by manager (value from config manager_tag).
 	LabelKeyManagedBy = "managed-by"
+	// LabelKeyIdentifier is the label key holding the deployment identifier on every object created for it.
+	LabelKeyIdentifier = "identifier"
+	// LabelKeyUserID is the label key holding the ID of the user that created the deployment.
+	LabelKeyUserID = "user-id"
+	// LabelKeyTeamID is the label key holding the ID of the team owning the deployment (absent for personal deployments).
+	LabelKeyTeamID = "team-id"
+	// LabelKeyRequestID is the label key holding the request ID that created the deployment.
+	LabelKeyRequestID = "request-id"
+	// LabelKeyTrack is the label key telling a canary deployment and its pods (TrackCanary) apart from the deployment's own.
+	LabelKeyTrack = "track"
+	// TrackCanary is the LabelKeyTrack value of canary deployments
+	TrackCanary = "canary"
+	// CanaryDeploymentSuffix is appended to the identifier to name a deployment's canary deployment
+	CanaryDeploymentSuffix = "-canary"
+	// AnnotationKeyRestartedAt is the pod template annotation a restart sets, the one `kubectl rollout restart` uses.
+	AnnotationKeyRestartedAt = "kubectl.kubernetes.io/restartedAt"
+	// AnnotationKeyContentHash is the pod template annotation holding the hash of the doc_html content, so
+	// pods are restarted when it changes.
+	AnnotationKeyContentHash = "content-hash"
+	// AnnotationKeyLastRequestID is the annotation key holding the request ID that last applied the deployment.
+	AnnotationKeyLastRequestID = "last-request-id"
 )
 
 // Conflict detection: substring used to detect "already exists" errors
@@ -91,8 +271,89 @@ const StrAlreadyExists = "already exists"
 // K8s config validation
 const ErrMsgK8sManagerTagRequired = "k8s config manager-tag is required"
 
-// Worker queue group default
-const QueueGroupDeploymentWorkers = "deployment-workers"
+// FieldOwnershipConflictHint is appended to the failure reason of requests rejected by an apply conflict
+const FieldOwnershipConflictHint = "resubmit with force_ownership to take over the conflicting fields"
+
+// DefaultRolloutTimeoutSeconds bounds the rollout wait of requests that set wait_for_rollout without a timeout
+const DefaultRolloutTimeoutSeconds = 300
+
+// Scaling schedules
+const (
+	// DefaultScheduleTimezone evaluates the cron expression of schedules created without a timezone
+	DefaultScheduleTimezone = "UTC"
+	// ScheduleRequestIDPrefix starts the request ID of requests emitted by a schedule, followed by the
+	// schedule ID and the Unix time of the run, so a run emits at most one request
+	ScheduleRequestIDPrefix = "schedule-"
+)
+
+// Rollout strategy types
+const (
+	StrategyRollingUpdate = "RollingUpdate"
+	StrategyRecreate      = "Recreate"
+	StrategyCanary        = "Canary"
+)
+
+// DefaultCanarySoakSeconds is how long a canary must stay healthy when the request sets no soak period
+const DefaultCanarySoakSeconds = 60
+
+// RolloutPollInterval is how often the worker checks rollout progress
+const RolloutPollInterval = 2 * time.Second
+
+// Progressing condition reasons of a deployment whose rollout is stuck, and of one whose rollout completed
+const (
+	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	ReasonNewReplicaSetAvailable   = "NewReplicaSetAvailable"
+)
+
+// SSEEventStatus is the event name of the deployment request status stream
+const SSEEventStatus = "status"
+
+// StatusStreamHeartbeatInterval is how often an idle status stream sends a keepalive comment
+const StatusStreamHeartbeatInterval = 15 * time.Second
+
+// StatusStreamResyncInterval is how often a status stream re-reads the request, in case a status event
+// was lost (core NATS delivers at most once)
+const StatusStreamResyncInterval = 30 * time.Second
+
+// Deployment event types, delivered to the webhooks subscribed to them
+const (
+	EventRequestSucceeded  = "deployment_request.succeeded"
+	EventRequestFailed     = "deployment_request.failed"
+	EventDeploymentDeleted = "deployment.deleted"
+	// EventDeploymentDegraded is sent when a deployment that finished its rollout loses available replicas
+	// or exceeds its progress deadline
+	EventDeploymentDegraded = "deployment.degraded"
+)
+
+// AllEventTypes lists every event type a webhook can subscribe to
+var AllEventTypes = []string{EventRequestSucceeded, EventRequestFailed, EventDeploymentDeleted, EventDeploymentDegraded}
+
+// Webhook request headers. The signature is "sha256=" followed by the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret.
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+	WebhookSignaturePrefix = "sha256="
+)
+
+// WebhookDeliveriesLimit caps the deliveries listed per webhook, newest first
+const WebhookDeliveriesLimit = 100
+
+// Default queue groups of the worker routes. Each route needs its own: the durable pull consumer
+// name defaults to the queue group, and one durable cannot serve two filter subjects.
+const (
+	QueueGroupDeploymentWorkers       = "deployment-workers"
+	QueueGroupDeploymentUpdateWorkers = "deployment-update-workers"
+	QueueGroupDeadLetterWorkers       = "dead-letter-workers"
+)
+
+// QueueGroupWebhookWorkers is the default queue group of the webhook event consumer
+const QueueGroupWebhookWorkers = "webhook-workers"
+
+// DefaultStreamDuplicates is the JetStream server default deduplication window
+const DefaultStreamDuplicates = 2 * time.Minute
 
 // Error variables
 var (
@@ -102,10 +363,70 @@ var (
 	ErrInvalidUserID = errors.New("invalid user ID")
 	// ErrDeploymentRequestNotFound is returned when deployment request is not found or not owned by user
 	ErrDeploymentRequestNotFound = errors.New("deployment request not found")
+	// ErrPrincipalNotFoundInContext is returned when the authenticated principal is missing from context
+	ErrPrincipalNotFoundInContext = errors.New("principal not found in context")
 	// ErrRequestIDNotFoundInContext is returned when request ID is missing from context
 	ErrRequestIDNotFoundInContext = errors.New("request ID not found in context")
 	// ErrInvalidRequestIDTypeInContext is returned when request ID in context has wrong type
 	ErrInvalidRequestIDTypeInContext = errors.New("invalid request ID type in context")
 	// ErrDeploymentNotFound is returned when deployment is not found or not owned by user
 	ErrDeploymentNotFound = errors.New("deployment not found")
+	// ErrDeadLetterNotFound is returned when a dead-lettered message is not found or not owned by user
+	ErrDeadLetterNotFound = errors.New("dead-lettered message not found")
+	// ErrUnsupportedImage is returned when no template matches the requested image
+	ErrUnsupportedImage = errors.New("unsupported image: no template matches")
+	// ErrMetadataKeyNotAllowed is returned when request metadata uses a key the template does not accept
+	ErrMetadataKeyNotAllowed = errors.New("metadata key not allowed by template")
+	// ErrInvalidStrategy is returned when a rollout strategy is inconsistent or does not fit the request
+	ErrInvalidStrategy = errors.New("invalid rollout strategy")
+	// ErrInvalidAutoscaling is returned when autoscaling settings are inconsistent or conflict with the request
+	ErrInvalidAutoscaling = errors.New("invalid autoscaling settings")
+	// ErrCanaryUnhealthy is returned when a canary deployment is not healthy at the end of its soak period
+	ErrCanaryUnhealthy = errors.New("canary unhealthy")
+	// ErrImageTemplateMismatch is returned when an image update targets an image of a different template
+	ErrImageTemplateMismatch = errors.New("image does not match the deployment's template")
+	// ErrFieldOwnershipConflict is returned when a server-side apply touches fields owned by another field manager
+	ErrFieldOwnershipConflict = errors.New("field ownership conflict")
+	// ErrRolloutFailed is returned when a rollout is stuck or does not complete within its timeout
+	ErrRolloutFailed = errors.New("rollout failed")
+	// ErrInvalidRollbackTarget is returned when the rollback target is not an earlier successful request of the deployment
+	ErrInvalidRollbackTarget = errors.New("invalid rollback target")
+	// ErrNoCredentials is returned by an authenticator when the request carries none of its credentials
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned by an authenticator when its credentials are present but not valid
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrAPIKeyNotFound is returned when an API key does not exist or belongs to another user
+	ErrAPIKeyNotFound = errors.New("api key not found")
+	// ErrScopeNotGranted is returned when a new API key asks for a scope the caller's credentials lack
+	ErrScopeNotGranted = errors.New("scope not granted to caller")
+	// ErrResourceNotVisible is returned by the policy when the caller cannot see the resource at all
+	ErrResourceNotVisible = errors.New("resource not visible to caller")
+	// ErrInsufficientTeamRole is returned by the policy when the caller's team role does not allow the action
+	ErrInsufficientTeamRole = errors.New("insufficient team role")
+	// ErrTeamNotFound is returned when a team does not exist or the caller is not a member
+	ErrTeamNotFound = errors.New("team not found")
+	// ErrTeamMemberNotFound is returned when the user is not a member of the team
+	ErrTeamMemberNotFound = errors.New("team member not found")
+	// ErrTeamNameTaken is returned when a team with the same name exists
+	ErrTeamNameTaken = errors.New("team name already taken")
+	// ErrLastTeamAdmin is returned when a change would leave a team without an admin
+	ErrLastTeamAdmin = errors.New("team must keep at least one admin")
+	// ErrDeploymentRevisionNotFound is returned when a revision of a deployment is not recorded
+	ErrDeploymentRevisionNotFound = errors.New("deployment revision not found")
+	// ErrNamespaceNotAllowed is returned by admission when the owner may not deploy to the namespace
+	ErrNamespaceNotAllowed = errors.New("namespace not allowed")
+	// ErrQuotaExceeded is returned by admission when a request would take the owner over a quota
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrInvalidResourceQuantity is returned when a CPU or memory value in the request cannot be parsed
+	ErrInvalidResourceQuantity = errors.New("invalid resource quantity")
+	// ErrWebhookNotFound is returned when a webhook does not exist or belongs to another user
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a delivery does not exist or belongs to another webhook
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrInvalidCursor is returned when a pagination cursor is malformed or was issued for another sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidSchedule is returned when a cron expression or timezone of a scaling schedule cannot be parsed
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	// ErrDeploymentScheduleNotFound is returned when a scaling schedule does not exist or belongs to another deployment
+	ErrDeploymentScheduleNotFound = errors.New("deployment schedule not found")
 )
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is a static API key of a user. Only the SHA-256 hash of the key's secret is stored;
+// KeyID is the public part of the key used to look it up. Scopes are space-delimited.
+type APIKey struct {
+	Common
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(255);not null" json:"name"`
+	KeyID      string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"key_id"`
+	SecretHash string     `gorm:"type:varchar(64);not null" json:"-"`
+	Scopes     string     `gorm:"type:text;not null;default:''" json:"scopes"`
+	ExpiresOn  *time.Time `gorm:"type:timestamp" json:"expires_on,omitempty"`
+	LastUsedOn *time.Time `gorm:"type:timestamp" json:"last_used_on,omitempty"`
+	RevokedOn  *time.Time `gorm:"type:timestamp" json:"revoked_on,omitempty"`
+}
+
+// TableName specifies the table name for APIKey
+func (APIKey) TableName() string {
+	return "api_keys"
+}
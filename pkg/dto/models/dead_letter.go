@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterStatus represents the status of a dead-lettered message
+type DeadLetterStatus string
+
+const (
+	DeadLetterStatusPending  DeadLetterStatus = "PENDING"
+	DeadLetterStatusReplayed DeadLetterStatus = "REPLAYED"
+)
+
+// DeadLetter represents a message whose retries were exhausted and that was
+// shifted to the dead-letter channel. It keeps the original payload and headers
+// so it can be replayed onto its original subject. A dead-letter message is identified by its stream, stream
+// sequence and the time the stream stored it, since sequences start over when the stream is recreated.
+type DeadLetter struct {
+	Common
+	Subject  string `gorm:"type:varchar(255);not null" json:"subject"`
+	Stream   string `gorm:"type:varchar(255);not null;default:'';uniqueIndex:idx_dead_letter_message,priority:1" json:"stream"`
+	Sequence uint64 `gorm:"not null;uniqueIndex:idx_dead_letter_message,priority:2" json:"sequence"`
+	// StoredOn is when the stream stored the dead-letter message (NULL for rows recorded before it was kept)
+	StoredOn        *time.Time       `gorm:"type:timestamp;uniqueIndex:idx_dead_letter_message,priority:3" json:"stored_on,omitempty"`
+	OriginalSubject string           `gorm:"type:varchar(255);not null" json:"original_subject"`
+	RequestID       *string          `gorm:"type:varchar(255);index" json:"request_id,omitempty"`
+	UserID          *uuid.UUID       `gorm:"type:uuid;index:idx_dead_letter_user_status" json:"user_id,omitempty"`
+	Status          DeadLetterStatus `gorm:"type:varchar(50);not null;index:idx_dead_letter_user_status" json:"status"`
+	Attempts        int              `gorm:"not null;default:0" json:"attempts"`
+	LastError       string           `gorm:"type:text" json:"last_error"`
+	ReplayCount     int              `gorm:"not null;default:0" json:"replay_count"`
+	Payload         []byte           `gorm:"type:bytea" json:"payload"`
+	Headers         JSONB            `gorm:"type:jsonb" json:"headers"`
+}
+
+// TableName specifies the table name for DeadLetter
+func (DeadLetter) TableName() string {
+	return "dead_letters"
+}
@@ -9,23 +9,41 @@ type DeploymentStatus string
 
 const (
 	DeploymentStatusInitiated DeploymentStatus = "INITIATED"
-	DeploymentStatusCreated    DeploymentStatus = "CREATED"
-	DeploymentStatusUpdating   DeploymentStatus = "UPDATING"
-	DeploymentStatusDeleted    DeploymentStatus = "DELETED"
+	DeploymentStatusCreated   DeploymentStatus = "CREATED"
+	DeploymentStatusUpdating  DeploymentStatus = "UPDATING"
+	DeploymentStatusDeleted   DeploymentStatus = "DELETED"
+	// DeploymentStatusDegraded marks a deployment whose rollout exceeded its progress deadline or
+	// that lost availability after rolling out
+	DeploymentStatusDegraded DeploymentStatus = "DEGRADED"
+	// DeploymentStatusScaledToZero marks a deployment scaled to 0 replicas with no pods left
+	DeploymentStatusScaledToZero DeploymentStatus = "SCALED_TO_ZERO"
 )
 
 // Deployment represents a deployment
 type Deployment struct {
 	Common
-	Identifier     string          `gorm:"type:varchar(63);uniqueIndex;not null" json:"identifier"`
-	Name           string          `gorm:"type:varchar(255)" json:"name"`
-	Namespace      string          `gorm:"type:varchar(255)" json:"namespace"`
-	Image          string          `gorm:"type:varchar(255)" json:"image"`
-	Status         DeploymentStatus `gorm:"type:varchar(50);not null;index:idx_deployment_user_status" json:"status"`
-	UserID         uuid.UUID       `gorm:"type:uuid;not null;index:idx_deployment_user_status" json:"user_id"`
-	ResourceVersion string          `gorm:"type:varchar(255)" json:"resource_version"`
-	Metadata       JSONB           `gorm:"type:jsonb" json:"metadata"`
-	
+	Identifier string           `gorm:"type:varchar(63);uniqueIndex;not null" json:"identifier"`
+	Name       string           `gorm:"type:varchar(255)" json:"name"`
+	Namespace  string           `gorm:"type:varchar(255)" json:"namespace"`
+	Image      string           `gorm:"type:varchar(255)" json:"image"`
+	Status     DeploymentStatus `gorm:"type:varchar(50);not null;index:idx_deployment_user_status" json:"status"`
+	UserID     uuid.UUID        `gorm:"type:uuid;not null;index:idx_deployment_user_status" json:"user_id"`
+	// TeamID is the owning team; nil for personal deployments, which only UserID can access
+	TeamID          *uuid.UUID `gorm:"type:uuid;index" json:"team_id,omitempty"`
+	ResourceVersion string     `gorm:"type:varchar(255)" json:"resource_version"`
+	// Replicas, PodCPUMillis and PodMemoryBytes size the deployment for quotas: desired replicas and the
+	// CPU/memory limits of one pod (summed over its containers), synced from Kubernetes
+	Replicas       int   `gorm:"not null;default:0" json:"replicas"`
+	PodCPUMillis   int64 `gorm:"not null;default:0" json:"pod_cpu_millis"`
+	PodMemoryBytes int64 `gorm:"not null;default:0" json:"pod_memory_bytes"`
+	// Autoscaling* report the deployment's HorizontalPodAutoscaler: its bounds and the replica count it
+	// wants and sees, synced from Kubernetes; nil when the deployment is not autoscaled
+	AutoscalingMinReplicas     *int  `gorm:"type:int" json:"autoscaling_min_replicas,omitempty"`
+	AutoscalingMaxReplicas     *int  `gorm:"type:int" json:"autoscaling_max_replicas,omitempty"`
+	AutoscalingDesiredReplicas *int  `gorm:"type:int" json:"autoscaling_desired_replicas,omitempty"`
+	AutoscalingCurrentReplicas *int  `gorm:"type:int" json:"autoscaling_current_replicas,omitempty"`
+	Metadata                   JSONB `gorm:"type:jsonb" json:"metadata"`
+
 	// Foreign key relationship
 	User User `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
 }
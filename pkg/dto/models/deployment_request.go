@@ -13,6 +13,34 @@ const (
 	DeploymentRequestStatusFailure DeploymentRequestStatus = "FAILURE"
 )
 
+// IsTerminal reports whether the worker is done with a request in this status
+func (s DeploymentRequestStatus) IsTerminal() bool {
+	return s == DeploymentRequestStatusSuccess || s == DeploymentRequestStatusFailure
+}
+
+// DeploymentRequestPhase is the progress of a CREATED request towards its final status; only canary
+// updates report phases
+type DeploymentRequestPhase string
+
+const (
+	// DeploymentRequestPhaseCanaryDeploying: the canary deployment is applied and rolling out
+	DeploymentRequestPhaseCanaryDeploying DeploymentRequestPhase = "CANARY_DEPLOYING"
+	// DeploymentRequestPhaseCanarySoaking: the canary is ready and must stay healthy for the soak period
+	DeploymentRequestPhaseCanarySoaking DeploymentRequestPhase = "CANARY_SOAKING"
+	// DeploymentRequestPhasePromoting: the canary was healthy and the update is applied to the deployment
+	DeploymentRequestPhasePromoting DeploymentRequestPhase = "PROMOTING"
+	// DeploymentRequestPhasePromoted: the update is rolled out and the canary removed
+	DeploymentRequestPhasePromoted DeploymentRequestPhase = "PROMOTED"
+	// DeploymentRequestPhaseAborted: the canary or its promotion failed and the canary was removed
+	DeploymentRequestPhaseAborted DeploymentRequestPhase = "ABORTED"
+)
+
+// IsCanaryInProgress reports whether a worker may still be running the canary of a request in this phase
+func (p DeploymentRequestPhase) IsCanaryInProgress() bool {
+	return p == DeploymentRequestPhaseCanaryDeploying || p == DeploymentRequestPhaseCanarySoaking ||
+		p == DeploymentRequestPhasePromoting
+}
+
 // DeploymentRequestType represents the type of deployment request
 type DeploymentRequestType string
 
@@ -20,6 +48,14 @@ const (
 	DeploymentRequestTypeCreate DeploymentRequestType = "CREATE"
 	DeploymentRequestTypeUpdate DeploymentRequestType = "UPDATE"
 	DeploymentRequestTypeDelete DeploymentRequestType = "DELETE"
+	// DeploymentRequestTypeRollback restores the state a deployment had after an earlier successful request
+	DeploymentRequestTypeRollback DeploymentRequestType = "ROLLBACK"
+	// DeploymentRequestTypeRestart restarts the deployment's pods with a rolling update
+	DeploymentRequestTypeRestart DeploymentRequestType = "RESTART"
+	// DeploymentRequestTypePause pauses the deployment's rollouts (spec.paused)
+	DeploymentRequestTypePause DeploymentRequestType = "PAUSE"
+	// DeploymentRequestTypeResume resumes the deployment's rollouts
+	DeploymentRequestTypeResume DeploymentRequestType = "RESUME"
 )
 
 // DeploymentRequest represents a deployment request
@@ -31,10 +67,26 @@ type DeploymentRequest struct {
 	Namespace     string                  `gorm:"type:varchar(255);index:idx_deployment_request_name_namespace,priority:1" json:"namespace"`
 	RequestType   DeploymentRequestType   `gorm:"type:varchar(50);not null" json:"request_type"`
 	UserID        uuid.UUID               `gorm:"type:uuid;not null;index:idx_deployment_request_user_status" json:"user_id"`
+	TeamID        *uuid.UUID              `gorm:"type:uuid;index" json:"team_id,omitempty"` // owning team; nil for personal deployments
 	Status        DeploymentRequestStatus `gorm:"type:varchar(50);not null;index:idx_deployment_request_user_status" json:"status"`
 	FailureReason *string                 `gorm:"type:text" json:"failure_reason,omitempty"`
 	Image         string                  `gorm:"type:varchar(255);not null" json:"image"`
 	Metadata      JSONB                   `gorm:"type:jsonb" json:"metadata"`
+	// Phase is the sub-state of a canary update (empty for other requests)
+	Phase DeploymentRequestPhase `gorm:"type:varchar(50)" json:"phase,omitempty"`
+	// ReapCount is how many times the stuck-request reaper has republished this request
+	ReapCount int `gorm:"not null;default:0" json:"reap_count"`
+	// ForceOwnership makes the worker's server-side apply take over fields owned by other field managers
+	ForceOwnership bool `gorm:"not null;default:false" json:"force_ownership"`
+	// WaitForRollout makes the worker wait for the rollout to complete before marking the request SUCCESS
+	WaitForRollout bool `gorm:"not null;default:false" json:"wait_for_rollout"`
+	// RolloutTimeoutSeconds bounds the rollout wait when WaitForRollout is set
+	RolloutTimeoutSeconds int `gorm:"not null;default:0" json:"rollout_timeout_seconds"`
+	// Snapshot holds the fields an UPDATE or ROLLBACK changes as they were before it was applied,
+	// so the worker can restore them when the request fails
+	Snapshot JSONB `gorm:"type:jsonb" json:"snapshot,omitempty"`
+	// ScheduleID is the scaling schedule that emitted the request (nil for requests made through the API)
+	ScheduleID *uuid.UUID `gorm:"type:uuid;index" json:"schedule_id,omitempty"`
 
 	// Foreign key relationship
 	User User `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
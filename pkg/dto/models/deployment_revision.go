@@ -0,0 +1,21 @@
+package models
+
+// DeploymentRevision is the state of a deployment's spec at one generation. The watcher-driven
+// deployment update records a revision whenever the generation changes.
+type DeploymentRevision struct {
+	Common
+	Identifier string `gorm:"type:varchar(63);not null;uniqueIndex:idx_deployment_revision_identifier_revision,priority:1" json:"identifier"`
+	// Revision is the Kubernetes metadata.generation of the deployment
+	Revision  int64  `gorm:"not null;uniqueIndex:idx_deployment_revision_identifier_revision,priority:2" json:"revision"`
+	Image     string `gorm:"type:varchar(255)" json:"image"`
+	Replicas  int32  `gorm:"not null;default:0" json:"replicas"`
+	Resources JSONB  `gorm:"type:jsonb" json:"resources"`
+	Spec      JSONB  `gorm:"type:jsonb" json:"spec"`
+	// RequestID is the deployment request that last applied the deployment (empty if changed outside the API)
+	RequestID string `gorm:"type:varchar(255)" json:"request_id"`
+}
+
+// TableName specifies the table name for DeploymentRevision
+func (DeploymentRevision) TableName() string {
+	return "deployment_revisions"
+}
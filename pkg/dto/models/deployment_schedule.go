@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeploymentSchedule scales the deployment with Identifier to ReplicaCount at the times matched by its
+// five-field Cron expression, evaluated in Timezone. Once NextRunAt has passed, the worker's scheduler emits
+// an UPDATE request on behalf of UserID, the user who created the schedule, and moves NextRunAt to the next
+// matching time; missed runs are not made up.
+type DeploymentSchedule struct {
+	Common
+	Identifier   string    `gorm:"type:varchar(63);not null;index" json:"identifier"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null" json:"user_id"`
+	Cron         string    `gorm:"type:varchar(100);not null" json:"cron"`
+	Timezone     string    `gorm:"type:varchar(64);not null" json:"timezone"`
+	ReplicaCount int       `gorm:"not null" json:"replica_count"`
+	NextRunAt    time.Time `gorm:"type:timestamp;not null;index" json:"next_run_at"`
+	// LastRunAt and LastRequestID record the last run and the request it emitted
+	LastRunAt     *time.Time `gorm:"type:timestamp" json:"last_run_at,omitempty"`
+	LastRequestID *string    `gorm:"type:varchar(255)" json:"last_request_id,omitempty"`
+}
+
+// TableName specifies the table name for DeploymentSchedule
+func (DeploymentSchedule) TableName() string {
+	return "deployment_schedules"
+}
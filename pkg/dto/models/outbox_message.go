@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// OutboxStatus represents the publishing status of an outbox message
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "PENDING"
+	OutboxStatusPublished OutboxStatus = "PUBLISHED"
+)
+
+// OutboxMessage is a NATS message written in the same transaction as the row it
+// announces. The outbox relay publishes pending messages to JetStream, using MsgID
+// as the Nats-Msg-Id so a message published twice is deduplicated by the stream.
+type OutboxMessage struct {
+	Common
+	MsgID       string       `gorm:"type:varchar(255);uniqueIndex;not null" json:"msg_id"`
+	Subject     string       `gorm:"type:varchar(255);not null" json:"subject"`
+	Payload     []byte       `gorm:"type:bytea" json:"payload"`
+	Headers     JSONB        `gorm:"type:jsonb" json:"headers"`
+	Status      OutboxStatus `gorm:"type:varchar(50);not null;index" json:"status"`
+	Attempts    int          `gorm:"not null;default:0" json:"attempts"`
+	LastError   string       `gorm:"type:text" json:"last_error"`
+	PublishedOn *time.Time   `gorm:"type:timestamp" json:"published_on,omitempty"`
+}
+
+// TableName specifies the table name for OutboxMessage
+func (OutboxMessage) TableName() string {
+	return "outbox"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// TeamRole is a member's role in a team
+type TeamRole string
+
+const (
+	// TeamRoleViewer can read the team's deployments and requests
+	TeamRoleViewer TeamRole = "VIEWER"
+	// TeamRoleDeployer can also create, update, delete and roll back the team's deployments
+	TeamRoleDeployer TeamRole = "DEPLOYER"
+	// TeamRoleAdmin can also manage the team's members
+	TeamRoleAdmin TeamRole = "ADMIN"
+)
+
+// Team owns deployments and their requests; its members access them according to their role
+type Team struct {
+	Common
+	Name string `gorm:"type:varchar(255);uniqueIndex;not null" json:"name"`
+}
+
+// TableName specifies the table name for Team
+func (Team) TableName() string {
+	return "teams"
+}
+
+// TeamMembership grants a user a role in a team
+type TeamMembership struct {
+	Common
+	TeamID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_team_membership_team_user,priority:1" json:"team_id"`
+	UserID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_team_membership_team_user,priority:2;index" json:"user_id"`
+	Role   TeamRole  `gorm:"type:varchar(50);not null" json:"role"`
+}
+
+// TableName specifies the table name for TeamMembership
+func (TeamMembership) TableName() string {
+	return "team_memberships"
+}
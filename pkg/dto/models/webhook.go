@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription subscribes a URL to deployment events. Without TeamID it receives the events of
+// UserID's personal deployments, with it those of the team's deployments while UserID is a member.
+// EventTypes are space-delimited. The secret is kept in clear because it keys the delivery signatures.
+type WebhookSubscription struct {
+	Common
+	UserID     uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	TeamID     *uuid.UUID `gorm:"type:uuid;index" json:"team_id,omitempty"`
+	URL        string     `gorm:"type:varchar(2048);not null" json:"url"`
+	EventTypes string     `gorm:"type:text;not null" json:"event_types"`
+	Secret     string     `gorm:"type:varchar(255);not null" json:"-"`
+}
+
+// TableName specifies the table name for WebhookSubscription
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// WebhookDeliveryStatus represents the status of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSuccess WebhookDeliveryStatus = "SUCCESS"
+	WebhookDeliveryStatusFailure WebhookDeliveryStatus = "FAILURE"
+)
+
+// WebhookDelivery is the delivery of one event to one subscription. The dispatcher sends PENDING deliveries
+// once NextAttemptAt has passed; Attempts counts the sends since the delivery was created or redelivered.
+type WebhookDelivery struct {
+	Common
+	SubscriptionID uuid.UUID             `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_delivery_subscription_event,priority:1" json:"subscription_id"`
+	EventID        string                `gorm:"type:varchar(255);not null;uniqueIndex:idx_webhook_delivery_subscription_event,priority:2" json:"event_id"`
+	EventType      string                `gorm:"type:varchar(100);not null" json:"event_type"`
+	Payload        []byte                `gorm:"type:bytea" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(50);not null;index:idx_webhook_delivery_due,priority:1" json:"status"`
+	Attempts       int                   `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time            `gorm:"type:timestamp;index:idx_webhook_delivery_due,priority:2" json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty"`
+	LastError      *string               `gorm:"type:text" json:"last_error,omitempty"`
+	DeliveredOn    *time.Time            `gorm:"type:timestamp" json:"delivered_on,omitempty"`
+}
+
+// TableName specifies the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryAttempt records one POST of a delivery: the response status code, or the error when the
+// request failed or the response was not 2xx
+type WebhookDeliveryAttempt struct {
+	Common
+	DeliveryID     uuid.UUID `gorm:"type:uuid;not null;index" json:"delivery_id"`
+	Attempt        int       `gorm:"not null" json:"attempt"`
+	StatusCode     *int      `json:"status_code,omitempty"`
+	Error          *string   `gorm:"type:text" json:"error,omitempty"`
+	DurationMillis int64     `gorm:"not null;default:0" json:"duration_ms"`
+}
+
+// TableName specifies the table name for WebhookDeliveryAttempt
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
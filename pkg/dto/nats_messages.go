@@ -1,5 +1,11 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // NATS message header key constants
 const (
 	HeaderKeyRequestID = "request_id"
@@ -16,3 +22,62 @@ type DeploymentUpdateMessage struct {
 	Identifier string `json:"identifier"`
 	EventType  string `json:"event_type"` // "add", "update", "delete"
 }
+
+// DeploymentStatusEvent is a status transition of a deployment request, published by the worker on the
+// deployment status channel and sent to clients as the data of the status stream's events
+type DeploymentStatusEvent struct {
+	RequestID     string    `json:"request_id"`
+	Status        string    `json:"status"`
+	Phase         string    `json:"phase,omitempty"`
+	FailureReason *string   `json:"failure_reason,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// WebhookEvent is a request completion or deployment state change, published by the worker on the
+// deployment event channel and POSTed as-is to the webhooks subscribed to its type. ID identifies the
+// event: the same event is published and delivered once per webhook.
+type WebhookEvent struct {
+	ID         string                  `json:"id"`
+	Type       string                  `json:"type"`
+	OccurredAt time.Time               `json:"occurred_at"`
+	UserID     uuid.UUID               `json:"user_id"`
+	TeamID     *uuid.UUID              `json:"team_id,omitempty"`
+	Request    *WebhookEventRequest    `json:"request,omitempty"`
+	Deployment *WebhookEventDeployment `json:"deployment,omitempty"`
+}
+
+// WebhookEventRequest describes the deployment request of a deployment_request.* event
+type WebhookEventRequest struct {
+	RequestID     string  `json:"request_id"`
+	RequestType   string  `json:"request_type"`
+	Identifier    string  `json:"identifier"`
+	Name          string  `json:"name"`
+	Namespace     string  `json:"namespace"`
+	Status        string  `json:"status"`
+	FailureReason *string `json:"failure_reason,omitempty"`
+}
+
+// WebhookEventDeployment describes the deployment of a deployment.* event
+type WebhookEventDeployment struct {
+	Identifier string `json:"identifier"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	Status     string `json:"status"`
+	// Reason explains a degraded deployment (e.g. the replica counts or the progress deadline message)
+	Reason string `json:"reason,omitempty"`
+}
+
+// DeadLetterMessage is a message received on the dead-letter channel, with the
+// dead-letter headers (original subject, attempts, last error) already extracted
+type DeadLetterMessage struct {
+	Subject string
+	// Stream, Sequence and StoredOn identify the message in the dead-letter stream
+	Stream          string
+	Sequence        uint64
+	StoredOn        time.Time
+	OriginalSubject string
+	Attempts        int
+	LastError       string
+	Payload         []byte
+	Headers         map[string][]string
+}
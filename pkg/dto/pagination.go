@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Page sizes of the list endpoints
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 200
+)
+
+// Sort fields of the list endpoints; a leading "-" sorts descending
+const (
+	SortFieldCreatedAt = "created_at"
+	SortFieldName      = "name"
+	DefaultListSort    = "-" + SortFieldCreatedAt
+)
+
+// ListSort orders a list by a field; rows with the same value are ordered by ID in the same direction
+type ListSort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseListSort parses a sort query value such as "name" or "-created_at"; empty uses DefaultListSort
+func ParseListSort(value string) ListSort {
+	if value == "" {
+		value = DefaultListSort
+	}
+	return ListSort{
+		Field: strings.TrimPrefix(value, "-"),
+		Desc:  strings.HasPrefix(value, "-"),
+	}
+}
+
+// String returns the sort as a query value
+func (s ListSort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// PageCursor marks the last row of a page: the next page starts after its sort value and ID. Sort records
+// the order the cursor was issued for, so it cannot be reused with another one.
+type PageCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// DeploymentRequestFilter selects, orders and pages deployment requests; empty fields do not filter
+type DeploymentRequestFilter struct {
+	Status        string
+	Namespace     string
+	NamePrefix    string
+	RequestType   string
+	Image         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Sort          ListSort
+	After         *PageCursor
+	Limit         int
+}
+
+// DeploymentFilter selects, orders and pages deployments; empty fields do not filter
+type DeploymentFilter struct {
+	Status        string
+	Namespace     string
+	NamePrefix    string
+	Image         string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Sort          ListSort
+	After         *PageCursor
+	Limit         int
+}
+
+// NewPageCursor returns the cursor of a row for the sort
+func NewPageCursor(sort ListSort, id uuid.UUID, createdOn time.Time, name string) *PageCursor {
+	cursor := &PageCursor{Sort: sort.String(), ID: id}
+	if sort.Field == SortFieldName {
+		cursor.Value = name
+	} else {
+		cursor.Value = createdOn.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
@@ -0,0 +1,20 @@
+package dto
+
+// ReapResult summarises one run of the stuck-request reaper
+type ReapResult struct {
+	// Examined is the number of stale CREATED requests found
+	Examined int
+	// Republished requests were handed to the workers again
+	Republished int
+	// Succeeded requests were already applied in the cluster and marked SUCCESS
+	Succeeded int
+	// Failed requests could not be completed and were marked FAILURE
+	Failed int
+	// Errors counts requests that could not be examined (they are retried next run)
+	Errors int
+}
+
+// Repaired returns the number of requests the run moved forward
+func (r *ReapResult) Repaired() int {
+	return r.Republished + r.Succeeded + r.Failed
+}
@@ -1,26 +1,121 @@
 package dto
 
+import "time"
+
 // CreateDeploymentRequestWithMetadata represents a request to create a deployment with metadata
 type CreateDeploymentRequestWithMetadata struct {
 	Name      string             `json:"name" validate:"required,min=3,max=50"`
 	Namespace string             `json:"namespace" validate:"required,min=1,max=63"`
 	Image     string             `json:"image" validate:"required"`
 	Metadata  DeploymentMetadata `json:"metadata" validate:"required"`
+	// TeamID makes the deployment owned by the team (the caller must be a deployer or admin); omitted
+	// deployments are personal to the caller
+	TeamID string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+	// ForceOwnership takes over fields owned by other field managers instead of failing with a conflict
+	ForceOwnership bool `json:"force_ownership,omitempty"`
+	// WaitForRollout marks the request SUCCESS only once the rollout completes
+	WaitForRollout bool `json:"wait_for_rollout,omitempty"`
+	// RolloutTimeoutSeconds bounds the wait for the rollout (default 300)
+	RolloutTimeoutSeconds int `json:"rollout_timeout_seconds,omitempty" validate:"omitempty,gte=1,lte=3600"`
 }
 
 // DeploymentMetadata represents metadata for a deployment
 type DeploymentMetadata struct {
-	ReplicaCount  int              `json:"replica_count" validate:"required,gte=1,lte=100"`
+	// ReplicaCount is the number of pods; 0 creates the deployment scaled to zero
+	ReplicaCount  *int             `json:"replica_count" validate:"required,gte=0,lte=100"`
 	ResourceLimit ResourceMetadata `json:"resource_limit" validate:"required"`
-	DocHTML       string           `json:"doc_html" validate:"required"`
+	DocHTML       string           `json:"doc_html" validate:"omitempty"` // only for templates with a content mount path
+	// Strategy sets how the deployment rolls out its pods (RollingUpdate or Recreate)
+	Strategy *RolloutStrategy `json:"strategy,omitempty" validate:"omitempty"`
+	// Autoscaling scales the deployment with a HorizontalPodAutoscaler; replica_count is then the initial
+	// count and must lie between min_replicas and max_replicas
+	Autoscaling *AutoscalingSettings `json:"autoscaling,omitempty" validate:"omitempty"`
+}
+
+// AutoscalingSettings scales a deployment between min_replicas and max_replicas with a HorizontalPodAutoscaler
+// that targets the average CPU and/or memory utilization of its pods, in percent of their resource requests.
+// On updates, disabled removes the autoscaler and hands the replica count back to replica_count.
+type AutoscalingSettings struct {
+	MinReplicas int `json:"min_replicas,omitempty" validate:"omitempty,gte=1,lte=100"`
+	MaxReplicas int `json:"max_replicas,omitempty" validate:"omitempty,gte=1,lte=100"`
+	// TargetCPUUtilization and TargetMemoryUtilization are percentages; at least one is required
+	TargetCPUUtilization    int  `json:"target_cpu_utilization,omitempty" validate:"omitempty,gte=1,lte=1000"`
+	TargetMemoryUtilization int  `json:"target_memory_utilization,omitempty" validate:"omitempty,gte=1,lte=1000"`
+	Disabled                bool `json:"disabled,omitempty"`
+}
+
+// RolloutStrategy controls how a create or update rolls out the deployment's pods
+type RolloutStrategy struct {
+	// Type is RollingUpdate, Recreate or Canary (updates only)
+	Type string `json:"type" validate:"required,oneof=RollingUpdate Recreate Canary"`
+	// MaxSurge and MaxUnavailable tune RollingUpdate: a number of pods or a percentage (e.g. "25%")
+	MaxSurge       string `json:"max_surge,omitempty" validate:"omitempty,max=10"`
+	MaxUnavailable string `json:"max_unavailable,omitempty" validate:"omitempty,max=10"`
+	// Canary is required for the Canary type
+	Canary *CanaryStrategy `json:"canary,omitempty" validate:"omitempty"`
+}
+
+// CanaryStrategy runs an update on a separate <identifier>-canary deployment first, and only applies it
+// to the deployment once the canary stayed healthy for the soak period
+type CanaryStrategy struct {
+	// Replicas is the number of canary pods
+	Replicas int `json:"replicas" validate:"required,gte=1,lte=10"`
+	// SoakSeconds is how long the canary must stay healthy before it is promoted (default 60)
+	SoakSeconds int `json:"soak_seconds,omitempty" validate:"omitempty,gte=1,lte=3600"`
 }
 
 // UpdateDeploymentRequestMetadata represents optional metadata for updating a deployment
 // All fields are optional, but if resources is provided, all fields within it must be provided
 type UpdateDeploymentRequestMetadata struct {
-	ReplicaCount  *int              `json:"replica_count,omitempty" validate:"omitempty,gte=1,lte=100"`
+	// ReplicaCount scales the deployment; 0 scales it to zero
+	ReplicaCount  *int              `json:"replica_count,omitempty" validate:"omitempty,gte=0,lte=100"`
 	ResourceLimit *ResourceMetadata `json:"resource_limit,omitempty" validate:"omitempty"`
 	DocHTML       *string           `json:"doc_html,omitempty" validate:"omitempty"`
+	// Image replaces the container image (rolling update); it must match the deployment's template
+	Image *string `json:"image,omitempty" validate:"omitempty,min=1,max=255"`
+	// Strategy sets how the update rolls out: RollingUpdate, Recreate, or Canary for image and resource changes
+	Strategy *RolloutStrategy `json:"strategy,omitempty" validate:"omitempty"`
+	// Autoscaling adds or changes the deployment's HorizontalPodAutoscaler, or removes it when disabled.
+	// replica_count cannot be set while the deployment is autoscaled.
+	Autoscaling *AutoscalingSettings `json:"autoscaling,omitempty" validate:"omitempty"`
+	// ForceOwnership takes over fields owned by other field managers (e.g. replicas edited by hand)
+	ForceOwnership bool `json:"force_ownership,omitempty"`
+	// WaitForRollout marks the request SUCCESS only once the rollout completes
+	WaitForRollout bool `json:"wait_for_rollout,omitempty"`
+	// RolloutTimeoutSeconds bounds the wait for the rollout (default 300)
+	RolloutTimeoutSeconds int `json:"rollout_timeout_seconds,omitempty" validate:"omitempty,gte=1,lte=3600"`
+}
+
+// RollbackDeploymentRequest represents a request to restore the state a deployment had after an earlier successful request
+type RollbackDeploymentRequest struct {
+	// TargetRequestID is the request ID of the successful CREATE, UPDATE or ROLLBACK request to roll back to
+	TargetRequestID string `json:"target_request_id" validate:"required"`
+	// ForceOwnership takes over fields owned by other field managers
+	ForceOwnership bool `json:"force_ownership,omitempty"`
+	// WaitForRollout marks the request SUCCESS only once the rollout completes
+	WaitForRollout bool `json:"wait_for_rollout,omitempty"`
+	// RolloutTimeoutSeconds bounds the wait for the rollout (default 300)
+	RolloutTimeoutSeconds int `json:"rollout_timeout_seconds,omitempty" validate:"omitempty,gte=1,lte=3600"`
+}
+
+// DeploymentSnapshot is the decoded form of a request snapshot: the replica count, resources and image of
+// the first container, rollout strategy, doc_html and autoscaling a deployment had before an UPDATE or ROLLBACK
+// was applied. Only the fields the request changes are recorded; a deployment without autoscaler records
+// disabled autoscaling.
+type DeploymentSnapshot struct {
+	Replicas    *int                 `json:"replicas,omitempty"`
+	Container   string               `json:"container,omitempty"`
+	Resources   *SnapshotResources   `json:"resources,omitempty"`
+	Image       *string              `json:"image,omitempty"`
+	Strategy    *RolloutStrategy     `json:"strategy,omitempty"`
+	DocHTML     *string              `json:"doc_html,omitempty"`
+	Autoscaling *AutoscalingSettings `json:"autoscaling,omitempty"`
+}
+
+// SnapshotResources holds container resource requests and limits by resource name (e.g. "cpu": "500m")
+type SnapshotResources struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
 }
 
 type ResourceMetadata struct {
@@ -36,3 +131,70 @@ type ResourceLimitInfo struct {
 
 // Example request without body validation (for demonstration)
 // Some endpoints might not need body validation
+
+// CreateAPIKeyRequest represents a request to create an API key for the authenticated user
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=255"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=deployments:read deployments:write requests:read api_keys:manage teams:manage webhooks:manage"`
+	// ExpiresInSeconds is the key's lifetime; omitted keys never expire
+	ExpiresInSeconds int `json:"expires_in_seconds,omitempty" validate:"omitempty,gte=60"`
+}
+
+// CreateTeamRequest represents a request to create a team; the caller becomes its admin
+type CreateTeamRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// SetTeamMemberRequest adds a user to a team or changes its role
+type SetTeamMemberRequest struct {
+	UserExternalID string `json:"user_external_id" validate:"required"`
+	Role           string `json:"role" validate:"required,oneof=VIEWER DEPLOYER ADMIN"`
+}
+
+// CreateWebhookRequest subscribes a URL to deployment events. Without team_id the webhook receives the
+// events of the caller's personal deployments; with it, those of the team's deployments (the caller must
+// be a member).
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,http_url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=deployment_request.succeeded deployment_request.failed deployment.deleted deployment.degraded"`
+	// Secret keys the HMAC-SHA256 signature of every delivery
+	Secret string `json:"secret" validate:"required,min=16,max=255"`
+	TeamID string `json:"team_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// CreateDeploymentScheduleRequest scales a deployment to replica_count at the times matched by a cron
+// expression (minute hour day-of-month month day-of-week), evaluated in timezone (an IANA name, default UTC)
+type CreateDeploymentScheduleRequest struct {
+	Cron         string `json:"cron" validate:"required,max=100"`
+	Timezone     string `json:"timezone,omitempty" validate:"omitempty,max=64"`
+	ReplicaCount *int   `json:"replica_count" validate:"required,gte=0,lte=100"`
+}
+
+// ListDeploymentRequestsQuery holds the query parameters of GET /api/v1/deployments/requests. A page ends
+// with next_cursor when more rows follow; pass it as cursor, with the same filters and sort, for the next one.
+type ListDeploymentRequestsQuery struct {
+	Limit         int        `form:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor        string     `form:"cursor" validate:"omitempty,max=512"`
+	Sort          string     `form:"sort" validate:"omitempty,oneof=created_at -created_at name -name"`
+	Status        string     `form:"status" validate:"omitempty,oneof=CREATED SUCCESS FAILURE"`
+	Namespace     string     `form:"namespace" validate:"omitempty,max=255"`
+	NamePrefix    string     `form:"name_prefix" validate:"omitempty,max=255"`
+	RequestType   string     `form:"request_type" validate:"omitempty,oneof=CREATE UPDATE DELETE ROLLBACK RESTART PAUSE RESUME"`
+	Image         string     `form:"image" validate:"omitempty,max=255"`
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ListDeploymentsQuery holds the query parameters of GET /api/v1/deployments, paged like
+// ListDeploymentRequestsQuery
+type ListDeploymentsQuery struct {
+	Limit         int        `form:"limit" validate:"omitempty,min=1,max=200"`
+	Cursor        string     `form:"cursor" validate:"omitempty,max=512"`
+	Sort          string     `form:"sort" validate:"omitempty,oneof=created_at -created_at name -name"`
+	Status        string     `form:"status" validate:"omitempty,oneof=INITIATED CREATED UPDATING DEGRADED SCALED_TO_ZERO DELETED"`
+	Namespace     string     `form:"namespace" validate:"omitempty,max=255"`
+	NamePrefix    string     `form:"name_prefix" validate:"omitempty,max=255"`
+	Image         string     `form:"image" validate:"omitempty,max=255"`
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+}
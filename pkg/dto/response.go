@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
 
 // Standard response structures
 
@@ -16,6 +20,12 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// PagedResponse is a success response holding one page of a list; NextCursor is empty on the last page
+type PagedResponse struct {
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
 
 // DeploymentEvent represents a deployment event for NATS
 type DeploymentEvent struct {
@@ -44,41 +54,205 @@ type DeploymentRequestResponse struct {
 	Namespace   string                 `json:"namespace"`
 	Image       string                 `json:"image"`
 	Status      string                 `json:"status"`
+	Phase       string                 `json:"phase,omitempty"`
 	RequestType string                 `json:"request_type"`
+	TeamID      *uuid.UUID             `json:"team_id,omitempty"`
+	ScheduleID  *uuid.UUID             `json:"schedule_id,omitempty"`
 	Metadata    map[string]interface{} `json:"metadata"`
 }
 
 // DeploymentRequestListResponse represents a deployment request in list responses (no metadata, includes failure_reason)
 type DeploymentRequestListResponse struct {
-	RequestID     string  `json:"request_id"`
-	Identifier    string  `json:"identifier"`
-	Name          string  `json:"name"`
-	Namespace     string  `json:"namespace"`
-	Image         string  `json:"image"`
-	Status        string  `json:"status"`
-	RequestType   string  `json:"request_type"`
-	FailureReason *string `json:"failure_reason,omitempty"`
+	RequestID     string     `json:"request_id"`
+	Identifier    string     `json:"identifier"`
+	Name          string     `json:"name"`
+	Namespace     string     `json:"namespace"`
+	Image         string     `json:"image"`
+	Status        string     `json:"status"`
+	Phase         string     `json:"phase,omitempty"`
+	RequestType   string     `json:"request_type"`
+	TeamID        *uuid.UUID `json:"team_id,omitempty"`
+	ScheduleID    *uuid.UUID `json:"schedule_id,omitempty"`
+	FailureReason *string    `json:"failure_reason,omitempty"`
 }
 
 // DeploymentListResponse represents a deployment in list responses (limited fields)
 type DeploymentListResponse struct {
-	Identifier string `json:"identifier"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
-	Status     string `json:"status"`
-	Name       string `json:"name"`
-	Namespace  string `json:"namespace"`
+	Identifier string     `json:"identifier"`
+	CreatedAt  string     `json:"created_at"`
+	UpdatedAt  string     `json:"updated_at"`
+	Status     string     `json:"status"`
+	Name       string     `json:"name"`
+	Namespace  string     `json:"namespace"`
+	TeamID     *uuid.UUID `json:"team_id,omitempty"`
 }
 
 // DeploymentResponse represents a full deployment response with all data including metadata
 type DeploymentResponse struct {
-	ID          uuid.UUID              `json:"id"`
-	Identifier  string                 `json:"identifier"`
-	Name        string                 `json:"name"`
-	Namespace   string                 `json:"namespace"`
-	Image       string                 `json:"image"`
-	Status      string                 `json:"status"`
-	CreatedAt   string                 `json:"created_at"`
-	UpdatedAt   string                 `json:"updated_at"`
-	Metadata    map[string]interface{} `json:"metadata"`
+	ID         uuid.UUID  `json:"id"`
+	Identifier string     `json:"identifier"`
+	Name       string     `json:"name"`
+	Namespace  string     `json:"namespace"`
+	Image      string     `json:"image"`
+	Status     string     `json:"status"`
+	CreatedAt  string     `json:"created_at"`
+	UpdatedAt  string     `json:"updated_at"`
+	TeamID     *uuid.UUID `json:"team_id,omitempty"`
+	// Autoscaling is set while the deployment has a HorizontalPodAutoscaler
+	Autoscaling *DeploymentAutoscalingResponse `json:"autoscaling,omitempty"`
+	Metadata    map[string]interface{}         `json:"metadata"`
+}
+
+// DeploymentAutoscalingResponse reports a deployment's HorizontalPodAutoscaler: its bounds, the replica count
+// it last computed (desired) and the replica count it last observed (current)
+type DeploymentAutoscalingResponse struct {
+	MinReplicas     int `json:"min_replicas"`
+	MaxReplicas     int `json:"max_replicas"`
+	DesiredReplicas int `json:"desired_replicas"`
+	CurrentReplicas int `json:"current_replicas"`
+}
+
+// DeploymentRevisionResponse represents one recorded revision (generation) of a deployment
+type DeploymentRevisionResponse struct {
+	Revision  int64                  `json:"revision"`
+	Image     string                 `json:"image"`
+	Replicas  int32                  `json:"replicas"`
+	Resources map[string]interface{} `json:"resources"`
+	Spec      map[string]interface{} `json:"spec"`
+	RequestID string                 `json:"request_id,omitempty"`
+	CreatedAt string                 `json:"created_at"`
+}
+
+// DeploymentRevisionDiffResponse lists the spec fields that differ between two revisions
+type DeploymentRevisionDiffResponse struct {
+	Identifier string        `json:"identifier"`
+	From       int64         `json:"from"`
+	To         int64         `json:"to"`
+	Changes    []FieldChange `json:"changes"`
+}
+
+// FieldChange is one changed field of a JSON document; From or To is nil when the field was added or removed
+type FieldChange struct {
+	Path string      `json:"path"`
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// DeadLetterResponse represents a dead-lettered message in list responses
+type DeadLetterResponse struct {
+	ID              uuid.UUID  `json:"id"`
+	OriginalSubject string     `json:"original_subject"`
+	RequestID       *string    `json:"request_id,omitempty"`
+	UserID          *uuid.UUID `json:"user_id,omitempty"`
+	Status          string     `json:"status"`
+	Attempts        int        `json:"attempts"`
+	LastError       string     `json:"last_error"`
+	ReplayCount     int        `json:"replay_count"`
+	Payload         string     `json:"payload"`
+	CreatedAt       string     `json:"created_at"`
+	UpdatedAt       string     `json:"updated_at"`
+}
+
+// APIKeyResponse represents an API key; the secret is never returned after creation
+type APIKeyResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	KeyID      string    `json:"key_id"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  string    `json:"expires_at,omitempty"`
+	LastUsedAt string    `json:"last_used_at,omitempty"`
+	RevokedAt  string    `json:"revoked_at,omitempty"`
+	CreatedAt  string    `json:"created_at"`
+}
+
+// CreateAPIKeyResponse is returned once when an API key is created and carries the full key
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// TeamResponse represents a team of the authenticated user with the user's role in it
+type TeamResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedAt string    `json:"created_at"`
+}
+
+// TeamMemberResponse represents a member of a team
+type TeamMemberResponse struct {
+	UserID         uuid.UUID `json:"user_id"`
+	UserExternalID string    `json:"user_external_id"`
+	Role           string    `json:"role"`
+	CreatedAt      string    `json:"created_at"`
+}
+
+// QuotaResponse represents the admission policy of an owner (the caller personally, or one of its teams)
+// with current usage; limits are omitted when unlimited
+type QuotaResponse struct {
+	TeamID   *uuid.UUID `json:"team_id,omitempty"`
+	TeamName string     `json:"team_name,omitempty"`
+	// Namespaces are the allowed namespace patterns; empty allows every namespace not reserved
+	Namespaces         []string `json:"namespaces"`
+	ReservedNamespaces []string `json:"reserved_namespaces"`
+	// DeploymentsPerNamespace counts the owner's deployments by namespace, including pending creates
+	DeploymentsPerNamespace    map[string]int `json:"deployments_per_namespace"`
+	MaxDeploymentsPerNamespace int            `json:"max_deployments_per_namespace,omitempty"`
+	Replicas                   QuotaUsage     `json:"replicas"`
+	CPU                        QuotaUsage     `json:"cpu"`
+	Memory                     QuotaUsage     `json:"memory"`
+}
+
+// QuotaUsage represents the usage of a quota and its limit (omitted when unlimited)
+type QuotaUsage struct {
+	Used  string `json:"used"`
+	Limit string `json:"limit,omitempty"`
+}
+
+// WebhookResponse represents a webhook subscription; the secret is never returned
+type WebhookResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	URL        string     `json:"url"`
+	EventTypes []string   `json:"event_types"`
+	TeamID     *uuid.UUID `json:"team_id,omitempty"`
+	CreatedAt  string     `json:"created_at"`
+}
+
+// WebhookDeliveryResponse represents the delivery of an event to a webhook with its attempts, oldest first
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	EventID        string                    `json:"event_id"`
+	EventType      string                    `json:"event_type"`
+	Status         string                    `json:"status"`
+	Attempts       int                       `json:"attempts"`
+	NextAttemptAt  string                    `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int                      `json:"last_status_code,omitempty"`
+	LastError      *string                   `json:"last_error,omitempty"`
+	DeliveredAt    string                    `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage           `json:"payload" swaggertype:"object"`
+	AttemptLog     []*WebhookAttemptResponse `json:"attempt_log"`
+	CreatedAt      string                    `json:"created_at"`
+}
+
+// WebhookAttemptResponse represents one POST of a delivery
+type WebhookAttemptResponse struct {
+	Attempt        int     `json:"attempt"`
+	StatusCode     *int    `json:"status_code,omitempty"`
+	Error          *string `json:"error,omitempty"`
+	DurationMillis int64   `json:"duration_ms"`
+	AttemptedAt    string  `json:"attempted_at"`
+}
+
+// DeploymentScheduleResponse represents a scaling schedule of a deployment; times are RFC3339
+type DeploymentScheduleResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Identifier    string    `json:"identifier"`
+	Cron          string    `json:"cron"`
+	Timezone      string    `json:"timezone"`
+	ReplicaCount  int       `json:"replica_count"`
+	UserID        uuid.UUID `json:"user_id"`
+	NextRunAt     string    `json:"next_run_at"`
+	LastRunAt     string    `json:"last_run_at,omitempty"`
+	LastRequestID string    `json:"last_request_id,omitempty"`
+	CreatedAt     string    `json:"created_at"`
 }
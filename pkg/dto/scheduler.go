@@ -0,0 +1,16 @@
+package dto
+
+// ScheduleRunResult summarises one check of the scaling schedule runner
+type ScheduleRunResult struct {
+	// Due is the number of schedules whose next run had passed
+	Due int
+	// Fired schedules emitted an UPDATE request
+	Fired int
+	// Rejected schedules recorded their run as a FAILURE request instead (over the owner's quota, or the
+	// deployment is autoscaled)
+	Rejected int
+	// Removed schedules belonged to a deployment that was deleted
+	Removed int
+	// Errors counts schedules that could not be fired (they are retried next check)
+	Errors int
+}
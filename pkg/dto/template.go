@@ -0,0 +1,28 @@
+package dto
+
+// TemplateManifest describes a deployment template, loaded from templates/<name>/manifest.yaml
+// next to the template's deployment.yaml
+type TemplateManifest struct {
+	// Name is the template name; defaults to the template folder name
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// ImagePatterns are glob patterns (path.Match syntax) matched against the image without
+	// tag or digest, e.g. "nginx", "*/nginx", "registry.example.com/team/*"
+	ImagePatterns []string `json:"image_patterns"`
+	// ContainerPort is the port the main container listens on
+	ContainerPort int32 `json:"container_port"`
+	// ContentMountPath is where custom content (doc_html) is mounted; empty disables custom content
+	ContentMountPath string `json:"content_mount_path,omitempty"`
+	// MetadataKeys lists the request metadata keys the template accepts (e.g. replica_count, doc_html)
+	MetadataKeys []string `json:"metadata_keys"`
+}
+
+// AllowsMetadataKey reports whether key is one of the template's allowed metadata keys
+func (m *TemplateManifest) AllowsMetadataKey(key string) bool {
+	for _, k := range m.MetadataKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
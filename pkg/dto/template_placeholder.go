@@ -7,10 +7,27 @@ type CreateTemplateData struct {
 	Identifier          string
 	Image               string
 	UserID              string
+	TeamID              string // empty for personal deployments; sets the team-id label
 	RequestID           string
 	DeploymentRequestID string
-	// HasCustomHTML is true when metadata contains inline HTML (html/content/body); enables ConfigMap volume mount
+	// HasCustomHTML is true when metadata contains doc_html and the template has a content mount path;
+	// enables the ConfigMap volume mount
 	HasCustomHTML bool
 	// ManagedBy is the value for the managed-by label (from config manager-tag)
 	ManagedBy string
+	// ContainerPort is the container port from the template manifest
+	ContainerPort int32
+	// ContentMountPath is where custom content is mounted, from the template manifest
+	ContentMountPath string
+}
+
+// NamespaceTemplateData holds the values for namespace template substitution
+type NamespaceTemplateData struct {
+	Namespace string
+	// ManagedBy is the value for the managed-by label (from config manager-tag)
+	ManagedBy string
+	// UserID and TeamID identify the owner of the deployment the namespace was created for;
+	// TeamID is empty for personal deployments
+	UserID string
+	TeamID string
 }
@@ -0,0 +1,15 @@
+package dto
+
+// DispatchResult summarizes one run of the webhook dispatcher
+type DispatchResult struct {
+	// Sent is the number of due deliveries POSTed
+	Sent int
+	// Delivered deliveries got a 2xx response and were marked SUCCESS
+	Delivered int
+	// Retrying deliveries failed and were scheduled for another attempt
+	Retrying int
+	// Failed deliveries used up their attempts and were marked FAILURE
+	Failed int
+	// Errors counts deliveries whose attempt could not be recorded (they are sent again once their lease expires)
+	Errors int
+}
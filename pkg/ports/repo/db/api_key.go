@@ -0,0 +1,23 @@
+package db
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// APIKey defines the interface for API key data access
+type APIKey interface {
+	Create(ctx context.Context, key *models.APIKey) error
+	// GetByKeyID returns the key with the given public key ID, boolean indicating if found, and error
+	GetByKeyID(ctx context.Context, keyID string) (*models.APIKey, bool, error)
+	// GetByID returns the key with the given ID, boolean indicating if found, and error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.APIKey, bool, error)
+	// ListByUserID returns the keys of a user, newest first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.APIKey, error)
+	// Revoke sets the key's revoked timestamp; revoking a revoked key keeps the first timestamp
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// TouchLastUsed sets the key's last used timestamp
+	TouchLastUsed(ctx context.Context, id uuid.UUID) error
+}
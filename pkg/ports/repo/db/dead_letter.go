@@ -0,0 +1,18 @@
+package db
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// DeadLetter defines the interface for dead-lettered message data access
+type DeadLetter interface {
+	// Create stores a dead-lettered message; false when the same dead-letter message was already stored
+	Create(ctx context.Context, deadLetter *models.DeadLetter) (bool, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DeadLetter, bool, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DeadLetter, error)
+	List(ctx context.Context) ([]*models.DeadLetter, error)
+	MarkReplayed(ctx context.Context, id uuid.UUID) error
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	"github.com/google/uuid"
 )
@@ -11,7 +12,11 @@ import (
 type Deployment interface {
 	GetByNameAndNamespace(ctx context.Context, name, namespace string) (*models.Deployment, bool, error)
 	GetByIdentifier(ctx context.Context, identifier string) (*models.Deployment, bool, error)
-	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Deployment, error)
+	// ListVisible returns up to filter.Limit of the user's personal deployments (no team) and those owned by the
+	// given teams that match the filter, in its sort order and after its cursor
+	ListVisible(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID, filter *dto.DeploymentFilter) ([]*models.Deployment, error)
+	// ListActiveByOwner returns the deployments that are not DELETED of a team, or the user's personal ones when teamID is nil
+	ListActiveByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.Deployment, error)
 	Upsert(ctx context.Context, deployment *models.Deployment) error
 	Update(ctx context.Context, deployment *models.Deployment) error
 }
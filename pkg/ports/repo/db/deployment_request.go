@@ -2,7 +2,9 @@ package db
 
 import (
 	"context"
+	"time"
 
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	"github.com/google/uuid"
 )
@@ -10,8 +12,30 @@ import (
 // DeploymentRequest defines the interface for deployment request data access
 type DeploymentRequest interface {
 	Create(ctx context.Context, deployment *models.DeploymentRequest) error
+	CreateWithOutbox(ctx context.Context, deployment *models.DeploymentRequest, msg *models.OutboxMessage) error
+	// CreateWithOutboxAdmitted is CreateWithOutbox serialized per owner: the transaction locks the owner's
+	// team or user row and runs admit before storing, so admit sees every request stored by the ones before
+	CreateWithOutboxAdmitted(ctx context.Context, owner dto.Ownership, deployment *models.DeploymentRequest, msg *models.OutboxMessage, admit func(ctx context.Context) error) error
 	GetByIdentifier(ctx context.Context, identifier string) (*models.DeploymentRequest, error)
 	GetByRequestID(ctx context.Context, requestID string) (*models.DeploymentRequest, bool, error)
-	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DeploymentRequest, error)
+	// ListVisible returns up to filter.Limit of the user's personal deployment requests (no team) and those owned
+	// by the given teams that match the filter, in its sort order and after its cursor
+	ListVisible(ctx context.Context, userID uuid.UUID, teamIDs []uuid.UUID, filter *dto.DeploymentRequestFilter) ([]*models.DeploymentRequest, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.DeploymentRequestStatus, failureReason *string) error
+	// CompleteStatus moves a request that is still CREATED to status and stores msg, if any, in the same
+	// transaction; false (and no message) when it was no longer CREATED
+	CompleteStatus(ctx context.Context, id uuid.UUID, status models.DeploymentRequestStatus, failureReason *string, msg *models.OutboxMessage) (bool, error)
+	// UpdatePhase stores the sub-state of a request that is still CREATED
+	UpdatePhase(ctx context.Context, id uuid.UUID, phase models.DeploymentRequestPhase) error
+	ListStale(ctx context.Context, olderThan time.Time, limit int) ([]*models.DeploymentRequest, error)
+	Requeue(ctx context.Context, req *models.DeploymentRequest, msg *models.OutboxMessage) (bool, error)
+	// ListSuccessfulByIdentifier returns the SUCCESS requests of a deployment, oldest first
+	ListSuccessfulByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentRequest, error)
+	// ListPendingResizesByOwner returns the CREATE, UPDATE and ROLLBACK requests still CREATED of a team, or the
+	// user's personal ones when teamID is nil
+	ListPendingResizesByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.DeploymentRequest, error)
+	// CountPendingCreatesInNamespace counts the CREATE requests still CREATED that target a namespace
+	CountPendingCreatesInNamespace(ctx context.Context, namespace string) (int64, error)
+	// SaveSnapshot stores the pre-update snapshot of an UPDATE or ROLLBACK request
+	SaveSnapshot(ctx context.Context, id uuid.UUID, snapshot models.JSONB) error
 }
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+)
+
+// DeploymentRevision defines the interface for deployment revision history data access
+type DeploymentRevision interface {
+	// Create stores the revision; it returns false if the revision of that deployment is already recorded
+	Create(ctx context.Context, revision *models.DeploymentRevision) (bool, error)
+	// ListByIdentifier returns the revisions of a deployment, newest first
+	ListByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentRevision, error)
+	GetByRevision(ctx context.Context, identifier string, revision int64) (*models.DeploymentRevision, bool, error)
+}
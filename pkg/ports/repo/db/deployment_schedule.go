@@ -0,0 +1,28 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// DeploymentSchedule defines the interface for scaling schedule data access
+type DeploymentSchedule interface {
+	Create(ctx context.Context, schedule *models.DeploymentSchedule) error
+	// GetByID returns the schedule with the given ID, boolean indicating if found, and error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.DeploymentSchedule, bool, error)
+	// ListByIdentifier returns the schedules of a deployment, oldest first
+	ListByIdentifier(ctx context.Context, identifier string) ([]*models.DeploymentSchedule, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteByIdentifier removes every schedule of a deployment
+	DeleteByIdentifier(ctx context.Context, identifier string) error
+	// ListDue returns up to limit schedules whose next run is at or before now, earliest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*models.DeploymentSchedule, error)
+	// Fire moves a due schedule to nextRunAt and stores the request it emits with its outbox message in one
+	// transaction. It only moves the schedule from the next run the caller read, so when several schedulers
+	// fire the same run only one stores the request; false is returned for the others. msg is the
+	// request's queue message, or the webhook event of a rejected run stored as FAILURE (nil without one).
+	Fire(ctx context.Context, schedule *models.DeploymentSchedule, nextRunAt time.Time, req *models.DeploymentRequest, msg *models.OutboxMessage) (bool, error)
+}
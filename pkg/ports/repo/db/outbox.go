@@ -0,0 +1,14 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+)
+
+// Outbox defines the interface for relaying outbox messages
+type Outbox interface {
+	PublishPending(ctx context.Context, limit int, publish func(msg *models.OutboxMessage) error) (int, error)
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
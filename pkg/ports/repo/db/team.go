@@ -0,0 +1,28 @@
+package db
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// Team defines the interface for team and team membership data access
+type Team interface {
+	// CreateWithAdmin creates the team and makes the user its admin in one transaction
+	CreateWithAdmin(ctx context.Context, team *models.Team, userID uuid.UUID) error
+	// GetByID returns the team with the given ID, boolean indicating if found, and error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Team, bool, error)
+	// GetByName returns the team with the given name, boolean indicating if found, and error
+	GetByName(ctx context.Context, name string) (*models.Team, bool, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Team, error)
+	// GetMembership returns the user's membership in the team, boolean indicating if found, and error
+	GetMembership(ctx context.Context, teamID, userID uuid.UUID) (*models.TeamMembership, bool, error)
+	ListMembershipsByUserID(ctx context.Context, userID uuid.UUID) ([]*models.TeamMembership, error)
+	ListMembers(ctx context.Context, teamID uuid.UUID) ([]*models.TeamMembership, error)
+	// UpsertMember adds the user to the team or changes its role
+	UpsertMember(ctx context.Context, membership *models.TeamMembership) error
+	// RemoveMember removes the user from the team; it returns false if the user was not a member
+	RemoveMember(ctx context.Context, teamID, userID uuid.UUID) (bool, error)
+	CountAdmins(ctx context.Context, teamID uuid.UUID) (int64, error)
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
 )
 
 // User defines the interface for user data access
 type User interface {
 	GetByExternalID(ctx context.Context, externalID string) (*models.User, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*models.User, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.User, error)
 	Create(ctx context.Context, user *models.User) error
+	// GetOrCreate returns the user with the external ID, creating it if it does not exist
+	GetOrCreate(ctx context.Context, externalID string) (*models.User, error)
 }
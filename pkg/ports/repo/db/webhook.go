@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+	"github.com/google/uuid"
+)
+
+// Webhook defines the interface for webhook subscription and delivery data access
+type Webhook interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	// GetByID returns the subscription with the given ID, boolean indicating if found, and error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, bool, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*models.WebhookSubscription, error)
+	ListByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.WebhookSubscription, error)
+	// ListByOwner returns the subscriptions of a team, or the user's personal ones when teamID is nil
+	ListByOwner(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) ([]*models.WebhookSubscription, error)
+	// Delete removes the subscription with its deliveries and their attempts
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// CreateDeliveries stores the deliveries; those already stored for the same subscription and event are skipped
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	// ClaimDueDeliveries returns up to limit PENDING deliveries whose next attempt is due, oldest first, and
+	// moves their next attempt lease ahead so other dispatchers skip them while they are sent
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*models.WebhookDelivery, error)
+	// RecordAttempt stores the attempt and the delivery's resulting status, attempts and next attempt
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookDeliveryAttempt) error
+	// GetDelivery returns the delivery with the given ID, boolean indicating if found, and error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*models.WebhookDelivery, bool, error)
+	// ListDeliveries returns up to limit deliveries of the subscription, newest first
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	// ListAttempts returns the attempts of the deliveries, oldest first
+	ListAttempts(ctx context.Context, deliveryIDs []uuid.UUID) ([]*models.WebhookDeliveryAttempt, error)
+	// Redeliver makes the delivery PENDING and due now with its attempt count reset
+	Redeliver(ctx context.Context, id uuid.UUID) error
+}
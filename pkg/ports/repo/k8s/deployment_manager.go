@@ -2,9 +2,11 @@ package k8s
 
 import (
 	"context"
+	"time"
 
 	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 )
 
 // DeploymentManager defines the interface for Kubernetes deployment operations
@@ -15,4 +17,28 @@ type DeploymentManager interface {
 	GetOptional(ctx context.Context, namespace, name string) (*appsv1.Deployment, bool, error)
 	Update(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (*appsv1.Deployment, error)
 	Delete(ctx context.Context, namespace, name string) error
+	// CollectNamespace deletes a namespace the manager created once it holds no deployment, when namespace GC
+	// is enabled. Failures are only logged.
+	CollectNamespace(ctx context.Context, namespace string)
+	// WaitForRollout blocks until the rollout of deployment completes; it returns an error wrapping
+	// dto.ErrRolloutFailed when the rollout is stuck or does not complete within timeout.
+	WaitForRollout(ctx context.Context, deployment *appsv1.Deployment, timeout time.Duration) error
+	// Snapshot records the fields an UPDATE or ROLLBACK request changes as they are on the existing deployment.
+	Snapshot(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (models.JSONB, error)
+	// Restore applies a snapshot taken by Snapshot.
+	Restore(ctx context.Context, req *models.DeploymentRequest, snapshot models.JSONB) error
+	// ApplyCanary applies the <identifier>-canary deployment running the pod template of a canary UPDATE
+	// with replicas pods.
+	ApplyCanary(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment, replicas int) (*appsv1.Deployment, error)
+	// CheckCanary returns an error wrapping dto.ErrCanaryUnhealthy when the canary has unavailable, failing or
+	// restarted pods.
+	CheckCanary(ctx context.Context, canary *appsv1.Deployment) error
+	// Restart restarts the deployment's pods with a rolling update by setting the restartedAt pod template annotation.
+	Restart(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment) (*appsv1.Deployment, error)
+	// SetPaused pauses or resumes the rollouts of the deployment by setting spec.paused.
+	SetPaused(ctx context.Context, req *models.DeploymentRequest, existingDeployment *appsv1.Deployment, paused bool) (*appsv1.Deployment, error)
+	// DeleteCanary deletes the canary deployment of a deployment; a missing canary is not an error.
+	DeleteCanary(ctx context.Context, namespace, identifier string) error
+	// GetAutoscaler returns the HorizontalPodAutoscaler of a deployment; second return is false if it is not autoscaled.
+	GetAutoscaler(ctx context.Context, namespace, identifier string) (*autoscalingv2.HorizontalPodAutoscaler, bool, error)
 }
@@ -0,0 +1,6 @@
+package queue
+
+// DeadLetter replays dead-lettered messages back onto their original NATS subject
+type DeadLetter interface {
+	Replay(subject string, payload []byte, headers map[string][]string) error
+}
@@ -1,6 +1,9 @@
 package queue
 
-// DeploymentRequest publishes deployment request messages to NATS
+import "github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+
+// DeploymentRequest builds deployment request messages for the outbox; the outbox relay
+// publishes them to NATS
 type DeploymentRequest interface {
-	Publish(requestID, userID string) error
+	Message(requestID, userID string) (*models.OutboxMessage, error)
 }
@@ -0,0 +1,14 @@
+package queue
+
+import "github.com/code-xd/k8s-deployment-manager/pkg/dto"
+
+// DeploymentStatus publishes deployment request status events to NATS
+type DeploymentStatus interface {
+	Publish(event *dto.DeploymentStatusEvent) error
+}
+
+// DeploymentStatusSubscriber subscribes to the status events of a deployment request; the returned func
+// ends the subscription
+type DeploymentStatusSubscriber interface {
+	Subscribe(requestID string, handler func(*dto.DeploymentStatusEvent)) (func(), error)
+}
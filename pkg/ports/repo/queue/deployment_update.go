@@ -2,5 +2,5 @@ package queue
 
 // DeploymentUpdate publishes deployment update messages to NATS
 type DeploymentUpdate interface {
-	Publish(identifier, eventType string) error
+	Publish(identifier, resourceVersion, eventType string) error
 }
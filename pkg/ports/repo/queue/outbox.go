@@ -0,0 +1,6 @@
+package queue
+
+// Outbox publishes relayed outbox messages to NATS, deduplicated by msgID
+type Outbox interface {
+	Publish(msgID, subject string, payload []byte, headers map[string][]string) error
+}
@@ -0,0 +1,14 @@
+package queue
+
+import (
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+)
+
+// WebhookEvent publishes the events delivered to webhooks to NATS, deduplicated by event ID
+type WebhookEvent interface {
+	Publish(event *dto.WebhookEvent) error
+	// Message builds the outbox message of an event, stored with the row change it announces; nil when the
+	// event channel is not configured
+	Message(event *dto.WebhookEvent) (*models.OutboxMessage, error)
+}
@@ -0,0 +1,13 @@
+package template
+
+import "github.com/code-xd/k8s-deployment-manager/pkg/dto"
+
+// Registry defines the interface for looking up deployment templates
+type Registry interface {
+	// List returns all templates ordered by name
+	List() []*dto.TemplateManifest
+	// Get returns the template with the given name; second return is false if it does not exist
+	Get(name string) (*dto.TemplateManifest, bool)
+	// Match returns the template whose image patterns match image; second return is false if none matches
+	Match(image string) (*dto.TemplateManifest, bool)
+}
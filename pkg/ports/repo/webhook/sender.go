@@ -0,0 +1,10 @@
+package webhook
+
+import "context"
+
+// Sender POSTs signed event payloads to webhook URLs
+type Sender interface {
+	// Send POSTs payload to url, signed with secret. It returns the response status code (0 when no
+	// response was received) and an error when the request failed or the status is not 2xx.
+	Send(ctx context.Context, url, secret, eventType, deliveryID string, payload []byte) (int, error)
+}
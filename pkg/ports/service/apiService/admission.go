@@ -0,0 +1,19 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+)
+
+// Admission checks deployment requests against the namespace allowlists and quotas of their owner
+// before they are queued (API stack). Violations are returned as *dto.AdmissionViolation.
+type Admission interface {
+	// AdmitCreate checks a new deployment of owner in namespace
+	AdmitCreate(ctx context.Context, owner dto.Ownership, namespace string, metadata *dto.DeploymentMetadata) error
+	// AdmitUpdate checks an UPDATE or ROLLBACK that may resize the deployment
+	AdmitUpdate(ctx context.Context, deployment *models.Deployment, metadata *dto.UpdateDeploymentRequestMetadata) error
+	// GetQuotas returns the policy and usage of the user's personal deployments and of each of its teams
+	GetQuotas(ctx context.Context, userID string) ([]*dto.QuotaResponse, error)
+}
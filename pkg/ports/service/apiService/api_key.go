@@ -0,0 +1,15 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// APIKey defines the interface for managing the authenticated user's API keys (API stack)
+type APIKey interface {
+	// CreateAPIKey creates a key for the principal's user; it may only grant scopes the principal has
+	CreateAPIKey(ctx context.Context, principal *dto.Principal, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID string) ([]*dto.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id string, userID string) (*dto.APIKeyResponse, error)
+}
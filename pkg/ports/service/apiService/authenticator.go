@@ -0,0 +1,15 @@
+package apiService
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// Authenticator resolves the principal (user and granted scopes) making an API request from its credentials (API stack).
+// It returns dto.ErrNoCredentials when the request carries none of its credentials, so the next
+// authenticator can be tried, and dto.ErrInvalidCredentials when they are present but not valid.
+type Authenticator interface {
+	Authenticate(ctx context.Context, r *http.Request) (*dto.Principal, error)
+}
@@ -0,0 +1,15 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// DeadLetter defines the interface for listing and replaying dead-lettered messages (API stack)
+type DeadLetter interface {
+	ListDeadLetters(ctx context.Context, userID string) ([]*dto.DeadLetterResponse, error)
+	ReplayDeadLetter(ctx context.Context, id string, userID string) (*dto.DeadLetterResponse, error)
+	ListAllDeadLetters(ctx context.Context) ([]*dto.DeadLetterResponse, error)
+	ReplayAnyDeadLetter(ctx context.Context, id string, userID string) (*dto.DeadLetterResponse, error)
+}
@@ -8,6 +8,10 @@ import (
 
 // Deployment defines the interface for listing and getting deployments (API stack)
 type Deployment interface {
-	ListDeployments(ctx context.Context, userID string) ([]*dto.DeploymentListResponse, error)
+	// ListDeployments returns a page of the deployments the user can view and the cursor of the next page
+	// (empty on the last page)
+	ListDeployments(ctx context.Context, userID string, query *dto.ListDeploymentsQuery) ([]*dto.DeploymentListResponse, string, error)
 	GetDeployment(ctx context.Context, identifier string, userID string) (*dto.DeploymentResponse, error)
+	ListDeploymentRevisions(ctx context.Context, identifier string, userID string) ([]*dto.DeploymentRevisionResponse, error)
+	DiffDeploymentRevisions(ctx context.Context, identifier string, from, to int64, userID string) (*dto.DeploymentRevisionDiffResponse, error)
 }
@@ -9,8 +9,16 @@ import (
 // DeploymentRequest defines the interface for creating, listing and getting deployment requests (API stack)
 type DeploymentRequest interface {
 	CreateDeploymentRequest(ctx context.Context, req *dto.CreateDeploymentRequestWithMetadata, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
-	ListDeploymentRequests(ctx context.Context, userID string) ([]*dto.DeploymentRequestListResponse, error)
+	// ListDeploymentRequests returns a page of the requests the user can view and the cursor of the next page
+	// (empty on the last page)
+	ListDeploymentRequests(ctx context.Context, userID string, query *dto.ListDeploymentRequestsQuery) ([]*dto.DeploymentRequestListResponse, string, error)
 	GetDeploymentRequest(ctx context.Context, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
+	WatchDeploymentRequest(ctx context.Context, requestID string, userID string) (<-chan *dto.DeploymentStatusEvent, error)
 	UpdateDeploymentRequest(ctx context.Context, identifier string, req *dto.UpdateDeploymentRequestMetadata, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
 	DeleteDeploymentRequest(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
+	RollbackDeployment(ctx context.Context, identifier string, req *dto.RollbackDeploymentRequest, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
+	// RestartDeployment, PauseDeployment and ResumeDeployment queue a RESTART, PAUSE or RESUME request
+	RestartDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
+	PauseDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
+	ResumeDeployment(ctx context.Context, identifier string, requestID string, userID string) (*dto.DeploymentRequestResponse, error)
 }
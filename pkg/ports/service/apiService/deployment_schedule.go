@@ -0,0 +1,17 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// DeploymentSchedule defines the interface for managing the scaling schedules of deployments (API stack)
+type DeploymentSchedule interface {
+	// CreateDeploymentSchedule adds a schedule to a deployment the user may deploy to
+	CreateDeploymentSchedule(ctx context.Context, identifier string, userID string, req *dto.CreateDeploymentScheduleRequest) (*dto.DeploymentScheduleResponse, error)
+	// ListDeploymentSchedules returns the schedules of a deployment visible to the user
+	ListDeploymentSchedules(ctx context.Context, identifier string, userID string) ([]*dto.DeploymentScheduleResponse, error)
+	// DeleteDeploymentSchedule removes a schedule from a deployment the user may deploy to
+	DeleteDeploymentSchedule(ctx context.Context, identifier string, scheduleID string, userID string) error
+}
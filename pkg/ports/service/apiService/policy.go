@@ -0,0 +1,18 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/google/uuid"
+)
+
+// Policy decides what a user may do with team-owned and personal resources (API stack)
+type Policy interface {
+	// Authorize returns nil if the user may perform action on a resource with the given ownership,
+	// dto.ErrResourceNotVisible if the user cannot see the resource, and dto.ErrInsufficientTeamRole
+	// if it can see it but its team role does not allow the action
+	Authorize(ctx context.Context, userID uuid.UUID, owner dto.Ownership, action dto.PolicyAction) error
+	// VisibleTeamIDs returns the teams whose resources the user can see
+	VisibleTeamIDs(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
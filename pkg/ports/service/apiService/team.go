@@ -0,0 +1,16 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// Team defines the interface for managing teams and their members (API stack)
+type Team interface {
+	CreateTeam(ctx context.Context, req *dto.CreateTeamRequest, userID string) (*dto.TeamResponse, error)
+	ListTeams(ctx context.Context, userID string) ([]*dto.TeamResponse, error)
+	ListTeamMembers(ctx context.Context, teamID string, userID string) ([]*dto.TeamMemberResponse, error)
+	SetTeamMember(ctx context.Context, teamID string, req *dto.SetTeamMemberRequest, userID string) (*dto.TeamMemberResponse, error)
+	RemoveTeamMember(ctx context.Context, teamID string, memberExternalID string, userID string) error
+}
@@ -0,0 +1,12 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// Template defines the interface for listing the available deployment templates (API stack)
+type Template interface {
+	ListTemplates(ctx context.Context) ([]*dto.TemplateManifest, error)
+}
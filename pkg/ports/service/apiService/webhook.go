@@ -0,0 +1,19 @@
+package apiService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// Webhook defines the interface for managing the authenticated user's webhooks and their deliveries (API stack)
+type Webhook interface {
+	// CreateWebhook subscribes a URL; a team webhook requires the user to see the team
+	CreateWebhook(ctx context.Context, userID string, req *dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context, userID string) ([]*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, id string, userID string) error
+	// ListWebhookDeliveries returns the latest deliveries of the webhook with their attempts, newest first
+	ListWebhookDeliveries(ctx context.Context, id string, userID string) ([]*dto.WebhookDeliveryResponse, error)
+	// RedeliverWebhook makes a delivery of the webhook PENDING and due now, with its attempt count reset
+	RedeliverWebhook(ctx context.Context, id string, deliveryID string, userID string) (*dto.WebhookDeliveryResponse, error)
+}
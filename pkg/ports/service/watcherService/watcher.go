@@ -4,5 +4,5 @@ import "context"
 
 // WatcherService publishes deployment update events (from the informer) to NATS
 type WatcherService interface {
-	PublishDeploymentUpdate(ctx context.Context, namespace, name, eventType string) error
+	PublishDeploymentUpdate(ctx context.Context, namespace, name, resourceVersion, eventType string) error
 }
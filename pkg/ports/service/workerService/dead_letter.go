@@ -0,0 +1,12 @@
+package workerService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// DeadLetter defines the interface for recording dead-lettered messages (worker stack)
+type DeadLetter interface {
+	ProcessDeadLetter(ctx context.Context, msg *dto.DeadLetterMessage) error
+}
@@ -0,0 +1,7 @@
+package workerService
+
+// OutboxRelay defines the interface for the background relay that publishes outbox messages (run by both the API and the worker)
+type OutboxRelay interface {
+	Start()
+	Stop()
+}
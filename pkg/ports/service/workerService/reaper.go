@@ -0,0 +1,14 @@
+package workerService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// Reaper defines the interface for repairing deployment requests stuck in CREATED (worker stack)
+type Reaper interface {
+	Start()
+	Stop()
+	Reap(ctx context.Context) (*dto.ReapResult, error)
+}
@@ -0,0 +1,21 @@
+package workerService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
+)
+
+// Scheduler defines the interface for firing due scaling schedules (worker stack)
+type Scheduler interface {
+	Start()
+	Stop()
+	RunDue(ctx context.Context) (*dto.ScheduleRunResult, error)
+}
+
+// ScheduleAdmission checks a scheduled resize against the owner's quotas, like the API does for the UPDATE
+// requests it queues. Violations are returned as *dto.AdmissionViolation.
+type ScheduleAdmission interface {
+	AdmitUpdate(ctx context.Context, deployment *models.Deployment, metadata *dto.UpdateDeploymentRequestMetadata) error
+}
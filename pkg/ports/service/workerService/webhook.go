@@ -0,0 +1,19 @@
+package workerService
+
+import (
+	"context"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// WebhookEvent defines the interface for turning webhook events into deliveries (worker stack)
+type WebhookEvent interface {
+	ProcessWebhookEvent(ctx context.Context, event *dto.WebhookEvent) error
+}
+
+// WebhookDispatcher defines the interface for sending due webhook deliveries (worker stack)
+type WebhookDispatcher interface {
+	Start()
+	Stop()
+	Dispatch(ctx context.Context) (*dto.DispatchResult, error)
+}
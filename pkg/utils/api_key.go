@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// GenerateAPIKey returns a new API key "<prefix>_<key id>_<secret>" with its key ID and the hash of its secret.
+// The key is only shown once; the key ID and hash are what gets stored.
+func GenerateAPIKey() (key, keyID, secretHash string, err error) {
+	keyID, err = randomHex(dto.APIKeyIDLength)
+	if err != nil {
+		return "", "", "", err
+	}
+	secret, err := randomHex(dto.APIKeySecretLength)
+	if err != nil {
+		return "", "", "", err
+	}
+	return strings.Join([]string{dto.APIKeyPrefix, keyID, secret}, "_"), keyID, HashAPIKeySecret(secret), nil
+}
+
+// ParseAPIKey splits an API key into its key ID and secret; ok is false if the key is malformed.
+func ParseAPIKey(key string) (keyID, secret string, ok bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != dto.APIKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
+// HashAPIKeySecret returns the hex SHA-256 hash stored for an API key secret.
+func HashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseScopes splits a space-delimited scope string
+func ParseScopes(scopes string) []string {
+	return strings.Fields(scopes)
+}
+
+// JoinScopes builds the space-delimited scope string stored for an API key
+func JoinScopes(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// randomHex returns n random hex characters.
+func randomHex(n int) (string, error) {
+	b := make([]byte, (n+1)/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(b)[:n], nil
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// cronSearchYears bounds the search for the next run of a schedule that may never fire (e.g. February 30)
+const cronSearchYears = 5
+
+// CronSchedule is a parsed five-field cron expression: minute, hour, day of month, month and day of week
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny record a day field starting with "*" (e.g. "*" or "*/2"): when both day fields are
+	// restricted, either may match, as in standard cron
+	domAny, dowAny bool
+}
+
+// cronField describes the range and names of one cron field
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDOM    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12,
+		names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronDOW = cronField{name: "day of week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// ParseCron parses a five-field cron expression. Each field takes "*", a value, a range "a-b" and a step
+// "/n" after "*" or a range, or a comma-separated list of those; months and days of week also take
+// three-letter names, and day of week 7 is Sunday. Errors wrap dto.ErrInvalidSchedule.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q must have 5 fields (minute hour day-of-month month day-of-week)", dto.ErrInvalidSchedule, expr)
+	}
+
+	var schedule CronSchedule
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = parseCronField(fields[2], cronDOM); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = parseCronField(fields[4], cronDOW); err != nil {
+		return nil, err
+	}
+	// Sunday is both 0 and 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domAny = strings.HasPrefix(fields[2], "*")
+	schedule.dowAny = strings.HasPrefix(fields[4], "*")
+	return &schedule, nil
+}
+
+// parseCronField parses one field into a bit set of the values it matches
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: %s step in %q", dto.ErrInvalidSchedule, field.name, part)
+			}
+			step = n
+		}
+
+		low, high := field.min, field.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = cronValue(bounds[0], field); err != nil {
+				return 0, err
+			}
+			if high, err = cronValue(bounds[1], field); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%w: %s range %q is reversed", dto.ErrInvalidSchedule, field.name, rangePart)
+			}
+		default:
+			if step != 1 {
+				return 0, fmt.Errorf("%w: %s step %q needs \"*\" or a range", dto.ErrInvalidSchedule, field.name, part)
+			}
+			n, err := cronValue(rangePart, field)
+			if err != nil {
+				return 0, err
+			}
+			low, high = n, n
+		}
+
+		for n := low; n <= high; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+// cronValue parses a number or name of a field and checks its range
+func cronValue(value string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if name != "" && strings.EqualFold(value, name) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("%w: %s %q is not in %d-%d", dto.ErrInvalidSchedule, field.name, value, field.min, field.max)
+	}
+	return n, nil
+}
+
+// Next returns the first time after t, at a whole minute in t's location, matched by the schedule. It
+// returns the zero time when the schedule does not fire within the next years. Local times skipped by a
+// daylight saving change do not match, and times repeated by one match twice.
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	// Truncate in absolute time: rebuilding a repeated wall clock time with time.Date may pick its other offset
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			// Move on in absolute time, so an hour the wall clock repeats is visited twice
+			t = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the day of month and day of week fields: when both are restricted, either may match
+func (c *CronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if !c.domAny && !c.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// NextCronRun returns the first time after t matched by the cron expression in the named IANA timezone,
+// in UTC. Errors, including an expression that never matches, wrap dto.ErrInvalidSchedule.
+func NextCronRun(expr, timezone string, t time.Time) (time.Time, error) {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: timezone %q: %v", dto.ErrInvalidSchedule, timezone, err)
+	}
+	next := schedule.Next(t.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %q never matches", dto.ErrInvalidSchedule, expr)
+	}
+	return next.UTC(), nil
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+func TestParseCronInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "too few fields", expr: "0 9 * *"},
+		{name: "too many fields", expr: "0 0 9 * * *"},
+		{name: "minute out of range", expr: "60 * * * *"},
+		{name: "day of month out of range", expr: "* * 0 * *"},
+		{name: "day of week out of range", expr: "* * * * 8"},
+		{name: "reversed range", expr: "5-1 * * * *"},
+		{name: "step without a range", expr: "5/2 * * * *"},
+		{name: "zero step", expr: "*/0 * * * *"},
+		{name: "unknown month name", expr: "* * * foo *"},
+		{name: "month name in the day of week field", expr: "* * * * jan"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); !errors.Is(err, dto.ErrInvalidSchedule) {
+				t.Errorf("ParseCron(%q) error = %v, want %v", tt.expr, err, dto.ErrInvalidSchedule)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// A Saturday
+	from := time.Date(2026, 10, 17, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{name: "every minute", expr: "* * * * *", want: time.Date(2026, 10, 17, 10, 8, 0, 0, time.UTC)},
+		{name: "minute step", expr: "*/15 * * * *", want: time.Date(2026, 10, 17, 10, 15, 0, 0, time.UTC)},
+		{name: "hour range with step", expr: "0 9-17/4 * * *", want: time.Date(2026, 10, 17, 13, 0, 0, 0, time.UTC)},
+		{name: "list", expr: "0,30 8,20 * * *", want: time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)},
+		{name: "weekday names", expr: "0 19 * * mon-fri", want: time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)},
+		{name: "7 is Sunday", expr: "0 0 * * 7", want: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "month and weekday names", expr: "0 9 * JAN mon", want: time.Date(2027, 1, 4, 9, 0, 0, 0, time.UTC)},
+		{name: "restricted day of month", expr: "0 0 1 * *", want: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "leap day", expr: "0 0 29 2 *", want: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match
+		{name: "day of month or day of week", expr: "0 0 1 * tue", want: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		// A day field starting with "*" is unrestricted, so both must match: the first odd Tuesday
+		{name: "stepped day of month and day of week", expr: "0 0 */2 * tue", want: time.Date(2026, 10, 27, 0, 0, 0, 0, time.UTC)},
+		{name: "day of month and stepped day of week", expr: "0 0 20 * */2", want: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", tt.expr, err)
+			}
+			if got := schedule.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextCronRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		timezone string
+		from     time.Time
+		want     time.Time
+	}{
+		{
+			name:     "converted to UTC",
+			expr:     "0 19 * * mon-fri",
+			timezone: "Europe/Berlin",
+			from:     time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 19, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			// 02:30 does not exist in Berlin on 2026-03-29
+			name:     "skipped by the spring daylight saving change",
+			expr:     "30 2 * * *",
+			timezone: "Europe/Berlin",
+			from:     time.Date(2026, 3, 28, 2, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 3, 30, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "first of the times repeated by the autumn daylight saving change",
+			expr:     "30 2 * * *",
+			timezone: "Europe/Berlin",
+			from:     time.Date(2026, 10, 24, 23, 0, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			// 02:30 happens twice in Berlin on 2026-10-25, first in CEST then in CET
+			name:     "second of the times repeated by the autumn daylight saving change",
+			expr:     "30 2 * * *",
+			timezone: "Europe/Berlin",
+			from:     time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+			want:     time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "hour after the repeated hour",
+			expr:     "0 3 * * *",
+			timezone: "America/New_York",
+			from:     time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC),
+			want:     time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextCronRun(tt.expr, tt.timezone, tt.from)
+			if err != nil {
+				t.Fatalf("NextCronRun() error = %v", err)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("NextCronRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextCronRunInvalid(t *testing.T) {
+	from := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		timezone string
+	}{
+		{name: "invalid expression", expr: "0 0 * *", timezone: "UTC"},
+		{name: "unknown timezone", expr: "0 0 * * *", timezone: "Mars/Olympus_Mons"},
+		{name: "never matches", expr: "0 0 30 2 *", timezone: "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NextCronRun(tt.expr, tt.timezone, from); !errors.Is(err, dto.ErrInvalidSchedule) {
+				t.Errorf("NextCronRun() error = %v, want %v", err, dto.ErrInvalidSchedule)
+			}
+		})
+	}
+}
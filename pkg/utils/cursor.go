@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// EncodeCursor returns the opaque, URL-safe form of a page cursor
+func EncodeCursor(cursor *dto.PageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by EncodeCursor and checks it was issued for sort;
+// any other value is dto.ErrInvalidCursor
+func DecodeCursor(value string, sort dto.ListSort) (*dto.PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidCursor, err)
+	}
+	var cursor dto.PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInvalidCursor, err)
+	}
+	if cursor.Sort != sort.String() {
+		return nil, fmt.Errorf("%w: issued for sort %q, not %q", dto.ErrInvalidCursor, cursor.Sort, sort.String())
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+	"github.com/google/uuid"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		sort   dto.ListSort
+		cursor dto.PageCursor
+	}{
+		{
+			name:   "created_at descending",
+			sort:   dto.ParseListSort("-created_at"),
+			cursor: dto.PageCursor{Value: "2026-10-17T08:30:00.123456Z", ID: uuid.MustParse("3f8c1a2e-5b7d-4c9e-8f10-2a3b4c5d6e7f")},
+		},
+		{
+			name:   "name ascending with characters that need escaping",
+			sort:   dto.ParseListSort("name"),
+			cursor: dto.PageCursor{Value: `web "frontend"/ü+?&`, ID: uuid.MustParse("00000000-0000-0000-0000-000000000001")},
+		},
+		{
+			name:   "empty value",
+			sort:   dto.ParseListSort(""),
+			cursor: dto.PageCursor{ID: uuid.Nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cursor.Sort = tt.sort.String()
+			encoded := EncodeCursor(&tt.cursor)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("EncodeCursor() = %q, want URL-safe without padding", encoded)
+			}
+
+			decoded, err := DecodeCursor(encoded, tt.sort)
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if *decoded != tt.cursor {
+				t.Errorf("DecodeCursor() = %+v, want %+v", *decoded, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	issued := EncodeCursor(&dto.PageCursor{Sort: "-created_at", Value: "2026-10-17T08:30:00Z", ID: uuid.New()})
+
+	tests := []struct {
+		name  string
+		value string
+		sort  dto.ListSort
+	}{
+		{name: "not base64", value: "not a cursor!", sort: dto.ParseListSort("")},
+		{name: "padded standard base64", value: base64.StdEncoding.EncodeToString([]byte(`{"s":"-created_at"}`)), sort: dto.ParseListSort("")},
+		{name: "not JSON", value: base64.RawURLEncoding.EncodeToString([]byte("created_at")), sort: dto.ParseListSort("")},
+		{name: "invalid ID", value: base64.RawURLEncoding.EncodeToString([]byte(`{"s":"-created_at","id":"x"}`)), sort: dto.ParseListSort("")},
+		{name: "issued for another field", value: issued, sort: dto.ParseListSort("name")},
+		{name: "issued for another direction", value: issued, sort: dto.ParseListSort("created_at")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeCursor(tt.value, tt.sort)
+			if !errors.Is(err, dto.ErrInvalidCursor) {
+				t.Errorf("DecodeCursor() error = %v, want %v", err, dto.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
+)
+
+// DiffJSON compares two decoded JSON documents and returns the leaf fields that differ, sorted by path.
+// Paths join object keys with "." and list indexes with "[i]" (e.g. "template.spec.containers[0].image").
+func DiffJSON(from, to map[string]interface{}) []dto.FieldChange {
+	fromFields := map[string]interface{}{}
+	toFields := map[string]interface{}{}
+	flattenJSON("", from, fromFields)
+	flattenJSON("", to, toFields)
+
+	changes := []dto.FieldChange{}
+	for path, fromValue := range fromFields {
+		toValue, ok := toFields[path]
+		if !ok || !reflect.DeepEqual(fromValue, toValue) {
+			changes = append(changes, dto.FieldChange{Path: path, From: fromValue, To: toValue})
+		}
+	}
+	for path, toValue := range toFields {
+		if _, ok := fromFields[path]; !ok {
+			changes = append(changes, dto.FieldChange{Path: path, To: toValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// flattenJSON stores every leaf of value under its path; empty objects and lists are leaves too.
+func flattenJSON(path string, value interface{}, out map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && path != "" {
+			out[path] = v
+			return
+		}
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenJSON(childPath, child, out)
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			out[path] = v
+			return
+		}
+		for i, child := range v {
+			flattenJSON(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	default:
+		out[path] = v
+	}
+}
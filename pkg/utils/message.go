@@ -3,6 +3,8 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto/models"
 )
 
 // UnmarshalMessage unmarshals message data (e.g. from NATS) into the provided type.
@@ -14,3 +16,23 @@ func UnmarshalMessage[T any](data []byte) (*T, error) {
 	}
 	return &out, nil
 }
+
+// HeadersFromJSONB converts message headers stored as JSONB back to NATS header form
+func HeadersFromJSONB(stored models.JSONB) map[string][]string {
+	headers := make(map[string][]string, len(stored))
+	for k, v := range stored {
+		switch vals := v.(type) {
+		case []string:
+			headers[k] = vals
+		case []interface{}:
+			for _, val := range vals {
+				if str, ok := val.(string); ok {
+					headers[k] = append(headers[k], str)
+				}
+			}
+		case string:
+			headers[k] = []string{vals}
+		}
+	}
+	return headers
+}
@@ -0,0 +1,12 @@
+package utils
+
+import "regexp"
+
+// requestIDPattern limits request IDs to characters that are safe in a NATS subject token: no ".", "*",
+// ">" or whitespace, so the status subject of one request never matches another's
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9_:-]{1,255}$`)
+
+// ValidRequestID reports whether id may be used as the X-Request-ID of a deployment request
+func ValidRequestID(id string) bool {
+	return requestIDPattern.MatchString(id)
+}
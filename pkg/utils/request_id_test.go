@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want bool
+	}{
+		{name: "UUID", id: "3f8c1a2e-5b7d-4c9e-8f10-2a3b4c5d6e7f", want: true},
+		{name: "schedule run", id: "schedule-3f8c1a2e-5b7d-4c9e-8f10-2a3b4c5d6e7f-1792224000", want: true},
+		{name: "letters, digits, underscores and colons", id: "deploy_web:42", want: true},
+		{name: "longest", id: strings.Repeat("a", 255), want: true},
+		{name: "empty", id: "", want: false},
+		{name: "too long", id: strings.Repeat("a", 256), want: false},
+		{name: "full wildcard", id: ">", want: false},
+		{name: "token wildcard", id: "a.*", want: false},
+		{name: "dot", id: "a.b", want: false},
+		{name: "space", id: "a b", want: false},
+		{name: "tab", id: "a\tb", want: false},
+		{name: "trailing newline", id: "abc\n", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidRequestID(tt.id); got != tt.want {
+				t.Errorf("ValidRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package utils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceListToMap converts a resource list to resource name -> quantity strings.
+func ResourceListToMap(list corev1.ResourceList) map[string]interface{} {
+	result := make(map[string]interface{}, len(list))
+	for name, quantity := range list {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
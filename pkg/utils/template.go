@@ -7,33 +7,56 @@ import (
 	"path"
 	"strings"
 	"text/template"
+
+	"github.com/code-xd/k8s-deployment-manager/pkg/dto"
 )
 
 // TemplateRenderer loads and renders deployment templates.
-// Methods are intended to be invoked in sequence: ExtractTemplateName -> Load -> Execute.
+// Methods are intended to be invoked in sequence: Load -> Execute.
 type TemplateRenderer[T any] struct {
 	basePath     string
 	templateName string
 	content      string
 }
 
-// NewTemplateRenderer creates a new renderer with the given base path and image.
-// ExtractTemplateName is called to derive the template folder from the image (e.g. "nginx:latest" -> "nginx").
-func NewTemplateRenderer[T any](basePath, image string) *TemplateRenderer[T] {
+// NewTemplateRenderer creates a new renderer with the given base path and template name
+// (as resolved by the template registry, e.g. "nginx").
+func NewTemplateRenderer[T any](basePath, templateName string) *TemplateRenderer[T] {
 	return &TemplateRenderer[T]{
 		basePath:     basePath,
-		templateName: extractTemplateName(image),
+		templateName: templateName,
 	}
 }
 
-// Load reads the template file from basePath/templates/<templateName>/deployment.yaml.
+// Load reads the template from basePath/templates/<templateName>/: deployment.yaml followed by every
+// other .yaml file in the folder (except manifest.yaml) in name order, joined as one multi-document
+// template. Each file may itself contain several documents separated by "---".
 func (t *TemplateRenderer[T]) Load() error {
-	tmplPath := path.Join(t.basePath, "templates", t.templateName, "deployment.yaml")
-	content, err := os.ReadFile(tmplPath)
+	dir := path.Join(t.basePath, dto.TemplatesDir, t.templateName)
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return fmt.Errorf("failed to read template %q: %w", tmplPath, err)
+		return fmt.Errorf("failed to read template directory %q: %w", dir, err)
+	}
+
+	files := []string{dto.TemplateDeploymentFile}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || path.Ext(name) != ".yaml" || name == dto.TemplateDeploymentFile || name == dto.TemplateManifestFile {
+			continue
+		}
+		files = append(files, name)
+	}
+
+	documents := make([]string, 0, len(files))
+	for _, name := range files {
+		tmplPath := path.Join(dir, name)
+		content, err := os.ReadFile(tmplPath)
+		if err != nil {
+			return fmt.Errorf("failed to read template %q: %w", tmplPath, err)
+		}
+		documents = append(documents, string(content))
 	}
-	t.content = string(content)
+	t.content = strings.Join(documents, "\n---\n")
 	return nil
 }
 
@@ -51,19 +74,7 @@ func (t *TemplateRenderer[T]) Execute(data T) (string, error) {
 	return buf.String(), nil
 }
 
-// TemplateName returns the extracted template name (e.g. "nginx").
+// TemplateName returns the template name (e.g. "nginx").
 func (t *TemplateRenderer[T]) TemplateName() string {
 	return t.templateName
 }
-
-// extractTemplateName derives the template folder name from the image.
-// e.g. "nginx" -> "nginx", "nginx:latest" -> "nginx", "docker.io/library/nginx:latest" -> "nginx"
-func extractTemplateName(image string) string {
-	if idx := strings.LastIndex(image, ":"); idx > 0 {
-		image = image[:idx]
-	}
-	if idx := strings.LastIndex(image, "/"); idx >= 0 {
-		image = image[idx+1:]
-	}
-	return image
-}